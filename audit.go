@@ -0,0 +1,171 @@
+package client
+
+import (
+	"context"
+	"encoding/hex"
+	"encoding/json"
+	"io"
+	"sync"
+	"time"
+
+	"github.com/celestiaorg/celestia-openrpc/types/blob"
+	"github.com/celestiaorg/celestia-openrpc/types/share"
+)
+
+// AuditRecord is a single entry of the audit log. Every submitted or retrieved
+// blob produces a separate record.
+type AuditRecord struct {
+	Time       time.Time `json:"time"`
+	Operation  string    `json:"operation"`
+	Namespace  string    `json:"namespace,omitempty"`
+	Commitment string    `json:"commitment,omitempty"`
+	Height     uint64    `json:"height,omitempty"`
+	TxHash     string    `json:"tx_hash,omitempty"`
+	Fee        uint64    `json:"fee,omitempty"`
+	// Verified reports the outcome of an inclusion check. It is only set for
+	// operations verifying a proof.
+	Verified *bool  `json:"verified,omitempty"`
+	Error    string `json:"error,omitempty"`
+}
+
+// AuditLogger persists audit records of the DA operations made through the client.
+// Implementations must be safe for concurrent use. Errors returned by Record are
+// not propagated to the caller of the audited operation.
+type AuditLogger interface {
+	Record(AuditRecord) error
+}
+
+// JSONLAuditLogger is an AuditLogger writing every record as a separate
+// JSON line to the underlying writer.
+type JSONLAuditLogger struct {
+	mu  sync.Mutex
+	enc *json.Encoder
+}
+
+// NewJSONLAuditLogger creates a new JSONLAuditLogger writing to w.
+func NewJSONLAuditLogger(w io.Writer) *JSONLAuditLogger {
+	return &JSONLAuditLogger{enc: json.NewEncoder(w)}
+}
+
+// Record implements AuditLogger.
+func (l *JSONLAuditLogger) Record(rec AuditRecord) error {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	return l.enc.Encode(rec)
+}
+
+// auditHook adapts an AuditLogger to the MetricsHook interface in order to
+// record submissions.
+type auditHook struct {
+	log AuditLogger
+}
+
+func (h auditHook) ObserveSubmit(obs SubmitObservation) {
+	rec := AuditRecord{
+		Time:      time.Now(),
+		Operation: obs.Method,
+		Height:    obs.Height,
+		TxHash:    obs.TxHash,
+		Fee:       obs.Fee,
+		Error:     errString(obs.Err),
+	}
+	if len(obs.Blobs) == 0 {
+		_ = h.log.Record(rec)
+		return
+	}
+	for _, b := range obs.Blobs {
+		rec.Namespace = b.Namespace.String()
+		rec.Commitment = hex.EncodeToString(b.Commitment)
+		_ = h.log.Record(rec)
+	}
+}
+
+// multiHook fans out observations to multiple hooks.
+type multiHook []MetricsHook
+
+func (m multiHook) ObserveSubmit(obs SubmitObservation) {
+	for _, hook := range m {
+		hook.ObserveSubmit(obs)
+	}
+}
+
+// auditRetrievals wraps the blob retrieval methods of the client to record
+// them in the audit log.
+func auditRetrievals(c *Client, log AuditLogger) {
+	get := c.Blob.Get
+	c.Blob.Get = func(
+		ctx context.Context,
+		height uint64,
+		namespace share.Namespace,
+		commitment blob.Commitment,
+	) (*blob.Blob, error) {
+		b, err := get(ctx, height, namespace, commitment)
+		_ = log.Record(AuditRecord{
+			Time:       time.Now(),
+			Operation:  "blob.Get",
+			Namespace:  namespace.String(),
+			Commitment: hex.EncodeToString(commitment),
+			Height:     height,
+			Error:      errString(err),
+		})
+		return b, err
+	}
+
+	getAll := c.Blob.GetAll
+	c.Blob.GetAll = func(ctx context.Context, height uint64, namespaces []share.Namespace) ([]*blob.Blob, error) {
+		blobs, err := getAll(ctx, height, namespaces)
+		if err != nil || len(blobs) == 0 {
+			for _, ns := range namespaces {
+				_ = log.Record(AuditRecord{
+					Time:      time.Now(),
+					Operation: "blob.GetAll",
+					Namespace: ns.String(),
+					Height:    height,
+					Error:     errString(err),
+				})
+			}
+			return blobs, err
+		}
+		for _, b := range blobs {
+			_ = log.Record(AuditRecord{
+				Time:       time.Now(),
+				Operation:  "blob.GetAll",
+				Namespace:  share.Namespace(b.Namespace().Bytes()).String(),
+				Commitment: hex.EncodeToString(b.Commitment),
+				Height:     height,
+			})
+		}
+		return blobs, err
+	}
+
+	included := c.Blob.Included
+	c.Blob.Included = func(
+		ctx context.Context,
+		height uint64,
+		namespace share.Namespace,
+		proof *blob.Proof,
+		commitment blob.Commitment,
+	) (bool, error) {
+		ok, err := included(ctx, height, namespace, proof, commitment)
+		rec := AuditRecord{
+			Time:       time.Now(),
+			Operation:  "blob.Included",
+			Namespace:  namespace.String(),
+			Commitment: hex.EncodeToString(commitment),
+			Height:     height,
+			Error:      errString(err),
+		}
+		if err == nil {
+			rec.Verified = &ok
+		}
+		_ = log.Record(rec)
+		return ok, err
+	}
+}
+
+func errString(err error) string {
+	if err == nil {
+		return ""
+	}
+	return err.Error()
+}
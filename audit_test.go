@@ -0,0 +1,97 @@
+package client
+
+import (
+	"bytes"
+	"context"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/celestiaorg/celestia-openrpc/types/blob"
+	"github.com/celestiaorg/celestia-openrpc/types/share"
+)
+
+// auditRecords is an AuditLogger collecting the records in memory.
+type auditRecords []AuditRecord
+
+func (r *auditRecords) Record(rec AuditRecord) error {
+	*r = append(*r, rec)
+	return nil
+}
+
+func TestJSONLAuditLogger(t *testing.T) {
+	var buf bytes.Buffer
+	log := NewJSONLAuditLogger(&buf)
+	require.NoError(t, log.Record(AuditRecord{Operation: "blob.Submit", Height: 1}))
+	require.NoError(t, log.Record(AuditRecord{Operation: "blob.Get", Error: "blob: not found"}))
+
+	lines := bytes.Split(bytes.TrimSpace(buf.Bytes()), []byte("\n"))
+	require.Len(t, lines, 2)
+	var rec AuditRecord
+	require.NoError(t, json.Unmarshal(lines[1], &rec))
+	require.Equal(t, "blob.Get", rec.Operation)
+	require.Equal(t, "blob: not found", rec.Error)
+}
+
+func TestAuditHookRecordsEveryBlob(t *testing.T) {
+	var records auditRecords
+	b1, b2 := testBlob(t, "one"), testBlob(t, "two")
+	auditHook{log: &records}.ObserveSubmit(SubmitObservation{
+		Method: "blob.Submit",
+		Blobs:  observeBlobs([]*blob.Blob{b1, b2}),
+		Height: 10,
+		Fee:    5,
+	})
+
+	require.Len(t, records, 2)
+	for i, b := range []*blob.Blob{b1, b2} {
+		require.Equal(t, "blob.Submit", records[i].Operation)
+		require.EqualValues(t, 10, records[i].Height)
+		require.EqualValues(t, 5, records[i].Fee)
+		require.Equal(t, hex.EncodeToString(b.Commitment), records[i].Commitment)
+	}
+}
+
+func TestAuditRetrievals(t *testing.T) {
+	b := testBlob(t, "hello")
+	namespace := share.Namespace(b.Namespace().Bytes())
+
+	c := &Client{}
+	c.Blob.GetAll = func(context.Context, uint64, []share.Namespace) ([]*blob.Blob, error) {
+		return nil, blob.ErrBlobNotFound
+	}
+	c.Blob.Included = func(context.Context, uint64, share.Namespace, *blob.Proof, blob.Commitment) (bool, error) {
+		return true, nil
+	}
+	c.Blob.Get = func(context.Context, uint64, share.Namespace, blob.Commitment) (*blob.Blob, error) {
+		return nil, errors.New("connection refused")
+	}
+
+	var records auditRecords
+	auditRetrievals(c, &records)
+
+	ctx := context.Background()
+	_, err := c.Blob.GetAll(ctx, 3, []share.Namespace{namespace})
+	require.ErrorIs(t, err, blob.ErrBlobNotFound)
+	included, err := c.Blob.Included(ctx, 3, namespace, &blob.Proof{}, b.Commitment)
+	require.NoError(t, err)
+	require.True(t, included)
+	_, err = c.Blob.Get(ctx, 3, namespace, b.Commitment)
+	require.Error(t, err)
+
+	require.Len(t, records, 3)
+	require.Equal(t, "blob.GetAll", records[0].Operation)
+	require.Equal(t, namespace.String(), records[0].Namespace)
+	require.Equal(t, blob.ErrBlobNotFound.Error(), records[0].Error)
+
+	require.Equal(t, "blob.Included", records[1].Operation)
+	require.NotNil(t, records[1].Verified)
+	require.True(t, *records[1].Verified)
+
+	require.Equal(t, "blob.Get", records[2].Operation)
+	require.Nil(t, records[2].Verified)
+	require.Equal(t, "connection refused", records[2].Error)
+}
@@ -0,0 +1,99 @@
+package client
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strings"
+	"sync"
+)
+
+// AuthProvider provides the tokens the client authenticates with, e.g. fetched
+// from a secret store or minted on expiry.
+type AuthProvider interface {
+	// Token returns the current token.
+	Token(ctx context.Context) (string, error)
+	// Refresh returns a new token after the current one was rejected by the node.
+	Refresh(ctx context.Context) (string, error)
+}
+
+// StaticToken is an AuthProvider always providing the same token.
+type StaticToken string
+
+func (t StaticToken) Token(context.Context) (string, error) {
+	return string(t), nil
+}
+
+func (t StaticToken) Refresh(context.Context) (string, error) {
+	return string(t), nil
+}
+
+// authHeader returns a copy of base with the token set as the bearer token.
+func authHeader(base http.Header, token string) http.Header {
+	header := base.Clone()
+	if header == nil {
+		header = http.Header{}
+	}
+	if token != "" {
+		header.Set(AuthKey, fmt.Sprintf("Bearer %s", token))
+	}
+	return header
+}
+
+// unauthorizedStatus is part of the message of errors of HTTP requests rejected with status 401.
+const unauthorizedStatus = "http status 401"
+
+// isUnauthorized reports whether the node rejected the token the HTTP request of the call was made with.
+func isUnauthorized(err error) bool {
+	return err != nil && strings.Contains(err.Error(), unauthorizedStatus)
+}
+
+// reauthenticator reconnects the endpoints with a refreshed token once the node rejects the current one.
+type reauthenticator struct {
+	provider AuthProvider
+	base     http.Header
+	proxy    *proxy
+
+	mu sync.Mutex
+	// generation is incremented with every refresh, so concurrently rejected calls refresh only once.
+	generation uint64
+}
+
+// intercept retries calls rejected as unauthorized once with a refreshed token.
+func (r *reauthenticator) intercept(ctx context.Context, c *call, next invoker) ([]interface{}, error) {
+	r.mu.Lock()
+	generation := r.generation
+	r.mu.Unlock()
+
+	out, err := next(ctx, c)
+	if !isUnauthorized(err) {
+		return out, err
+	}
+	if rerr := r.refresh(ctx, generation); rerr != nil {
+		return nil, fmt.Errorf("%w (refreshing token: %w)", err, rerr)
+	}
+	return next(ctx, c)
+}
+
+// refresh refreshes the token and reconnects all endpoints with it,
+// unless that happened already since the given generation.
+func (r *reauthenticator) refresh(ctx context.Context, generation uint64) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if r.generation != generation {
+		return nil
+	}
+
+	token, err := r.provider.Refresh(ctx)
+	if err != nil {
+		return err
+	}
+	header := authHeader(r.base, token)
+	for _, ep := range r.proxy.endpoints {
+		if err := ep.connect(ctx, header); err != nil {
+			return fmt.Errorf("reconnecting to %s: %w", ep.addr, err)
+		}
+	}
+	r.generation++
+	return nil
+}
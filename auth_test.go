@@ -0,0 +1,72 @@
+package client
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+// rotatingToken provides "expired" until it's refreshed to "fresh".
+type rotatingToken struct {
+	refreshes atomic.Int32
+}
+
+func (p *rotatingToken) Token(context.Context) (string, error) {
+	return "expired", nil
+}
+
+func (p *rotatingToken) Refresh(context.Context) (string, error) {
+	p.refreshes.Add(1)
+	return "fresh", nil
+}
+
+func TestAuthProviderRefresh(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get(AuthKey) != "Bearer fresh" {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		req, err := io.ReadAll(r.Body)
+		require.NoError(t, err)
+		_, _ = w.Write(rpcResponse(t, req, true))
+	}))
+	defer srv.Close()
+
+	provider := &rotatingToken{}
+	c, err := NewClient(context.Background(), srv.URL, "", WithAuthProvider(provider))
+	require.NoError(t, err)
+	defer c.Close()
+
+	ready, err := c.Node.Ready(context.Background())
+	require.NoError(t, err)
+	require.True(t, ready)
+	// the token is refreshed once and used by all later calls
+	require.EqualValues(t, 1, provider.refreshes.Load())
+}
+
+func TestIsUnauthorized(t *testing.T) {
+	require.True(t, isUnauthorized(errors.New("request failed, http status 401 Unauthorized")))
+	for _, err := range []error{
+		nil,
+		errors.New("header: height 401 is from the future"),
+		errors.New("request failed, http status 403 Forbidden"),
+		&RPCError{Code: 401, Message: "blob: not found"},
+	} {
+		require.False(t, isUnauthorized(err), err)
+	}
+}
+
+func TestStaticToken(t *testing.T) {
+	token, err := StaticToken("token").Token(context.Background())
+	require.NoError(t, err)
+	require.Equal(t, "token", token)
+	token, err = StaticToken("token").Refresh(context.Background())
+	require.NoError(t, err)
+	require.Equal(t, "token", token)
+}
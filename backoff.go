@@ -0,0 +1,29 @@
+package client
+
+import (
+	"math"
+	"math/rand"
+	"time"
+)
+
+const (
+	defaultBackoffMinDelay = 100 * time.Millisecond
+	defaultBackoffMaxDelay = 5 * time.Second
+)
+
+// backoff computes exponentially growing, jittered delays between attempts.
+type backoff struct {
+	minDelay time.Duration
+	maxDelay time.Duration
+}
+
+// next returns the delay to wait before the given attempt, starting at zero.
+func (b backoff) next(attempt int) time.Duration {
+	delay := float64(b.minDelay) * math.Pow(2, float64(attempt))
+	//nolint:gosec
+	delay += rand.Float64() * float64(b.minDelay)
+	if delay > float64(b.maxDelay) {
+		return b.maxDelay
+	}
+	return time.Duration(delay)
+}
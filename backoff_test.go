@@ -0,0 +1,38 @@
+package client
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestBackoff(t *testing.T) {
+	b := backoff{minDelay: 100 * time.Millisecond, maxDelay: time.Second}
+	for attempt, base := range []time.Duration{100, 200, 400, 800} {
+		base *= time.Millisecond
+		for i := 0; i < 20; i++ {
+			delay := b.next(attempt)
+			require.GreaterOrEqual(t, delay, min(base, b.maxDelay))
+			// jittered by up to the minimum delay
+			require.LessOrEqual(t, delay, min(base+b.minDelay, b.maxDelay))
+		}
+	}
+	require.Equal(t, time.Second, b.next(10))
+	require.Equal(t, time.Second, b.next(1000))
+}
+
+func TestReconnectBackoffOption(t *testing.T) {
+	cfg := newConfig()
+	require.Equal(t, backoff{minDelay: defaultBackoffMinDelay, maxDelay: defaultBackoffMaxDelay}, cfg.reconnectBackoff())
+	require.Empty(t, cfg.rpcOptions())
+
+	cfg = newConfig(WithReconnectBackoff(time.Second, 100*time.Millisecond))
+	// the maximum is raised to the minimum
+	require.Equal(t, backoff{minDelay: time.Second, maxDelay: time.Second}, cfg.reconnectBackoff())
+	require.Len(t, cfg.rpcOptions(), 1)
+
+	cfg = newConfig(WithoutReconnect())
+	require.True(t, cfg.noReconnect)
+	require.Len(t, cfg.rpcOptions(), 1)
+}
@@ -0,0 +1,160 @@
+package client
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+)
+
+// Batch collects calls to be sent to the node in a single JSON-RPC batch request,
+// saving a round trip per call, e.g. when fetching a range of headers.
+// Batched calls bypass the options applied to regular calls, such as retries or caching.
+type Batch struct {
+	client *Client
+	calls  []*BatchCall
+}
+
+// BatchCall is a call added to a Batch. Its result is available once the batch is sent.
+type BatchCall struct {
+	method string
+	params []interface{}
+	result interface{}
+	err    error
+}
+
+// Err returns the error the call failed with, if any.
+func (bc *BatchCall) Err() error {
+	return bc.err
+}
+
+// Batch returns a new, empty batch of calls.
+func (c *Client) Batch() *Batch {
+	return &Batch{client: c}
+}
+
+// Add adds a call of the method, e.g. "header.GetByHeight", to the batch. Once the batch
+// is sent, the result of the call is decoded into result, which must be a pointer or nil.
+func (b *Batch) Add(method string, result interface{}, params ...interface{}) *BatchCall {
+	if params == nil {
+		params = []interface{}{}
+	}
+	bc := &BatchCall{method: method, params: params, result: result}
+	b.calls = append(b.calls, bc)
+	return bc
+}
+
+// Len returns the number of calls in the batch.
+func (b *Batch) Len() int {
+	return len(b.calls)
+}
+
+type batchRequest struct {
+	Jsonrpc string        `json:"jsonrpc"`
+	ID      int           `json:"id"`
+	Method  string        `json:"method"`
+	Params  []interface{} `json:"params"`
+}
+
+type batchResponse struct {
+	ID     int             `json:"id"`
+	Result json.RawMessage `json:"result"`
+	Error  *errorObject    `json:"error"`
+}
+
+// Send sends all calls of the batch to the most preferred endpoint in a single request,
+// over HTTP unless the client was constructed with a TransportFunc. The returned error
+// reports failures of the request as a whole, while failures of individual calls are
+// reported by their Err method. Like other calls, batches fail with ErrClientClosed once
+// the client is closed and batches in flight are awaited by Shutdown.
+func (b *Batch) Send(ctx context.Context) error {
+	if !b.client.beginCall() {
+		return fmt.Errorf("batch: %w", ErrClientClosed)
+	}
+	defer b.client.calls.Done()
+	if len(b.calls) == 0 {
+		return nil
+	}
+	ep := b.client.proxy.endpoints[b.client.proxy.rank()[0]]
+
+	reqs := make([]batchRequest, len(b.calls))
+	for i, bc := range b.calls {
+		if perm := b.client.methodPerm(bc.method); b.client.readOnly && (perm == permWrite || perm == permAdmin) {
+			return fmt.Errorf("%s: %w", bc.method, ErrReadOnlyClient)
+		}
+		reqs[i] = batchRequest{Jsonrpc: "2.0", ID: i, Method: bc.method, Params: bc.params}
+	}
+	body, err := json.Marshal(reqs)
+	if err != nil {
+		return fmt.Errorf("marshaling batch: %w", err)
+	}
+
+	respBody, err := ep.post(ctx, body)
+	if err != nil {
+		return fmt.Errorf("sending batch: %w", err)
+	}
+	defer respBody.Close()
+
+	var resps []batchResponse
+	if err := json.NewDecoder(respBody).Decode(&resps); err != nil {
+		return fmt.Errorf("unmarshaling batch response: %w", err)
+	}
+
+	for _, bc := range b.calls {
+		bc.err = errors.New("no response received")
+	}
+	for _, r := range resps {
+		if r.ID < 0 || r.ID >= len(b.calls) {
+			continue
+		}
+		bc := b.calls[r.ID]
+		switch {
+		case r.Error != nil:
+			bc.err = mapNodeError(r.Error.rpcError())
+		case bc.result != nil:
+			bc.err = json.Unmarshal(r.Result, bc.result)
+		default:
+			bc.err = nil
+		}
+	}
+	return nil
+}
+
+// post sends the encoded request to the endpoint and returns the encoded response. Requests
+// are handed to a TransportFunc, if configured, and sent with the HTTP client of the endpoint otherwise.
+func (ep *endpoint) post(ctx context.Context, body []byte) (io.ReadCloser, error) {
+	if f, ok := ep.transport.(TransportFunc); ok {
+		return f(withUnixClient(ctx, ep.unix), ep.addr, ep.requestHeader(), body)
+	}
+
+	url, client := httpEndpoint(ep.addr, http.DefaultClient, ep.unix)
+	if ep.httpClient != nil {
+		client = ep.httpClient
+	}
+	return postHTTP(ctx, client, url, ep.requestHeader(), body)
+}
+
+// postHTTP posts the encoded request to url with the client and returns the body of the response.
+func postHTTP(ctx context.Context, client *http.Client, url string, header http.Header, body []byte) (io.ReadCloser, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, url, bytes.NewReader(body))
+	if err != nil {
+		return nil, err
+	}
+	for key, values := range header {
+		req.Header[key] = values
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	if resp.StatusCode != http.StatusOK {
+		resp.Body.Close()
+		return nil, fmt.Errorf("http status %s", resp.Status)
+	}
+	return resp.Body, nil
+}
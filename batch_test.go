@@ -0,0 +1,103 @@
+package client
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+// batchHandler answers the first call of a batch with true and fails all others as
+// with a missing blob.
+func batchHandler(t *testing.T, body []byte) []byte {
+	var reqs []batchRequest
+	require.NoError(t, json.Unmarshal(body, &reqs))
+
+	resps := make([]map[string]interface{}, len(reqs))
+	for i, req := range reqs {
+		resps[i] = map[string]interface{}{"jsonrpc": "2.0", "id": req.ID}
+		if i == 0 {
+			resps[i]["result"] = true
+		} else {
+			resps[i]["error"] = map[string]interface{}{"code": 1, "message": "blob: not found", "data": map[string]int{"height": 1}}
+		}
+	}
+	out, err := json.Marshal(resps)
+	require.NoError(t, err)
+	return out
+}
+
+// countingRoundTripper counts the requests sent through it.
+type countingRoundTripper struct {
+	requests int
+}
+
+func (rt *countingRoundTripper) RoundTrip(req *http.Request) (*http.Response, error) {
+	rt.requests++
+	return http.DefaultTransport.RoundTrip(req)
+}
+
+func TestBatchSend(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		require.Equal(t, "Bearer token", r.Header.Get("Authorization"))
+		body, err := io.ReadAll(r.Body)
+		require.NoError(t, err)
+		_, _ = w.Write(batchHandler(t, body))
+	}))
+	defer srv.Close()
+
+	rt := &countingRoundTripper{}
+	c := &Client{proxy: newProxy([]*endpoint{{
+		addr:       srv.URL,
+		header:     http.Header{"Authorization": {"Bearer token"}},
+		httpClient: &http.Client{Transport: rt},
+	}})}
+
+	b := c.Batch()
+	var ready bool
+	first := b.Add("node.Ready", &ready)
+	second := b.Add("blob.Get", nil, 1, nil, nil)
+	require.NoError(t, b.Send(context.Background()))
+
+	require.NoError(t, first.Err())
+	require.True(t, ready)
+	require.ErrorIs(t, second.Err(), ErrBlobNotFound)
+	var rpcErr *RPCError
+	require.ErrorAs(t, second.Err(), &rpcErr)
+	require.JSONEq(t, `{"height":1}`, string(rpcErr.Data))
+	// the configured client of the endpoint is used
+	require.Equal(t, 1, rt.requests)
+}
+
+func TestBatchSendTransportFunc(t *testing.T) {
+	var sent int
+	transport := TransportFunc(func(_ context.Context, _ string, _ http.Header, req []byte) (io.ReadCloser, error) {
+		sent++
+		return io.NopCloser(bytes.NewReader(batchHandler(t, req))), nil
+	})
+	c := &Client{proxy: newProxy([]*endpoint{{addr: "memory", transport: transport}})}
+
+	b := c.Batch()
+	var ready bool
+	b.Add("node.Ready", &ready)
+	require.NoError(t, b.Send(context.Background()))
+	require.True(t, ready)
+	require.Equal(t, 1, sent)
+}
+
+func TestBatchSendStatus(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}))
+	defer srv.Close()
+
+	c := &Client{proxy: newProxy([]*endpoint{{addr: srv.URL}})}
+	b := c.Batch()
+	b.Add("node.Ready", nil)
+	require.ErrorContains(t, b.Send(context.Background()), "http status 503")
+}
@@ -0,0 +1,287 @@
+package client
+
+import (
+	"context"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/celestiaorg/celestia-openrpc/types/blob"
+	"github.com/celestiaorg/celestia-openrpc/types/share"
+)
+
+const (
+	// DefaultBatchSize is the size in bytes of the payloads flushed by a Batcher by default.
+	DefaultBatchSize = 512 * 1024
+	// DefaultBatchDelay is the time after which a Batcher flushes its payloads by default.
+	DefaultBatchDelay = 5 * time.Second
+)
+
+// ErrBatcherClosed is returned by the methods of a Batcher once it is closed.
+var ErrBatcherClosed = errors.New("client: batcher is closed")
+
+// BatcherOption is the functional option that is applied to the batcher
+// to configure its flush policy.
+type BatcherOption func(b *Batcher)
+
+// WithBatchSize is an option that allows to specify the size in bytes of the payloads
+// that triggers a flush.
+func WithBatchSize(size int) BatcherOption {
+	return func(b *Batcher) {
+		b.maxSize = size
+	}
+}
+
+// WithBatchDelay is an option that allows to specify the maximum time a payload waits
+// for a flush. Zero disables flushes triggered by time.
+func WithBatchDelay(delay time.Duration) BatcherOption {
+	return func(b *Batcher) {
+		b.maxDelay = delay
+	}
+}
+
+// WithBatchPacking is an option that packs the payloads of a flush into a single blob,
+// instead of submitting a blob for every payload. Use UnpackPayloads to split the data
+// of such a blob back into the payloads.
+func WithBatchPacking() BatcherOption {
+	return func(b *Batcher) {
+		b.pack = true
+	}
+}
+
+// WithBatchSubmitOptions is an option that allows to specify the options of the submissions.
+func WithBatchSubmitOptions(opts *blob.SubmitOptions) BatcherOption {
+	return func(b *Batcher) {
+		b.submitOpts = opts
+	}
+}
+
+// WithFlushCallback is an option that allows to specify a function called with the receipt
+// of every flush once its submission completed. Flushes are submitted one at a time, so the
+// callback delays the next flush, and it must not call the methods of the batcher.
+func WithFlushCallback(onFlush func(BatchReceipt)) BatcherOption {
+	return func(b *Batcher) {
+		b.onFlush = onFlush
+	}
+}
+
+// BatchReceipt is the outcome of a flush of a Batcher.
+type BatchReceipt struct {
+	// Payloads is the number of payloads flushed.
+	Payloads int
+	// Blobs are the blobs the payloads were submitted in.
+	Blobs []*blob.Blob
+	// Height is the height the blobs were included at. Zero if the submission failed.
+	Height uint64
+	// Err is the error the submission failed with.
+	Err error
+}
+
+// Batcher accumulates small payloads of a rollup and submits them together, in a single
+// PayForBlobs transaction, once their size reaches a threshold or the first of them waited
+// for long enough. Flushes are submitted one after the other, in the order of the payloads.
+type Batcher struct {
+	submit     func(context.Context, []*blob.Blob, *blob.SubmitOptions) (uint64, error)
+	namespace  share.Namespace
+	maxSize    int
+	maxDelay   time.Duration
+	pack       bool
+	submitOpts *blob.SubmitOptions
+	onFlush    func(BatchReceipt)
+	clock      Clock
+
+	mu      sync.Mutex
+	pending [][]byte
+	size    int
+	// generation counts the flushes, so timers of flushed payloads are ignored
+	generation uint64
+	last       *batch
+	closed     bool
+
+	batches chan *batch
+	// stop stops the timers once the batcher is closed
+	stop    chan struct{}
+	stopped chan struct{}
+}
+
+// batch is a flush handed to the goroutine submitting the payloads.
+type batch struct {
+	payloads [][]byte
+	receipt  BatchReceipt
+	done     chan struct{}
+}
+
+// NewBatcher returns a batcher submitting the payloads as blobs of the namespace with the client.
+func (c *Client) NewBatcher(namespace share.Namespace, opts ...BatcherOption) (*Batcher, error) {
+	if err := namespace.ValidateForBlob(); err != nil {
+		return nil, err
+	}
+	b := &Batcher{
+		submit: func(ctx context.Context, blobs []*blob.Blob, opts *blob.SubmitOptions) (uint64, error) {
+			return c.Blob.Submit(ctx, blobs, opts)
+		},
+		namespace: namespace,
+		maxSize:   DefaultBatchSize,
+		maxDelay:  DefaultBatchDelay,
+		clock:     realClock{},
+		batches:   make(chan *batch),
+		stop:      make(chan struct{}),
+		stopped:   make(chan struct{}),
+	}
+	for _, opt := range opts {
+		opt(b)
+	}
+	if b.maxSize <= 0 {
+		return nil, fmt.Errorf("invalid batch size %d", b.maxSize)
+	}
+	go b.run()
+	return b, nil
+}
+
+// Add adds the payload to the next flush. If the payload makes the pending ones reach the
+// batch size, they're flushed right away, which blocks while the previous flush is submitted.
+func (b *Batcher) Add(payload []byte) error {
+	if len(payload) == 0 {
+		return errors.New("client: empty payload")
+	}
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	if b.closed {
+		return ErrBatcherClosed
+	}
+
+	if len(b.pending) > 0 && b.size+len(payload) > b.maxSize {
+		b.flushLocked()
+	}
+	b.pending = append(b.pending, append([]byte(nil), payload...))
+	b.size += len(payload)
+	switch {
+	case b.size >= b.maxSize:
+		b.flushLocked()
+	case len(b.pending) == 1 && b.maxDelay > 0:
+		go b.flushAfter(b.generation)
+	}
+	return nil
+}
+
+// Flush flushes the pending payloads and waits for the submission of all the flushes so far,
+// returning the error of the last one.
+func (b *Batcher) Flush(ctx context.Context) error {
+	b.mu.Lock()
+	if b.closed {
+		b.mu.Unlock()
+		return ErrBatcherClosed
+	}
+	last := b.flushLocked()
+	b.mu.Unlock()
+
+	if last == nil {
+		return nil
+	}
+	select {
+	case <-last.done:
+		return last.receipt.Err
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+// Close flushes the pending payloads and waits for the submission of all the flushes.
+func (b *Batcher) Close() {
+	b.mu.Lock()
+	if !b.closed {
+		b.closed = true
+		b.flushLocked()
+		close(b.batches)
+		close(b.stop)
+	}
+	b.mu.Unlock()
+	<-b.stopped
+}
+
+// flushLocked hands the pending payloads to the submitting goroutine, returning the last
+// flush. It blocks while the previous flush is submitted, so it must not be called by that
+// goroutine.
+func (b *Batcher) flushLocked() *batch {
+	if len(b.pending) == 0 {
+		return b.last
+	}
+	bt := &batch{payloads: b.pending, done: make(chan struct{})}
+	b.pending, b.size = nil, 0
+	b.generation++
+	b.last = bt
+	b.batches <- bt
+	return bt
+}
+
+// flushAfter flushes the payloads of the generation once they waited for the maximum delay.
+func (b *Batcher) flushAfter(generation uint64) {
+	select {
+	case <-b.clock.After(b.maxDelay):
+	case <-b.stop:
+		return
+	}
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	if !b.closed && b.generation == generation {
+		b.flushLocked()
+	}
+}
+
+// run submits the flushes one after the other, until the batcher is closed.
+func (b *Batcher) run() {
+	defer close(b.stopped)
+	for bt := range b.batches {
+		bt.receipt = b.submitBatch(bt.payloads)
+		if b.onFlush != nil {
+			b.onFlush(bt.receipt)
+		}
+		close(bt.done)
+	}
+}
+
+func (b *Batcher) submitBatch(payloads [][]byte) BatchReceipt {
+	receipt := BatchReceipt{Payloads: len(payloads)}
+	data := payloads
+	if b.pack {
+		data = [][]byte{PackPayloads(payloads)}
+	}
+	for _, d := range data {
+		bl, err := blob.NewBlobV0(b.namespace, d)
+		if err != nil {
+			receipt.Err = err
+			return receipt
+		}
+		receipt.Blobs = append(receipt.Blobs, bl)
+	}
+	receipt.Height, receipt.Err = b.submit(context.Background(), receipt.Blobs, b.submitOpts)
+	return receipt
+}
+
+// PackPayloads packs the payloads into the data of a single blob, each prefixed by its
+// length as an unsigned varint.
+func PackPayloads(payloads [][]byte) []byte {
+	var data []byte
+	for _, p := range payloads {
+		data = binary.AppendUvarint(data, uint64(len(p)))
+		data = append(data, p...)
+	}
+	return data
+}
+
+// UnpackPayloads splits the data packed by PackPayloads back into the payloads.
+func UnpackPayloads(data []byte) ([][]byte, error) {
+	var payloads [][]byte
+	for len(data) > 0 {
+		size, n := binary.Uvarint(data)
+		if n <= 0 || size > uint64(len(data)-n) {
+			return nil, fmt.Errorf("malformed payload %d", len(payloads))
+		}
+		data = data[n:]
+		payloads = append(payloads, data[:size:size])
+		data = data[size:]
+	}
+	return payloads, nil
+}
@@ -0,0 +1,109 @@
+package client
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/celestiaorg/celestia-openrpc/types/blob"
+	"github.com/celestiaorg/celestia-openrpc/types/share"
+)
+
+// manualClock is a Clock whose timers fire when the test sends on fire.
+type manualClock struct {
+	fire chan time.Time
+}
+
+func (c manualClock) Now() time.Time {
+	return time.Time{}
+}
+
+func (c manualClock) After(time.Duration) <-chan time.Time {
+	return c.fire
+}
+
+func (c manualClock) NewTimer(time.Duration) Timer {
+	return chanTimer(c.fire)
+}
+
+func newTestBatcher(t *testing.T, fail error, opts ...BatcherOption) (*Batcher, <-chan BatchReceipt) {
+	t.Helper()
+	namespace, err := share.NewBlobNamespaceV0([]byte{1, 2, 3, 4})
+	require.NoError(t, err)
+
+	var height uint64
+	c := &Client{}
+	c.Blob.Submit = func(context.Context, []*blob.Blob, *blob.SubmitOptions) (uint64, error) {
+		height++
+		return height, fail
+	}
+	receipts := make(chan BatchReceipt, 10)
+	opts = append(opts, WithFlushCallback(func(r BatchReceipt) { receipts <- r }))
+	b, err := c.NewBatcher(namespace, opts...)
+	require.NoError(t, err)
+	return b, receipts
+}
+
+func TestBatcherSize(t *testing.T) {
+	b, receipts := newTestBatcher(t, nil, WithBatchSize(10), WithBatchDelay(0))
+	defer b.Close()
+
+	require.NoError(t, b.Add([]byte("hello")))
+	require.NoError(t, b.Add([]byte("world")))
+	r := <-receipts
+	require.Equal(t, 2, r.Payloads)
+	require.Len(t, r.Blobs, 2)
+	require.Equal(t, []byte("world"), r.Blobs[1].Data)
+	require.EqualValues(t, 1, r.Height)
+
+	// a payload exceeding the batch size flushes the pending ones first
+	require.NoError(t, b.Add([]byte("abc")))
+	require.NoError(t, b.Add([]byte("defghijk")))
+	r = <-receipts
+	require.Equal(t, 1, r.Payloads)
+	require.Equal(t, []byte("abc"), r.Blobs[0].Data)
+
+	require.ErrorContains(t, b.Add(nil), "empty payload")
+}
+
+func TestBatcherDelay(t *testing.T) {
+	clock := manualClock{fire: make(chan time.Time)}
+	b, receipts := newTestBatcher(t, nil, WithBatchPacking())
+	b.clock = clock
+	defer b.Close()
+
+	require.NoError(t, b.Add([]byte("hello")))
+	require.NoError(t, b.Add([]byte("world")))
+	clock.fire <- time.Time{}
+	r := <-receipts
+	require.Equal(t, 2, r.Payloads)
+	require.Len(t, r.Blobs, 1)
+
+	payloads, err := UnpackPayloads(r.Blobs[0].Data)
+	require.NoError(t, err)
+	require.Equal(t, [][]byte{[]byte("hello"), []byte("world")}, payloads)
+	_, err = UnpackPayloads([]byte{10, 'a'})
+	require.Error(t, err)
+}
+
+func TestBatcherFlushAndClose(t *testing.T) {
+	failure := errors.New("insufficient funds")
+	b, receipts := newTestBatcher(t, failure, WithBatchDelay(0))
+
+	require.NoError(t, b.Flush(context.Background()))
+	require.NoError(t, b.Add([]byte("hello")))
+	require.ErrorIs(t, b.Flush(context.Background()), failure)
+	require.ErrorIs(t, (<-receipts).Err, failure)
+
+	// closing flushes the pending payloads
+	require.NoError(t, b.Add([]byte("world")))
+	b.Close()
+	r := <-receipts
+	require.Equal(t, []byte("world"), r.Blobs[0].Data)
+	require.ErrorIs(t, b.Add([]byte("late")), ErrBatcherClosed)
+	require.ErrorIs(t, b.Flush(context.Background()), ErrBatcherClosed)
+	b.Close()
+}
@@ -0,0 +1,54 @@
+package client
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/celestiaorg/celestia-openrpc/types/blob"
+	"github.com/celestiaorg/celestia-openrpc/types/share"
+)
+
+func TestCompressedBlob(t *testing.T) {
+	namespace, err := share.NewBlobNamespaceV0([]byte{1, 2, 3, 4})
+	require.NoError(t, err)
+	data := []byte(strings.Repeat("compressible ", 1000))
+
+	for _, codec := range []blob.Codec{blob.CodecGzip, blob.CodecZstd} {
+		t.Run(codec.String(), func(t *testing.T) {
+			b, err := blob.NewCompressedBlob(namespace, data, codec)
+			require.NoError(t, err)
+			require.Less(t, len(b.Data), len(data))
+			got, ok := b.Codec()
+			require.True(t, ok)
+			require.Equal(t, codec, got)
+
+			decompressed, err := b.DecompressedData()
+			require.NoError(t, err)
+			require.Equal(t, data, decompressed)
+
+			b.Data = b.Data[:len(b.Data)-4]
+			_, err = b.DecompressedData()
+			require.ErrorIs(t, err, blob.ErrInvalidCompression)
+		})
+	}
+
+	// data which doesn't shrink is stored as is
+	b, err := blob.NewCompressedBlob(namespace, []byte("x"), blob.CodecGzip)
+	require.NoError(t, err)
+	codec, ok := b.Codec()
+	require.True(t, ok)
+	require.Equal(t, blob.CodecNone, codec)
+	decompressed, err := b.DecompressedData()
+	require.NoError(t, err)
+	require.Equal(t, []byte("x"), decompressed)
+
+	// blobs which weren't compressed are returned as is
+	plain := testBlob(t, "plain")
+	_, ok = plain.Codec()
+	require.False(t, ok)
+	decompressed, err = plain.DecompressedData()
+	require.NoError(t, err)
+	require.Equal(t, plain.Data, decompressed)
+}
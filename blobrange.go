@@ -0,0 +1,47 @@
+package client
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/celestiaorg/celestia-openrpc/types/blob"
+	"github.com/celestiaorg/celestia-openrpc/types/header"
+	"github.com/celestiaorg/celestia-openrpc/types/share"
+)
+
+// GetBlobRange returns the shares of the blob along with their proof. The node is asked for
+// exactly the shares of the blob, located by the index it reports for retrieved blobs, so
+// the proof covers the blob only instead of the whole namespace.
+func (c *Client) GetBlobRange(
+	ctx context.Context,
+	height uint64,
+	namespace share.Namespace,
+	commitment blob.Commitment,
+) (*share.GetRangeResult, error) {
+	b, err := c.Blob.Get(ctx, height, namespace, commitment)
+	if err != nil {
+		return nil, err
+	}
+	eh, err := c.Header.GetByHeight(ctx, height)
+	if err != nil {
+		return nil, err
+	}
+	width, err := squareWidth(eh)
+	if err != nil {
+		return nil, err
+	}
+	start, end, err := b.ShareRange(width)
+	if err != nil {
+		return nil, fmt.Errorf("locating blob at height %d: %w", height, err)
+	}
+	return c.Share.GetRange(ctx, height, start, end)
+}
+
+// squareWidth returns the width of the original data square of the block.
+func squareWidth(eh *header.ExtendedHeader) (int, error) {
+	if eh == nil || eh.DAH == nil || len(eh.DAH.RowRoots) == 0 {
+		return 0, errors.New("header has no data availability header")
+	}
+	return len(eh.DAH.RowRoots) / 2, nil
+}
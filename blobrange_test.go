@@ -0,0 +1,62 @@
+package client
+
+import (
+	"context"
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/celestiaorg/celestia-openrpc/mocks"
+	"github.com/celestiaorg/celestia-openrpc/types/blob"
+	"github.com/celestiaorg/celestia-openrpc/types/header"
+	"github.com/celestiaorg/celestia-openrpc/types/share"
+)
+
+func TestBlobShareRange(t *testing.T) {
+	b := testBlob(t, strings.Repeat("x", 1000))
+	_, _, err := b.ShareRange(4)
+	require.Error(t, err)
+
+	// the first share of the third row of an extended square of 8x8
+	start, end, err := withIndex(t, b, 16).ShareRange(4)
+	require.NoError(t, err)
+	require.Equal(t, 8, start)
+	require.Equal(t, 11, end)
+
+	// shares of the parity half of the rows aren't in the original square
+	_, _, err = withIndex(t, b, 6).ShareRange(4)
+	require.Error(t, err)
+
+	// blobs returned by nodes not reporting the index have none
+	var decoded blob.Blob
+	require.NoError(t, json.Unmarshal([]byte(`{"namespace":"AAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAECAwQ=","data":"aGk=",`+
+		`"share_version":0,"commitment":"AA=="}`), &decoded))
+	require.Equal(t, -1, decoded.Index())
+	require.Equal(t, 16, withIndex(t, b, 16).Index())
+}
+
+func TestGetBlobRange(t *testing.T) {
+	b := testBlob(t, strings.Repeat("x", 1000))
+	eh := mocks.NewHeader(3)
+	eh.DAH = &header.DataAvailabilityHeader{RowRoots: make([][]byte, 8), ColumnRoots: make([][]byte, 8)}
+
+	c := &Client{}
+	c.Blob.Get = func(context.Context, uint64, share.Namespace, blob.Commitment) (*blob.Blob, error) {
+		return withIndex(t, b, 17), nil
+	}
+	c.Header.GetByHeight = func(context.Context, uint64) (*header.ExtendedHeader, error) {
+		return eh, nil
+	}
+	c.Share.GetRange = func(_ context.Context, height uint64, start, end int) (*share.GetRangeResult, error) {
+		require.EqualValues(t, 3, height)
+		require.Equal(t, 9, start)
+		require.Equal(t, 12, end)
+		return &share.GetRangeResult{}, nil
+	}
+
+	res, err := c.GetBlobRange(context.Background(), 3, share.Namespace(b.Namespace().Bytes()), b.Commitment)
+	require.NoError(t, err)
+	require.NotNil(t, res)
+}
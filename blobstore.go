@@ -0,0 +1,150 @@
+package client
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+
+	"github.com/celestiaorg/celestia-openrpc/types/blob"
+	"github.com/celestiaorg/celestia-openrpc/types/share"
+)
+
+// BlobStore keeps retrieved blobs, so repeated reads of the same rollup data are served
+// without a round trip to the node. Implementations must be safe for concurrent use.
+type BlobStore interface {
+	// Get returns the blob of the namespace with the commitment at the height, if stored.
+	Get(height uint64, namespace share.Namespace, commitment blob.Commitment) (*blob.Blob, bool)
+	// Put stores the blob retrieved at the height.
+	Put(height uint64, b *blob.Blob) error
+	// GetAll returns all the blobs of the namespace at the height, if they were stored
+	// with PutAll. The returned blobs are empty for heights without any.
+	GetAll(height uint64, namespace share.Namespace) ([]*blob.Blob, bool)
+	// PutAll stores all the blobs of the namespace at the height.
+	PutAll(height uint64, namespace share.Namespace, blobs []*blob.Blob) error
+}
+
+// NewMemoryBlobStore returns a BlobStore keeping the blobs of the most recently read
+// size heights and namespaces in memory, along with as many single blobs.
+func NewMemoryBlobStore(size int) BlobStore {
+	return &cacheBlobStore{cache: NewLRUCache(size)}
+}
+
+// NewFileBlobStore returns a BlobStore persisting the blobs in dir, which survives restarts
+// and can be shared between processes. The directory is created if it does not exist.
+func NewFileBlobStore(dir string) (BlobStore, error) {
+	cache, err := NewFileCache(dir)
+	if err != nil {
+		return nil, err
+	}
+	return &cacheBlobStore{cache: cache}, nil
+}
+
+// cacheBlobStore is a BlobStore keeping the blobs encoded as JSON in a Cache.
+type cacheBlobStore struct {
+	cache Cache
+}
+
+// Get implements BlobStore.
+func (s *cacheBlobStore) Get(height uint64, namespace share.Namespace, commitment blob.Commitment) (*blob.Blob, bool) {
+	var b *blob.Blob
+	return b, s.get(fmt.Sprintf("blobstore/%d/%s/%x", height, namespace, []byte(commitment)), &b) && b != nil
+}
+
+// Put implements BlobStore.
+func (s *cacheBlobStore) Put(height uint64, b *blob.Blob) error {
+	key := fmt.Sprintf("blobstore/%d/%s/%x", height, share.Namespace(b.Namespace().Bytes()), []byte(b.Commitment))
+	return s.put(key, b)
+}
+
+// GetAll implements BlobStore.
+func (s *cacheBlobStore) GetAll(height uint64, namespace share.Namespace) ([]*blob.Blob, bool) {
+	var blobs []*blob.Blob
+	return blobs, s.get(fmt.Sprintf("blobstore/%d/%s", height, namespace), &blobs)
+}
+
+// PutAll implements BlobStore.
+func (s *cacheBlobStore) PutAll(height uint64, namespace share.Namespace, blobs []*blob.Blob) error {
+	if blobs == nil {
+		// distinguishes heights without blobs from ones which weren't stored
+		blobs = []*blob.Blob{}
+	}
+	return s.put(fmt.Sprintf("blobstore/%d/%s", height, namespace), blobs)
+}
+
+func (s *cacheBlobStore) get(key string, v interface{}) bool {
+	bz, ok := s.cache.Get(key)
+	return ok && json.Unmarshal(bz, v) == nil
+}
+
+func (s *cacheBlobStore) put(key string, v interface{}) error {
+	bz, err := json.Marshal(v)
+	if err != nil {
+		return err
+	}
+	return s.cache.Put(key, bz)
+}
+
+// storeBlobs wraps the methods of the client retrieving blobs to serve them from the store.
+func storeBlobs(c *Client, store BlobStore) {
+	get := c.Blob.Get
+	c.Blob.Get = func(
+		ctx context.Context,
+		height uint64,
+		namespace share.Namespace,
+		commitment blob.Commitment,
+	) (*blob.Blob, error) {
+		if b, ok := store.Get(height, namespace, commitment); ok {
+			return b, nil
+		}
+		b, err := get(ctx, height, namespace, commitment)
+		if err != nil {
+			return nil, err
+		}
+		_ = store.Put(height, b)
+		return b, nil
+	}
+
+	getAll := c.Blob.GetAll
+	c.Blob.GetAll = func(ctx context.Context, height uint64, namespaces []share.Namespace) ([]*blob.Blob, error) {
+		if blobs, ok := getAllStored(store, height, namespaces); ok {
+			if len(blobs) == 0 {
+				return nil, blob.ErrBlobNotFound
+			}
+			return blobs, nil
+		}
+
+		blobs, err := getAll(ctx, height, namespaces)
+		if err != nil && !errors.Is(err, blob.ErrBlobNotFound) {
+			return nil, err
+		}
+		for _, namespace := range namespaces {
+			var nsBlobs []*blob.Blob
+			for _, b := range blobs {
+				if namespace.Equals(share.Namespace(b.Namespace().Bytes())) {
+					nsBlobs = append(nsBlobs, b)
+					_ = store.Put(height, b)
+				}
+			}
+			_ = store.PutAll(height, namespace, nsBlobs)
+		}
+		return blobs, err
+	}
+}
+
+// getAllStored returns the stored blobs of all the namespaces at the height, in the order
+// of the namespaces, if the blobs of every one of them are stored.
+func getAllStored(store BlobStore, height uint64, namespaces []share.Namespace) ([]*blob.Blob, bool) {
+	if len(namespaces) == 0 {
+		return nil, false
+	}
+	var blobs []*blob.Blob
+	for _, namespace := range namespaces {
+		nsBlobs, ok := store.GetAll(height, namespace)
+		if !ok {
+			return nil, false
+		}
+		blobs = append(blobs, nsBlobs...)
+	}
+	return blobs, true
+}
@@ -0,0 +1,66 @@
+package client
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/celestiaorg/celestia-openrpc/types/blob"
+	"github.com/celestiaorg/celestia-openrpc/types/share"
+)
+
+func TestStoreBlobs(t *testing.T) {
+	b := testBlob(t, "hello")
+	namespace := share.Namespace(b.Namespace().Bytes())
+	other, err := share.NewBlobNamespaceV0([]byte{9, 9, 9, 9})
+	require.NoError(t, err)
+	fileStore, err := NewFileBlobStore(t.TempDir())
+	require.NoError(t, err)
+
+	for _, store := range []BlobStore{NewMemoryBlobStore(16), fileStore} {
+		var gets, getAlls int
+		c := &Client{}
+		c.Blob.Get = func(context.Context, uint64, share.Namespace, blob.Commitment) (*blob.Blob, error) {
+			gets++
+			return b, nil
+		}
+		c.Blob.GetAll = func(_ context.Context, height uint64, _ []share.Namespace) ([]*blob.Blob, error) {
+			getAlls++
+			if height == 2 {
+				return nil, blob.ErrBlobNotFound
+			}
+			return []*blob.Blob{b}, nil
+		}
+		storeBlobs(c, store)
+		ctx := context.Background()
+
+		for i := 0; i < 2; i++ {
+			blobs, err := c.Blob.GetAll(ctx, 1, []share.Namespace{namespace, other})
+			require.NoError(t, err)
+			require.Len(t, blobs, 1)
+			require.Equal(t, b.Data, blobs[0].Data)
+			require.Equal(t, b.Commitment, blobs[0].Commitment)
+
+			_, err = c.Blob.GetAll(ctx, 2, []share.Namespace{namespace})
+			require.ErrorIs(t, err, blob.ErrBlobNotFound)
+		}
+		require.Equal(t, 2, getAlls)
+
+		// the blobs retrieved by GetAll are served to Get, and the namespaces separately
+		got, err := c.Blob.Get(ctx, 1, namespace, b.Commitment)
+		require.NoError(t, err)
+		require.Equal(t, b.Data, got.Data)
+		_, err = c.Blob.GetAll(ctx, 1, []share.Namespace{other})
+		require.ErrorIs(t, err, blob.ErrBlobNotFound)
+		require.Zero(t, gets)
+		require.Equal(t, 2, getAlls)
+
+		// other heights are fetched separately
+		_, err = c.Blob.Get(ctx, 3, namespace, b.Commitment)
+		require.NoError(t, err)
+		_, err = c.Blob.Get(ctx, 3, namespace, b.Commitment)
+		require.NoError(t, err)
+		require.Equal(t, 1, gets)
+	}
+}
@@ -0,0 +1,124 @@
+package client
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"time"
+)
+
+// ErrCircuitOpen is returned for calls made against an endpoint whose circuit breaker is open.
+var ErrCircuitOpen = errors.New("circuit breaker is open")
+
+type circuitState int
+
+const (
+	circuitClosed circuitState = iota
+	circuitOpen
+	circuitHalfOpen
+)
+
+// circuitBreaker stops calls to an endpoint after consecutive connection failures.
+// Once the cool-down has passed, a single probe call is let through, closing the
+// circuit if it succeeds and re-opening it otherwise. A nil breaker lets all calls through.
+type circuitBreaker struct {
+	threshold int
+	coolDown  time.Duration
+	clock     Clock
+
+	mu       sync.Mutex
+	state    circuitState
+	failures int
+	openedAt time.Time
+	probing  bool
+	// probe identifies the current probe call, so only its outcome decides the half-open state.
+	probe uint64
+}
+
+func newCircuitBreaker(threshold int, coolDown time.Duration, clock Clock) *circuitBreaker {
+	return &circuitBreaker{threshold: max(threshold, 1), coolDown: coolDown, clock: clock}
+}
+
+// available reports whether a call would currently be let through, without reserving it.
+func (b *circuitBreaker) available() bool {
+	if b == nil {
+		return true
+	}
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	switch b.state {
+	case circuitOpen:
+		return b.clock.Now().Sub(b.openedAt) >= b.coolDown
+	case circuitHalfOpen:
+		return !b.probing
+	default:
+		return true
+	}
+}
+
+// allow reports whether the call may proceed. In the half-open state, only the
+// first caller is allowed to probe the endpoint. The returned ticket has to be
+// passed to record once the call finished. It is non-zero for probe calls.
+func (b *circuitBreaker) allow() (ticket uint64, ok bool) {
+	if b == nil {
+		return 0, true
+	}
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	switch b.state {
+	case circuitOpen:
+		if b.clock.Now().Sub(b.openedAt) < b.coolDown {
+			return 0, false
+		}
+		b.state = circuitHalfOpen
+		fallthrough
+	case circuitHalfOpen:
+		if b.probing {
+			return 0, false
+		}
+		b.probing = true
+		b.probe++
+		return b.probe, true
+	default:
+		return 0, true
+	}
+}
+
+// record updates the breaker with the outcome of a call allowed with the ticket.
+func (b *circuitBreaker) record(ticket uint64, err error) {
+	if b == nil {
+		return
+	}
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	probe := b.probing && ticket != 0 && ticket == b.probe
+	if !probe && b.state != circuitClosed {
+		// the call was allowed before the circuit opened, so it says nothing about the probe
+		return
+	}
+	if probe {
+		b.probing = false
+	}
+	switch {
+	case errors.Is(err, context.Canceled):
+		// the call was aborted by the caller, let the next one probe instead
+	case isConnectionError(err):
+		b.failures++
+		if probe || b.failures >= b.threshold {
+			b.state, b.openedAt = circuitOpen, b.clock.Now()
+		}
+	default:
+		b.state, b.failures = circuitClosed, 0
+	}
+}
+
+// isOpen reports whether the circuit is open or half-open.
+func (b *circuitBreaker) isOpen() bool {
+	if b == nil {
+		return false
+	}
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return b.state != circuitClosed
+}
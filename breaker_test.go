@@ -0,0 +1,97 @@
+package client
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestCircuitBreaker(t *testing.T) {
+	b := newCircuitBreaker(2, 10*time.Millisecond, realClock{})
+
+	ticket, ok := b.allow()
+	require.True(t, ok)
+	require.Zero(t, ticket)
+	b.record(ticket, errConnection)
+	require.False(t, b.isOpen())
+	b.record(0, errConnection)
+	require.True(t, b.isOpen())
+
+	_, ok = b.allow()
+	require.False(t, ok)
+	require.False(t, b.available())
+
+	time.Sleep(20 * time.Millisecond)
+	require.True(t, b.available())
+	probe, ok := b.allow()
+	require.True(t, ok)
+	require.NotZero(t, probe)
+	// only a single probe is let through
+	_, ok = b.allow()
+	require.False(t, ok)
+
+	b.record(probe, nil)
+	require.False(t, b.isOpen())
+	_, ok = b.allow()
+	require.True(t, ok)
+}
+
+func TestCircuitBreakerFailedProbe(t *testing.T) {
+	b := newCircuitBreaker(1, 10*time.Millisecond, realClock{})
+	b.record(0, errConnection)
+	time.Sleep(20 * time.Millisecond)
+
+	probe, ok := b.allow()
+	require.True(t, ok)
+	b.record(probe, errConnection)
+	require.True(t, b.isOpen())
+	_, ok = b.allow()
+	require.False(t, ok)
+}
+
+func TestCircuitBreakerIgnoresStaleCalls(t *testing.T) {
+	b := newCircuitBreaker(1, 10*time.Millisecond, realClock{})
+	// a slow call is admitted before the circuit opens
+	stale, ok := b.allow()
+	require.True(t, ok)
+	b.record(0, errConnection)
+	time.Sleep(20 * time.Millisecond)
+
+	probe, ok := b.allow()
+	require.True(t, ok)
+
+	// the outcome of the stale call neither closes the circuit nor admits a second probe
+	b.record(stale, nil)
+	require.True(t, b.isOpen())
+	b.record(stale, errConnection)
+	_, ok = b.allow()
+	require.False(t, ok)
+
+	b.record(probe, nil)
+	require.False(t, b.isOpen())
+}
+
+func TestCircuitBreakerCancelledProbe(t *testing.T) {
+	b := newCircuitBreaker(1, 10*time.Millisecond, realClock{})
+	b.record(0, errConnection)
+	time.Sleep(20 * time.Millisecond)
+
+	probe, ok := b.allow()
+	require.True(t, ok)
+	b.record(probe, context.Canceled)
+	// the next call probes instead
+	next, ok := b.allow()
+	require.True(t, ok)
+	require.NotEqual(t, probe, next)
+}
+
+func TestNilCircuitBreaker(t *testing.T) {
+	var b *circuitBreaker
+	_, ok := b.allow()
+	require.True(t, ok)
+	require.True(t, b.available())
+	b.record(0, errConnection)
+	require.False(t, b.isOpen())
+}
@@ -0,0 +1,212 @@
+package client
+
+import (
+	"container/list"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"sync"
+
+	libhead "github.com/celestiaorg/go-header"
+	"github.com/celestiaorg/rsmt2d"
+
+	"github.com/celestiaorg/celestia-openrpc/types/blob"
+	"github.com/celestiaorg/celestia-openrpc/types/header"
+	"github.com/celestiaorg/celestia-openrpc/types/share"
+)
+
+// Cache stores serialized responses of immutable objects, such as headers,
+// blobs and extended data squares. Implementations must be safe for concurrent use.
+type Cache interface {
+	// Get returns the value stored under the key, if any.
+	Get(key string) ([]byte, bool)
+	// Put stores the value under the key.
+	Put(key string, value []byte) error
+}
+
+// FileCache is a Cache persisting every value as a separate file in a directory.
+// Values are written atomically, so the same directory can be shared between
+// processes and survives restarts.
+type FileCache struct {
+	dir string
+}
+
+// NewFileCache creates a new FileCache storing values under dir.
+// The directory is created if it does not exist.
+func NewFileCache(dir string) (*FileCache, error) {
+	if err := os.MkdirAll(dir, 0o750); err != nil {
+		return nil, fmt.Errorf("creating cache directory: %w", err)
+	}
+	return &FileCache{dir: dir}, nil
+}
+
+// Get implements Cache.
+func (c *FileCache) Get(key string) ([]byte, bool) {
+	value, err := os.ReadFile(c.path(key))
+	if err != nil {
+		return nil, false
+	}
+	return value, true
+}
+
+// Put implements Cache.
+func (c *FileCache) Put(key string, value []byte) error {
+	path := c.path(key)
+	if _, err := os.Stat(path); err == nil {
+		// values are immutable, so there is nothing to update
+		return nil
+	} else if !errors.Is(err, fs.ErrNotExist) {
+		return err
+	}
+
+	if err := os.MkdirAll(filepath.Dir(path), 0o750); err != nil {
+		return err
+	}
+	tmp, err := os.CreateTemp(filepath.Dir(path), ".tmp-*")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(tmp.Name()) //nolint:errcheck
+
+	if _, err := tmp.Write(value); err != nil {
+		_ = tmp.Close()
+		return err
+	}
+	if err := tmp.Close(); err != nil {
+		return err
+	}
+	return os.Rename(tmp.Name(), path)
+}
+
+// path maps the key to a file path, sharding files into subdirectories
+// to keep directories small.
+func (c *FileCache) path(key string) string {
+	sum := sha256.Sum256([]byte(key))
+	name := hex.EncodeToString(sum[:])
+	return filepath.Join(c.dir, name[:2], name)
+}
+
+// LRUCache is a Cache keeping up to a fixed number of values in memory, evicting the least
+// recently used ones, e.g. for several components of a process querying the same heights.
+type LRUCache struct {
+	size int
+
+	mu sync.Mutex
+	// entries holds the lruEntry values, from the most to the least recently used one.
+	entries *list.List
+	keys    map[string]*list.Element
+}
+
+type lruEntry struct {
+	key   string
+	value []byte
+}
+
+// NewLRUCache creates a new LRUCache holding up to size values, at least one.
+func NewLRUCache(size int) *LRUCache {
+	return &LRUCache{size: max(size, 1), entries: list.New(), keys: make(map[string]*list.Element)}
+}
+
+// Get implements Cache.
+func (c *LRUCache) Get(key string) ([]byte, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	elem, ok := c.keys[key]
+	if !ok {
+		return nil, false
+	}
+	c.entries.MoveToFront(elem)
+	return elem.Value.(*lruEntry).value, true
+}
+
+// Put implements Cache.
+func (c *LRUCache) Put(key string, value []byte) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if elem, ok := c.keys[key]; ok {
+		// values are immutable, so there is nothing to update
+		c.entries.MoveToFront(elem)
+		return nil
+	}
+	c.keys[key] = c.entries.PushFront(&lruEntry{key: key, value: value})
+	if c.entries.Len() > c.size {
+		oldest := c.entries.Back()
+		c.entries.Remove(oldest)
+		delete(c.keys, oldest.Value.(*lruEntry).key)
+	}
+	return nil
+}
+
+// Len returns the number of values in the cache.
+func (c *LRUCache) Len() int {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.entries.Len()
+}
+
+// cached returns the value stored in the cache under the key or fetches
+// and stores it otherwise.
+func cached[T any](c Cache, key string, fetch func() (T, error)) (T, error) {
+	if bz, ok := c.Get(key); ok {
+		var v T
+		if err := json.Unmarshal(bz, &v); err == nil {
+			return v, nil
+		}
+	}
+
+	v, err := fetch()
+	if err != nil {
+		return v, err
+	}
+	if bz, err := json.Marshal(v); err == nil {
+		_ = c.Put(key, bz)
+	}
+	return v, nil
+}
+
+// cacheImmutable wraps the methods of the client returning immutable objects
+// to serve them from the cache.
+func cacheImmutable(c *Client, cache Cache) {
+	getByHeight := c.Header.GetByHeight
+	c.Header.GetByHeight = func(ctx context.Context, height uint64) (*header.ExtendedHeader, error) {
+		return cached(cache, fmt.Sprintf("header/height/%d", height), func() (*header.ExtendedHeader, error) {
+			return getByHeight(ctx, height)
+		})
+	}
+
+	getByHash := c.Header.GetByHash
+	c.Header.GetByHash = func(ctx context.Context, hash libhead.Hash) (*header.ExtendedHeader, error) {
+		return cached(cache, fmt.Sprintf("header/hash/%s", hash), func() (*header.ExtendedHeader, error) {
+			return getByHash(ctx, hash)
+		})
+	}
+
+	get := c.Blob.Get
+	c.Blob.Get = func(
+		ctx context.Context,
+		height uint64,
+		namespace share.Namespace,
+		commitment blob.Commitment,
+	) (*blob.Blob, error) {
+		key := fmt.Sprintf("blob/%d/%s/%x", height, namespace, []byte(commitment))
+		return cached(cache, key, func() (*blob.Blob, error) {
+			return get(ctx, height, namespace, commitment)
+		})
+	}
+
+	getEDS := c.Share.GetEDS
+	c.Share.GetEDS = func(ctx context.Context, eh *header.ExtendedHeader) (*rsmt2d.ExtendedDataSquare, error) {
+		if eh == nil || eh.DAH == nil {
+			return getEDS(ctx, eh)
+		}
+		return cached(cache, fmt.Sprintf("eds/%X", eh.DAH.Hash()), func() (*rsmt2d.ExtendedDataSquare, error) {
+			return getEDS(ctx, eh)
+		})
+	}
+}
@@ -0,0 +1,106 @@
+package client
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/celestiaorg/celestia-openrpc/types/blob"
+	"github.com/celestiaorg/celestia-openrpc/types/share"
+)
+
+func TestFileCache(t *testing.T) {
+	dir := t.TempDir()
+	cache, err := NewFileCache(dir)
+	require.NoError(t, err)
+
+	_, ok := cache.Get("header/height/1")
+	require.False(t, ok)
+
+	require.NoError(t, cache.Put("header/height/1", []byte("first")))
+	// values are immutable, so later puts are ignored
+	require.NoError(t, cache.Put("header/height/1", []byte("second")))
+
+	value, ok := cache.Get("header/height/1")
+	require.True(t, ok)
+	require.Equal(t, []byte("first"), value)
+
+	// the values survive a restart
+	reopened, err := NewFileCache(dir)
+	require.NoError(t, err)
+	value, ok = reopened.Get("header/height/1")
+	require.True(t, ok)
+	require.Equal(t, []byte("first"), value)
+}
+
+func TestLRUCache(t *testing.T) {
+	cache := NewLRUCache(2)
+	require.NoError(t, cache.Put("header/height/1", []byte("1")))
+	require.NoError(t, cache.Put("header/height/2", []byte("2")))
+	// values are immutable, so later puts are ignored
+	require.NoError(t, cache.Put("header/height/1", []byte("updated")))
+
+	value, ok := cache.Get("header/height/1")
+	require.True(t, ok)
+	require.Equal(t, []byte("1"), value)
+
+	// the least recently used value is evicted
+	require.NoError(t, cache.Put("header/height/3", []byte("3")))
+	require.Equal(t, 2, cache.Len())
+	_, ok = cache.Get("header/height/2")
+	require.False(t, ok)
+	_, ok = cache.Get("header/height/1")
+	require.True(t, ok)
+	_, ok = cache.Get("header/height/3")
+	require.True(t, ok)
+
+	require.Equal(t, 1, NewLRUCache(0).size)
+}
+
+func TestCacheImmutable(t *testing.T) {
+	b := testBlob(t, "hello")
+	namespace := share.Namespace(b.Namespace().Bytes())
+
+	var fetches int
+	c := &Client{}
+	c.Blob.Get = func(context.Context, uint64, share.Namespace, blob.Commitment) (*blob.Blob, error) {
+		fetches++
+		return b, nil
+	}
+	cache, err := NewFileCache(t.TempDir())
+	require.NoError(t, err)
+	cacheImmutable(c, cache)
+
+	for i := 0; i < 3; i++ {
+		got, err := c.Blob.Get(context.Background(), 5, namespace, b.Commitment)
+		require.NoError(t, err)
+		require.Equal(t, b.Data, got.Data)
+		require.Equal(t, b.Commitment, got.Commitment)
+	}
+	require.Equal(t, 1, fetches)
+
+	// other heights are fetched separately
+	_, err = c.Blob.Get(context.Background(), 6, namespace, b.Commitment)
+	require.NoError(t, err)
+	require.Equal(t, 2, fetches)
+}
+
+func TestCacheImmutableSkipsErrors(t *testing.T) {
+	b := testBlob(t, "hello")
+	var fetches int
+	c := &Client{}
+	c.Blob.Get = func(context.Context, uint64, share.Namespace, blob.Commitment) (*blob.Blob, error) {
+		fetches++
+		return nil, blob.ErrBlobNotFound
+	}
+	cache, err := NewFileCache(t.TempDir())
+	require.NoError(t, err)
+	cacheImmutable(c, cache)
+
+	for i := 0; i < 2; i++ {
+		_, err := c.Blob.Get(context.Background(), 5, share.Namespace(b.Namespace().Bytes()), b.Commitment)
+		require.ErrorIs(t, err, blob.ErrBlobNotFound)
+	}
+	require.Equal(t, 2, fetches)
+}
@@ -0,0 +1,90 @@
+package client
+
+import (
+	"context"
+	"fmt"
+	"time"
+)
+
+// CallOption is the functional option that is applied to individual calls made
+// with a context returned by WithCallOptions.
+type CallOption func(opts *callOptions)
+
+type callOptions struct {
+	timeout  time.Duration
+	retry    *RetryPolicy
+	endpoint string
+}
+
+type callOptionsKey struct{}
+
+// WithCallOptions returns a context applying the options to all calls made with it, e.g.
+//
+//	ctx = client.WithCallOptions(ctx, client.WithCallTimeout(90*time.Second))
+//	blobs, err := c.Blob.GetAll(ctx, height, namespaces)
+func WithCallOptions(ctx context.Context, opts ...CallOption) context.Context {
+	co := callOptions{}
+	if parent, ok := ctx.Value(callOptionsKey{}).(callOptions); ok {
+		co = parent
+	}
+	for _, opt := range opts {
+		opt(&co)
+	}
+	return context.WithValue(ctx, callOptionsKey{}, co)
+}
+
+// callOptionsFrom returns the call options applied to the context, if any.
+func callOptionsFrom(ctx context.Context) callOptions {
+	co, _ := ctx.Value(callOptionsKey{}).(callOptions)
+	return co
+}
+
+// WithCallTimeout is a call option that bounds the duration of the call, including retries.
+func WithCallTimeout(timeout time.Duration) CallOption {
+	return func(opts *callOptions) {
+		opts.timeout = timeout
+	}
+}
+
+// WithCallRetry is a call option that overrides the retry policy set with WithRetry.
+// Only read-only methods and those marked with WithRetrySafe are retried.
+func WithCallRetry(policy RetryPolicy) CallOption {
+	return func(opts *callOptions) {
+		opts.retry = &policy
+	}
+}
+
+// WithCallEndpoint is a call option that pins the call to the endpoint with the given
+// address, which must be the one the client was constructed with or one set with WithEndpoints.
+func WithCallEndpoint(addr string) CallOption {
+	return func(opts *callOptions) {
+		opts.endpoint = addr
+	}
+}
+
+// applyCallOptions returns an interceptor applying the call options of the context
+// of the call which aren't handled by other interceptors.
+func applyCallOptions(p *proxy) interceptor {
+	return func(ctx context.Context, c *call, next invoker) ([]interface{}, error) {
+		co := callOptionsFrom(ctx)
+		if co.endpoint != "" {
+			idx := -1
+			for i, ep := range p.endpoints {
+				if ep.addr == co.endpoint {
+					idx = i
+					break
+				}
+			}
+			if idx < 0 {
+				return nil, fmt.Errorf("%s: endpoint %s is not configured", c.name(), co.endpoint)
+			}
+			ctx = withEndpoint(ctx, idx)
+		}
+		if co.timeout > 0 {
+			var cancel context.CancelFunc
+			ctx, cancel = context.WithTimeout(ctx, co.timeout)
+			defer cancel()
+		}
+		return next(ctx, c)
+	}
+}
@@ -0,0 +1,53 @@
+package client
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestWithCallOptions(t *testing.T) {
+	ctx := WithCallOptions(context.Background(), WithCallTimeout(time.Second), WithCallEndpoint("a"))
+	// options of nested contexts are merged with the ones of the parent
+	ctx = WithCallOptions(ctx, WithCallRetry(fastRetry))
+
+	co := callOptionsFrom(ctx)
+	require.Equal(t, time.Second, co.timeout)
+	require.Equal(t, "a", co.endpoint)
+	require.Equal(t, &fastRetry, co.retry)
+	require.Equal(t, callOptions{}, callOptionsFrom(context.Background()))
+}
+
+func TestApplyCallOptionsTimeout(t *testing.T) {
+	intercept := applyCallOptions(testProxy("a"))
+	ctx := WithCallOptions(context.Background(), WithCallTimeout(10*time.Millisecond))
+
+	_, err := intercept(ctx, &call{module: "share", method: "GetEDS", perm: "read"},
+		func(ctx context.Context, _ *call) ([]interface{}, error) {
+			<-ctx.Done()
+			return nil, ctx.Err()
+		})
+	require.ErrorIs(t, err, context.DeadlineExceeded)
+}
+
+func TestApplyCallOptionsEndpoint(t *testing.T) {
+	intercept := applyCallOptions(testProxy("a", "b"))
+	c := &call{module: "header", method: "NetworkHead", perm: "read"}
+
+	var pinned int
+	ctx := WithCallOptions(context.Background(), WithCallEndpoint("b"))
+	_, err := intercept(ctx, c, func(ctx context.Context, _ *call) ([]interface{}, error) {
+		var ok bool
+		pinned, ok = pinnedEndpoint(ctx)
+		require.True(t, ok)
+		return nil, nil
+	})
+	require.NoError(t, err)
+	require.Equal(t, 1, pinned)
+
+	ctx = WithCallOptions(context.Background(), WithCallEndpoint("c"))
+	_, err = intercept(ctx, c, nil)
+	require.ErrorContains(t, err, "endpoint c is not configured")
+}
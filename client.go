@@ -2,10 +2,13 @@ package client
 
 import (
 	"context"
+	"errors"
 	"fmt"
-	"net/http"
+	"log/slog"
+	"reflect"
+	"sync"
+	"time"
 
-	clientbuilder "github.com/celestiaorg/celestia-openrpc/builder"
 	"github.com/celestiaorg/celestia-openrpc/types/blob"
 	"github.com/celestiaorg/celestia-openrpc/types/da"
 	"github.com/celestiaorg/celestia-openrpc/types/das"
@@ -15,12 +18,16 @@ import (
 	"github.com/celestiaorg/celestia-openrpc/types/p2p"
 	"github.com/celestiaorg/celestia-openrpc/types/share"
 	"github.com/celestiaorg/celestia-openrpc/types/state"
-
-	"github.com/filecoin-project/go-jsonrpc"
 )
 
 const AuthKey = "Authorization"
 
+// closeTimeout is the time Close waits for calls in flight to complete.
+const closeTimeout = 5 * time.Second
+
+// ErrClientClosed is returned by calls made once the client is closed.
+var ErrClientClosed = errors.New("client is closed")
+
 type Client struct {
 	Fraud  fraud.API
 	Blob   blob.API
@@ -32,41 +39,250 @@ type Client struct {
 	Node   node.API
 	DA     da.API
 
-	closer clientbuilder.MultiClientCloser
+	proxy *proxy
+	// closing is cancelled once the client is closed, cancelling all subscriptions.
+	closing   context.Context
+	stopAll   context.CancelFunc
+	closeOnce sync.Once
+	// callsMu guards starting calls against the client being closed.
+	callsMu sync.RWMutex
+	closed  bool
+	calls   sync.WaitGroup
+
+	readOnly bool
+	log      *slog.Logger
+	// clock times the polls of PollBlobs. The real clock is used if it's nil.
+	clock Clock
+	// extensions holds the modules registered with WithModule keyed by their RPC namespace.
+	extensions map[string]interface{}
 }
 
-// Close closes the connections to all namespaces registered on the client.
+// Close closes the client like Shutdown, waiting up to 5s for calls in flight.
 func (c *Client) Close() {
-	c.closer.CloseAll()
+	ctx, cancel := context.WithTimeout(context.Background(), closeTimeout)
+	defer cancel()
+	_ = c.Shutdown(ctx)
+}
+
+// Shutdown closes the client gracefully. Calls made from then on fail with ErrClientClosed
+// and subscriptions are cancelled, closing their channels. Calls in flight are awaited
+// until ctx is done, after which the connections to all nodes are closed regardless.
+// The error of ctx is returned if calls were still in flight.
+func (c *Client) Shutdown(ctx context.Context) error {
+	var err error
+	c.closeOnce.Do(func() {
+		c.callsMu.Lock()
+		c.closed = true
+		c.callsMu.Unlock()
+		c.stopAll()
+
+		drained := make(chan struct{})
+		go func() {
+			c.calls.Wait()
+			close(drained)
+		}()
+		select {
+		case <-drained:
+		case <-ctx.Done():
+			err = ctx.Err()
+			c.log.WarnContext(ctx, "closing client with calls in flight", "err", err)
+		}
+
+		for _, ep := range c.proxy.endpoints {
+			ep.close()
+		}
+		c.log.Info("client closed")
+	})
+	return err
 }
 
-func NewClient(ctx context.Context, addr string, token string) (*Client, error) {
-	var authHeader http.Header
-	if token != "" {
-		authHeader = http.Header{AuthKey: []string{fmt.Sprintf("Bearer %s", token)}}
+// trackCalls is an interceptor rejecting calls once the client is closed and tracking
+// those in flight. Subscriptions outlive their call, so they're cancelled once the client
+// is closed, while other calls are left to complete.
+func (c *Client) trackCalls(ctx context.Context, cl *call, next invoker) ([]interface{}, error) {
+	if !c.beginCall() {
+		return nil, fmt.Errorf("%s: %w", cl.name(), ErrClientClosed)
 	}
+	defer c.calls.Done()
 
-	var client Client
+	ctx, cancel := context.WithCancel(ctx)
+	out, err := next(ctx, cl)
+	if err != nil || !subscribed(out) {
+		cancel()
+		return out, err
+	}
+	stop := context.AfterFunc(c.closing, cancel)
+	context.AfterFunc(ctx, func() { stop() })
+	return out, err
+}
 
-	modules := map[string]interface{}{
-		"fraud":  &client.Fraud,
-		"blob":   &client.Blob,
-		"header": &client.Header,
-		"state":  &client.State,
-		"share":  &client.Share,
-		"das":    &client.DAS,
-		"p2p":    &client.P2P,
-		"node":   &client.Node,
-		"da":     &client.DA,
+// beginCall registers a call in flight, which Shutdown awaits, unless the client is closed.
+// The caller must mark the call done if true is returned.
+func (c *Client) beginCall() bool {
+	c.callsMu.RLock()
+	defer c.callsMu.RUnlock()
+	if c.closed {
+		return false
 	}
+	c.calls.Add(1)
+	return true
+}
 
-	for name, module := range modules {
-		closer, err := jsonrpc.NewClient(ctx, addr, name, module, authHeader)
+// subscribed reports whether the results of a call contain a subscription channel.
+func subscribed(out []interface{}) bool {
+	for _, res := range out {
+		if res != nil && reflect.TypeOf(res).Kind() == reflect.Chan {
+			return true
+		}
+	}
+	return false
+}
+
+func NewClient(ctx context.Context, addr string, token string, opts ...Option) (*Client, error) {
+	cfg := newConfig(opts...)
+
+	if cfg.auth != nil {
+		var err error
+		if token, err = cfg.auth.Token(ctx); err != nil {
+			return nil, fmt.Errorf("getting token: %w", err)
+		}
+	}
+
+	client := Client{log: cfg.logger, clock: cfg.clock}
+	client.closing, client.stopAll = context.WithCancel(context.Background())
+	for name, module := range cfg.extensions {
+		if _, ok := client.modules()[name]; ok {
+			return nil, fmt.Errorf("module %s is already registered", name)
+		}
+		if t := reflect.TypeOf(module); t.Kind() != reflect.Pointer || t.Elem().Kind() != reflect.Struct {
+			return nil, fmt.Errorf("module %s must be a pointer to a struct, got %T", name, module)
+		}
+	}
+	client.extensions = cfg.extensions
+	modules := client.modules()
+	dialed, err := cfg.dialedModules(modules)
+	if err != nil {
+		return nil, err
+	}
+
+	addrs := append([]string{addr}, cfg.endpoints...)
+	for _, epAddr := range addrs {
+		if err := cfg.checkEndpoint(epAddr); err != nil {
+			return nil, err
+		}
+	}
+
+	endpoints := make([]*endpoint, 0, len(addrs))
+	for _, epAddr := range addrs {
+		ep, err := dialEndpoint(ctx, epAddr, dialed, authHeader(cfg.header, token), cfg.transport, cfg.rpcOptions())
 		if err != nil {
+			cfg.logger.ErrorContext(ctx, "connecting to node failed", "addr", epAddr, "err", err)
+			for _, ep := range endpoints {
+				ep.close()
+			}
 			return nil, err
 		}
-		client.closer.Register(closer)
+		cfg.logger.DebugContext(ctx, "connected to node", "addr", epAddr)
+		ep.breaker = cfg.circuitBreaker()
+		ep.httpClient = cfg.httpClient(epAddr, ep.unix)
+		ep.events = cfg.events
+		if ep.events != nil {
+			ep.events(ConnectionEvent{Type: Connected, Addr: epAddr})
+		}
+		endpoints = append(endpoints, ep)
+	}
+
+	client.proxy = newProxy(endpoints)
+	interceptors := cfg.interceptors(client.proxy)
+	if cfg.auth != nil {
+		r := &reauthenticator{provider: cfg.auth, base: cfg.header, proxy: client.proxy}
+		// refresh the token before any other interceptor sees the rejection
+		interceptors = append(interceptors, r.intercept)
+	}
+	client.proxy.use(append([]interceptor{client.trackCalls}, interceptors...)...)
+	for name, module := range modules {
+		client.proxy.bind(name, module)
+	}
+
+	if !canWrite(token) {
+		client.readOnly = true
+		for _, module := range modules {
+			disableMethods(module, permWrite, ErrReadOnlyClient)
+		}
+	}
+
+	if !cfg.noReconnect {
+		r := &resubscriber{
+			backoff: cfg.reconnectBackoff(),
+			onGap:   cfg.onGap,
+			onError: cfg.onSubscriptionError,
+			closing: client.closing.Done(),
+			events:  cfg.events,
+			log:     cfg.logger,
+			clock:   cfg.clock,
+		}
+		if cfg.prometheus != nil {
+			r.onResubscribe = cfg.prometheus.observeResubscription
+		}
+		resubscribeAll(&client, r)
+	}
+	if cfg.cache != nil {
+		cacheImmutable(&client, cfg.cache)
+	}
+	if cfg.verifyProofs {
+		verifyProofs(&client)
+	}
+	if cfg.blobStore != nil {
+		storeBlobs(&client, cfg.blobStore)
+	}
+	if hook := cfg.submitHook(); hook != nil {
+		instrumentSubmit(&client, hook)
+	}
+	if hook, ok := cfg.metrics.(RetrievalHook); ok {
+		observeRetrievals(&client, hook)
+	}
+	if cfg.audit != nil {
+		auditRetrievals(&client, cfg.audit)
+	}
+	if cfg.resubmitPolicy != nil {
+		resubmit(&client, *cfg.resubmitPolicy, cfg.logger, cfg.clock)
+	}
+	if cfg.receipts != nil {
+		recordReceipts(&client, cfg.receipts, cfg.logger)
+	}
+	// blobs are skipped before their receipts are recorded
+	if cfg.submissions != nil {
+		submitOnce(&client, cfg.submissions, cfg.logger)
+	}
+	if cfg.dryRun {
+		enableDryRun(&client, cfg.dryRunSigner)
+	}
+	validateSigners(&client)
+
+	if err := negotiateVersion(ctx, &client, cfg.strictVersion); err != nil {
+		client.Close()
+		return nil, err
 	}
 
 	return &client, nil
 }
+
+// modules returns pointers to all the modules of the client keyed by their RPC namespace,
+// including the extensions.
+func (c *Client) modules() map[string]interface{} {
+	modules := map[string]interface{}{
+		"fraud":  &c.Fraud,
+		"blob":   &c.Blob,
+		"header": &c.Header,
+		"state":  &c.State,
+		"share":  &c.Share,
+		"das":    &c.DAS,
+		"p2p":    &c.P2P,
+		"node":   &c.Node,
+		"da":     &c.DA,
+	}
+	for name, module := range c.extensions {
+		modules[name] = module
+	}
+	return modules
+}
@@ -0,0 +1,54 @@
+package client
+
+import "time"
+
+// Clock is the source of time of the retries, the re-creation of subscriptions, the circuit
+// breakers, hedging, rate limits, polling and keep-alive pings of the client. It can be
+// replaced with WithClock, e.g. with a fake clock letting tests of reconnects run instantly
+// and deterministically.
+type Clock interface {
+	// Now returns the current time.
+	Now() time.Time
+	// After returns a channel receiving the current time once d has elapsed.
+	After(d time.Duration) <-chan time.Time
+	// NewTimer returns a timer sending the current time on its channel once d has elapsed.
+	// Unlike After, the timer can be stopped to release it before it fires.
+	NewTimer(d time.Duration) Timer
+}
+
+// Timer is a single event created by a Clock.
+type Timer interface {
+	// C returns the channel the current time is sent on once the timer fires.
+	C() <-chan time.Time
+	// Stop prevents the timer from firing. It returns false if the timer fired or was
+	// stopped already.
+	Stop() bool
+}
+
+// realClock is the Clock of the time package.
+type realClock struct{}
+
+func (realClock) Now() time.Time {
+	return time.Now()
+}
+
+func (realClock) After(d time.Duration) <-chan time.Time {
+	return time.After(d)
+}
+
+func (realClock) NewTimer(d time.Duration) Timer {
+	return realTimer{timer: time.NewTimer(d)}
+}
+
+// realTimer is the Timer of the time package.
+type realTimer struct {
+	timer *time.Timer
+}
+
+func (t realTimer) C() <-chan time.Time {
+	return t.timer.C
+}
+
+func (t realTimer) Stop() bool {
+	return t.timer.Stop()
+}
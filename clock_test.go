@@ -0,0 +1,163 @@
+package client
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"sync"
+	"syscall"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/celestiaorg/celestia-openrpc/types/header"
+	"github.com/celestiaorg/celestia-openrpc/types/share"
+)
+
+// fakeClock is a Clock whose timers fire right away, advancing the time by their
+// duration and recording it.
+type fakeClock struct {
+	mu     sync.Mutex
+	now    time.Time
+	delays []time.Duration
+}
+
+func (c *fakeClock) Now() time.Time {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.now
+}
+
+func (c *fakeClock) After(d time.Duration) <-chan time.Time {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.now = c.now.Add(d)
+	c.delays = append(c.delays, d)
+	fired := make(chan time.Time, 1)
+	fired <- c.now
+	return fired
+}
+
+func (c *fakeClock) NewTimer(d time.Duration) Timer {
+	return chanTimer(c.After(d))
+}
+
+func (c *fakeClock) advance(d time.Duration) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.now = c.now.Add(d)
+}
+
+// chanTimer is a Timer of a fake clock firing on the channel.
+type chanTimer <-chan time.Time
+
+func (t chanTimer) C() <-chan time.Time {
+	return t
+}
+
+func (chanTimer) Stop() bool {
+	return true
+}
+
+func TestRetryClock(t *testing.T) {
+	clock := &fakeClock{}
+	slowRetry := RetryPolicy{MaxAttempts: 3, MinDelay: time.Hour, MaxDelay: time.Hour}
+	intercept := retry(map[string]RetryPolicy{"": slowRetry}, nil, discardLogger, clock)
+	next, calls := failingInvoker(2, syscall.ECONNRESET)
+
+	_, err := intercept(context.Background(), &call{module: "header", method: "GetByHeight", perm: "read"}, next)
+	require.NoError(t, err)
+	require.Equal(t, 3, *calls)
+	require.Equal(t, []time.Duration{time.Hour, time.Hour}, clock.delays)
+}
+
+func TestCircuitBreakerClock(t *testing.T) {
+	clock := &fakeClock{now: time.Unix(0, 0)}
+	b := newCircuitBreaker(1, time.Hour, clock)
+	b.record(0, errConnection)
+	require.False(t, b.available())
+
+	clock.advance(time.Hour - time.Second)
+	require.False(t, b.available())
+	clock.advance(time.Second)
+	require.True(t, b.available())
+}
+
+func TestResubscribeClock(t *testing.T) {
+	var buf bytes.Buffer
+	r := testResubscriber(&buf)
+	r.backoff = backoff{minDelay: time.Hour, maxDelay: time.Hour}
+	clock := &fakeClock{}
+	r.clock = clock
+
+	first, second := make(chan uint64), make(chan uint64, 1)
+	close(first)
+	second <- 1
+	subscribe, _ := subscriptions(first, second)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	out, err := resubscribe(ctx, r, "header.Subscribe", subscribe, nil)
+	require.NoError(t, err)
+	require.EqualValues(t, 1, <-out)
+	cancel()
+	close(second)
+	drain(out)
+	require.Equal(t, []time.Duration{time.Hour}, clock.delays)
+}
+
+func TestHedgeClock(t *testing.T) {
+	p := testProxy("slow", "fast")
+	clock := &fakeClock{}
+	intercept := hedge(p, time.Hour, defaultHedgedMethods, clock)
+
+	next := func(ctx context.Context, _ *call) ([]interface{}, error) {
+		idx, _ := pinnedEndpoint(ctx)
+		if p.endpoints[idx].addr == "slow" {
+			<-ctx.Done()
+			return nil, ctx.Err()
+		}
+		return []interface{}{p.endpoints[idx].addr}, nil
+	}
+
+	out, err := intercept(context.Background(), &call{module: "header", method: "GetByHeight", perm: "read"}, next)
+	require.NoError(t, err)
+	require.Equal(t, []interface{}{"fast"}, out)
+	require.Equal(t, []time.Duration{time.Hour}, clock.delays)
+}
+
+func TestRateLimitClock(t *testing.T) {
+	clock := &fakeClock{now: time.Unix(0, 0)}
+	b := newTokenBucket(1, 1)
+	require.NoError(t, b.wait(context.Background(), clock))
+	require.NoError(t, b.wait(context.Background(), clock))
+	require.Equal(t, []time.Duration{time.Second}, clock.delays)
+
+	// the bucket is refilled as the clock advances
+	clock.advance(time.Hour)
+	require.NoError(t, b.wait(context.Background(), clock))
+	require.Len(t, clock.delays, 1)
+}
+
+func TestPollClock(t *testing.T) {
+	clock := &fakeClock{}
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	var polls int
+	c := &Client{clock: clock}
+	c.Header.LocalHead = func(context.Context) (*header.ExtendedHeader, error) {
+		if polls++; polls == 4 {
+			cancel()
+		}
+		return nil, errors.New("connection refused")
+	}
+
+	sub, err := c.PollBlobs(ctx, share.Namespace(testBlob(t, "hello").Namespace().Bytes()),
+		WithPollStartHeight(11),
+		WithPollInterval(time.Hour, time.Hour, 4*time.Hour),
+		WithPollJitter(0),
+	)
+	require.NoError(t, err)
+	drain(sub)
+	require.Equal(t, []time.Duration{time.Hour, 2 * time.Hour, 4 * time.Hour, 4 * time.Hour}, clock.delays[:4])
+}
@@ -0,0 +1,158 @@
+package client
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/celestiaorg/celestia-openrpc/rpctest"
+)
+
+// blockingNode returns a node whose node.Ready calls block until release is closed,
+// signalling on started once they arrived.
+func blockingNode(t *testing.T) (addr string, started chan struct{}, release chan struct{}) {
+	started, release = make(chan struct{}, 1), make(chan struct{})
+	srv := testNode(t, func(_ *http.Request, method string, _ json.RawMessage) (interface{}, error) {
+		if method != "node.Ready" {
+			return nil, errors.New("method not found")
+		}
+		started <- struct{}{}
+		<-release
+		return true, nil
+	})
+	return srv.URL, started, release
+}
+
+func TestShutdownWaitsForCalls(t *testing.T) {
+	addr, started, release := blockingNode(t)
+	c, err := NewClient(context.Background(), addr, "")
+	require.NoError(t, err)
+
+	ready := make(chan error, 1)
+	go func() {
+		_, err := c.Node.Ready(context.Background())
+		ready <- err
+	}()
+	<-started
+
+	shutdown := make(chan error, 1)
+	go func() {
+		shutdown <- c.Shutdown(context.Background())
+	}()
+	require.Eventually(t, func() bool {
+		c.callsMu.RLock()
+		defer c.callsMu.RUnlock()
+		return c.closed
+	}, time.Second, time.Millisecond)
+	// new calls are rejected while the one in flight is awaited
+	_, err = c.Node.Ready(context.Background())
+	require.ErrorIs(t, err, ErrClientClosed)
+	select {
+	case <-shutdown:
+		t.Fatal("shutdown didn't wait for the call in flight")
+	default:
+	}
+
+	close(release)
+	require.NoError(t, <-ready)
+	require.NoError(t, <-shutdown)
+}
+
+func TestShutdownWaitsForBatches(t *testing.T) {
+	started, release := make(chan struct{}, 1), make(chan struct{})
+	transport := TransportFunc(func(context.Context, string, http.Header, []byte) (io.ReadCloser, error) {
+		started <- struct{}{}
+		<-release
+		return io.NopCloser(strings.NewReader(`[{"jsonrpc":"2.0","id":0,"result":true}]`)), nil
+	})
+	c := &Client{proxy: newProxy([]*endpoint{{addr: "memory", transport: transport}}), log: discardLogger}
+	c.closing, c.stopAll = context.WithCancel(context.Background())
+
+	var ready bool
+	b := c.Batch()
+	b.Add("node.Ready", &ready)
+	sent := make(chan error, 1)
+	go func() {
+		sent <- b.Send(context.Background())
+	}()
+	<-started
+
+	shutdown := make(chan error, 1)
+	go func() {
+		shutdown <- c.Shutdown(context.Background())
+	}()
+	require.Eventually(t, func() bool {
+		c.callsMu.RLock()
+		defer c.callsMu.RUnlock()
+		return c.closed
+	}, time.Second, time.Millisecond)
+	// new batches are rejected while the one in flight is awaited
+	rejected := c.Batch()
+	rejected.Add("node.Ready", nil)
+	require.ErrorIs(t, rejected.Send(context.Background()), ErrClientClosed)
+	select {
+	case <-shutdown:
+		t.Fatal("shutdown didn't wait for the batch in flight")
+	default:
+	}
+
+	close(release)
+	require.NoError(t, <-sent)
+	require.True(t, ready)
+	require.NoError(t, <-shutdown)
+}
+
+func TestShutdownDeadline(t *testing.T) {
+	addr, started, release := blockingNode(t)
+	defer close(release)
+	c, err := NewClient(context.Background(), addr, "")
+	require.NoError(t, err)
+
+	go func() {
+		_, _ = c.Node.Ready(context.Background())
+	}()
+	<-started
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	require.ErrorIs(t, c.Shutdown(ctx), context.DeadlineExceeded)
+	// closing again is a no-op
+	require.NoError(t, c.Shutdown(context.Background()))
+}
+
+func TestCloseCancelsSubscriptions(t *testing.T) {
+	for name, opts := range map[string][]Option{
+		"resubscribing":     nil,
+		"without reconnect": {WithoutReconnect()},
+	} {
+		t.Run(name, func(t *testing.T) {
+			srv := rpctest.NewServer()
+			defer srv.Close()
+			c, err := NewClient(context.Background(), srv.WebSocketURL(), "", opts...)
+			require.NoError(t, err)
+
+			sub, err := c.Header.Subscribe(context.Background())
+			require.NoError(t, err)
+			c.Close()
+
+			timeout := time.After(time.Second)
+			for {
+				select {
+				case _, ok := <-sub:
+					if !ok {
+						return
+					}
+				case <-timeout:
+					t.Fatal("subscription wasn't closed")
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,101 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"os"
+
+	client "github.com/celestiaorg/celestia-openrpc"
+	"github.com/celestiaorg/celestia-openrpc/nsarchive"
+	"github.com/celestiaorg/celestia-openrpc/types/blob"
+)
+
+/*
+	nsarchive dumps all blobs of a namespace over a range of heights to
+	content-addressed files, and verifies or re-submits such an archive.
+
+	Usage:
+		nsarchive export   -namespace <hex> -from <height> [-to <height>] -dir <path>
+		nsarchive verify   -dir <path> [-offline]
+		nsarchive resubmit -dir <path>
+
+	Unless provided with -to, the export runs up to the local head of the node.
+	The auth token is read from the CELESTIA_NODE_AUTH_TOKEN environment
+	variable unless provided with -token.
+*/
+
+func main() {
+	if len(os.Args) < 2 {
+		fmt.Fprintln(os.Stderr, "usage: nsarchive <export|verify|resubmit> [flags]")
+		os.Exit(2)
+	}
+
+	if err := run(context.Background(), os.Args[1], os.Args[2:]); err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(1)
+	}
+}
+
+func run(ctx context.Context, verb string, args []string) error {
+	flags := flag.NewFlagSet(verb, flag.ExitOnError)
+	addr := flags.String("addr", "ws://localhost:26658", "celestia-node RPC address")
+	token := flags.String("token", os.Getenv("CELESTIA_NODE_AUTH_TOKEN"), "celestia-node auth token")
+	dir := flags.String("dir", "", "archive directory")
+	nsHex := flags.String("namespace", "", "namespace to export, hex encoded")
+	from := flags.Uint64("from", 1, "first height to export")
+	to := flags.Uint64("to", 0, "last height to export, defaults to the local head of the node")
+	offline := flags.Bool("offline", false, "verify the archive without checking proofs against the node")
+	if err := flags.Parse(args); err != nil {
+		return err
+	}
+	if *dir == "" {
+		return fmt.Errorf("-dir is required")
+	}
+
+	var c *client.Client
+	if verb != "verify" || !*offline {
+		var err error
+		c, err = client.NewClient(ctx, *addr, *token)
+		if err != nil {
+			return err
+		}
+		defer c.Close()
+	}
+
+	switch verb {
+	case "export":
+		namespace, err := nsarchive.ParseNamespace(*nsHex)
+		if err != nil {
+			return err
+		}
+		if *to == 0 {
+			head, err := c.Header.LocalHead(ctx)
+			if err != nil {
+				return fmt.Errorf("getting local head: %w", err)
+			}
+			*to = head.Height()
+		}
+		manifest, err := nsarchive.Export(ctx, c, namespace, *from, *to, *dir)
+		if err != nil {
+			return err
+		}
+		fmt.Printf("Exported %d blobs to %s\n", len(manifest.Entries), *dir)
+	case "verify":
+		if err := nsarchive.Verify(ctx, c, *dir); err != nil {
+			return err
+		}
+		fmt.Println("Archive is valid")
+	case "resubmit":
+		heights, err := nsarchive.Resubmit(ctx, c, *dir, blob.NewSubmitOptions())
+		if err != nil {
+			return err
+		}
+		for old, height := range heights {
+			fmt.Printf("Blobs of height %d were included at height %d\n", old, height)
+		}
+	default:
+		return fmt.Errorf("unknown verb %q", verb)
+	}
+	return nil
+}
@@ -0,0 +1,89 @@
+package client
+
+import (
+	"bytes"
+	"compress/gzip"
+	"compress/zlib"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+)
+
+// compressionRoundTripper negotiates compressed responses, decompressing them, and
+// compresses the bodies of requests with gzip if compressRequests is set.
+type compressionRoundTripper struct {
+	next             http.RoundTripper
+	compressRequests bool
+}
+
+func (rt *compressionRoundTripper) RoundTrip(req *http.Request) (*http.Response, error) {
+	req = req.Clone(req.Context())
+	if rt.compressRequests && req.Body != nil && req.Body != http.NoBody {
+		body, err := gzipBody(req.Body)
+		if err != nil {
+			return nil, fmt.Errorf("compressing request: %w", err)
+		}
+		req.Body = io.NopCloser(bytes.NewReader(body))
+		req.GetBody = func() (io.ReadCloser, error) {
+			return io.NopCloser(bytes.NewReader(body)), nil
+		}
+		req.ContentLength = int64(len(body))
+		req.Header.Set("Content-Encoding", "gzip")
+	}
+	// setting the header disables the transparent decompression of the http package,
+	// which only covers gzip
+	req.Header.Set("Accept-Encoding", "gzip, deflate")
+
+	resp, err := rt.next.RoundTrip(req)
+	if err != nil {
+		return nil, err
+	}
+	var body io.ReadCloser
+	switch strings.ToLower(resp.Header.Get("Content-Encoding")) {
+	case "gzip":
+		body, err = gzip.NewReader(resp.Body)
+	case "deflate":
+		body, err = zlib.NewReader(resp.Body)
+	default:
+		return resp, nil
+	}
+	if err != nil {
+		resp.Body.Close()
+		return nil, fmt.Errorf("decompressing response: %w", err)
+	}
+	resp.Body = &decompressedBody{ReadCloser: body, compressed: resp.Body}
+	resp.Header.Del("Content-Encoding")
+	resp.Header.Del("Content-Length")
+	resp.ContentLength = -1
+	resp.Uncompressed = true
+	return resp, nil
+}
+
+// gzipBody reads and closes the body, returning it compressed.
+func gzipBody(body io.ReadCloser) ([]byte, error) {
+	defer body.Close()
+	var buf bytes.Buffer
+	zw := gzip.NewWriter(&buf)
+	if _, err := io.Copy(zw, body); err != nil {
+		return nil, err
+	}
+	if err := zw.Close(); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+// decompressedBody closes both the decompressing reader and the compressed body it reads.
+type decompressedBody struct {
+	io.ReadCloser
+	compressed io.ReadCloser
+}
+
+func (b *decompressedBody) Close() error {
+	err := b.ReadCloser.Close()
+	if cerr := b.compressed.Close(); err == nil {
+		err = cerr
+	}
+	return err
+}
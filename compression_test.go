@@ -0,0 +1,94 @@
+package client
+
+import (
+	"compress/gzip"
+	"compress/zlib"
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+// compressingNode answers all calls with true, compressing the responses with encoding
+// and decompressing gzip requests, which it counts.
+func compressingNode(t *testing.T, encoding string) (*httptest.Server, *atomic.Int32) {
+	var compressed atomic.Int32
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		require.Contains(t, r.Header.Get("Accept-Encoding"), encoding)
+		body := io.Reader(r.Body)
+		if r.Header.Get("Content-Encoding") == "gzip" {
+			zr, err := gzip.NewReader(r.Body)
+			require.NoError(t, err)
+			body = zr
+			compressed.Add(1)
+		}
+		req, err := io.ReadAll(body)
+		require.NoError(t, err)
+
+		w.Header().Set("Content-Encoding", encoding)
+		var zw io.WriteCloser
+		if encoding == "gzip" {
+			zw = gzip.NewWriter(w)
+		} else {
+			zw = zlib.NewWriter(w)
+		}
+		_, _ = zw.Write(rpcResponse(t, req, true))
+		require.NoError(t, zw.Close())
+	}))
+	t.Cleanup(srv.Close)
+	return srv, &compressed
+}
+
+func TestCompression(t *testing.T) {
+	for _, encoding := range []string{"gzip", "deflate"} {
+		t.Run(encoding, func(t *testing.T) {
+			srv, compressed := compressingNode(t, encoding)
+			c, err := NewClient(context.Background(), srv.URL, "", WithCompression(false))
+			require.NoError(t, err)
+			defer c.Close()
+
+			ready, err := c.Node.Ready(context.Background())
+			require.NoError(t, err)
+			require.True(t, ready)
+			require.Zero(t, compressed.Load())
+		})
+	}
+}
+
+func TestRequestCompression(t *testing.T) {
+	srv, compressed := compressingNode(t, "gzip")
+	c, err := NewClient(context.Background(), srv.URL, "", WithCompression(true))
+	require.NoError(t, err)
+	defer c.Close()
+
+	before := compressed.Load()
+	ready, err := c.Node.Ready(context.Background())
+	require.NoError(t, err)
+	require.True(t, ready)
+	require.Equal(t, before+1, compressed.Load())
+}
+
+func TestCompressionRoundTripperUncompressed(t *testing.T) {
+	rt := &compressionRoundTripper{next: roundTripperFunc(func(*http.Request) (*http.Response, error) {
+		return &http.Response{
+			StatusCode:    http.StatusOK,
+			Header:        http.Header{},
+			Body:          io.NopCloser(strings.NewReader("plain")),
+			ContentLength: 5,
+		}, nil
+	})}
+	req, err := http.NewRequest(http.MethodPost, "http://node", strings.NewReader("{}"))
+	require.NoError(t, err)
+	resp, err := rt.RoundTrip(req)
+	require.NoError(t, err)
+	defer resp.Body.Close()
+	body, err := io.ReadAll(resp.Body)
+	require.NoError(t, err)
+	require.Equal(t, "plain", string(body))
+	require.EqualValues(t, 5, resp.ContentLength)
+}
@@ -0,0 +1,90 @@
+package client
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/celestiaorg/celestia-openrpc/types/blob"
+	"github.com/celestiaorg/celestia-openrpc/types/share"
+	"github.com/celestiaorg/celestia-openrpc/types/state"
+)
+
+// InclusionReceipt proves to the submitter where the blobs of a confirmed submission are.
+type InclusionReceipt struct {
+	// Height is the height the blobs were included at.
+	Height uint64
+	// TxHash is the hash of the PayForBlobs transaction.
+	TxHash string
+	// Blobs describes every submitted blob, in the order they were submitted.
+	Blobs []BlobReceipt
+}
+
+// BlobReceipt locates a blob included in a block.
+type BlobReceipt struct {
+	Namespace  share.Namespace
+	Commitment blob.Commitment
+	// Start and End are the indexes of the first share of the blob and the one after
+	// its last in the original data square, as expected by share.API.GetRange.
+	Start, End int
+}
+
+// SubmitAndConfirm submits the blobs in a PayForBlobs transaction and waits until the
+// node has processed the header of the block including them. The returned receipt
+// locates every blob in the block, so they can be retrieved or proven later on.
+func (c *Client) SubmitAndConfirm(
+	ctx context.Context,
+	blobs []*blob.Blob,
+	config *state.TxConfig,
+) (*InclusionReceipt, error) {
+	resp, err := c.State.SubmitPayForBlob(ctx, blobs, config)
+	if err != nil {
+		return nil, err
+	}
+	if resp.Code != 0 {
+		return nil, fmt.Errorf("submission %s failed with code %d: %s", resp.TxHash, resp.Code, resp.RawLog)
+	}
+	if resp.Height <= 0 {
+		return nil, fmt.Errorf("submission %s wasn't included", resp.TxHash)
+	}
+
+	//nolint:gosec
+	receipt := &InclusionReceipt{Height: uint64(resp.Height), TxHash: resp.TxHash}
+	receipt.Blobs, err = c.locateBlobs(ctx, receipt.Height, blobs)
+	if err != nil {
+		return nil, err
+	}
+	return receipt, nil
+}
+
+// locateBlobs waits for the header at the height and locates the blobs included at it in
+// the original data square.
+func (c *Client) locateBlobs(ctx context.Context, height uint64, blobs []*blob.Blob) ([]BlobReceipt, error) {
+	eh, err := c.Header.WaitForHeight(ctx, height)
+	if err != nil {
+		return nil, fmt.Errorf("waiting for the header at height %d: %w", height, err)
+	}
+	odsWidth, err := squareWidth(eh)
+	if err != nil {
+		return nil, fmt.Errorf("header at height %d: %w", height, err)
+	}
+
+	receipts := make([]BlobReceipt, 0, len(blobs))
+	for i, b := range blobs {
+		namespace := share.Namespace(b.Namespace().Bytes())
+		included, err := c.Blob.Get(ctx, height, namespace, b.Commitment)
+		if err != nil {
+			return nil, fmt.Errorf("getting blob %d at height %d: %w", i, height, err)
+		}
+		start, end, err := included.ShareRange(odsWidth)
+		if err != nil {
+			return nil, fmt.Errorf("blob %d at height %d: %w", i, height, err)
+		}
+		receipts = append(receipts, BlobReceipt{
+			Namespace:  namespace,
+			Commitment: b.Commitment,
+			Start:      start,
+			End:        end,
+		})
+	}
+	return receipts, nil
+}
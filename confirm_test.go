@@ -0,0 +1,74 @@
+package client
+
+import (
+	"context"
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/celestiaorg/celestia-openrpc/mocks"
+	"github.com/celestiaorg/celestia-openrpc/types/blob"
+	"github.com/celestiaorg/celestia-openrpc/types/header"
+	"github.com/celestiaorg/celestia-openrpc/types/share"
+	"github.com/celestiaorg/celestia-openrpc/types/state"
+)
+
+// withIndex returns a copy of the blob as retrieved from the node, with its first share
+// at the index of the extended square.
+func withIndex(t *testing.T, b *blob.Blob, index int) *blob.Blob {
+	t.Helper()
+	data, err := json.Marshal(b)
+	require.NoError(t, err)
+	var fields map[string]interface{}
+	require.NoError(t, json.Unmarshal(data, &fields))
+	fields["index"] = index
+	data, err = json.Marshal(fields)
+	require.NoError(t, err)
+	included := &blob.Blob{}
+	require.NoError(t, json.Unmarshal(data, included))
+	return included
+}
+
+func TestSubmitAndConfirm(t *testing.T) {
+	b := testBlob(t, strings.Repeat("x", 1000))
+	eh := mocks.NewHeader(7)
+	// an original square of 4x4 shares
+	eh.DAH = &header.DataAvailabilityHeader{RowRoots: make([][]byte, 8), ColumnRoots: make([][]byte, 8)}
+
+	c := &Client{}
+	c.State.SubmitPayForBlob = func(context.Context, []*blob.Blob, *state.TxConfig) (*state.TxResponse, error) {
+		return &state.TxResponse{Height: 7, TxHash: "ABCD"}, nil
+	}
+	c.Header.WaitForHeight = func(_ context.Context, height uint64) (*header.ExtendedHeader, error) {
+		require.EqualValues(t, 7, height)
+		return eh, nil
+	}
+	c.Blob.Get = func(context.Context, uint64, share.Namespace, blob.Commitment) (*blob.Blob, error) {
+		// the second share of the second row of the extended square
+		return withIndex(t, b, 9), nil
+	}
+
+	receipt, err := c.SubmitAndConfirm(context.Background(), []*blob.Blob{b}, nil)
+	require.NoError(t, err)
+	require.Equal(t, &InclusionReceipt{
+		Height: 7,
+		TxHash: "ABCD",
+		Blobs: []BlobReceipt{{
+			Namespace:  share.Namespace(b.Namespace().Bytes()),
+			Commitment: b.Commitment,
+			Start:      5,
+			End:        8,
+		}},
+	}, receipt)
+}
+
+func TestSubmitAndConfirmRejected(t *testing.T) {
+	c := &Client{}
+	c.State.SubmitPayForBlob = func(context.Context, []*blob.Blob, *state.TxConfig) (*state.TxResponse, error) {
+		return &state.TxResponse{TxHash: "ABCD", Code: 11, RawLog: "out of gas"}, nil
+	}
+	_, err := c.SubmitAndConfirm(context.Background(), []*blob.Blob{testBlob(t, "hello")}, nil)
+	require.ErrorContains(t, err, "out of gas")
+}
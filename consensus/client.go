@@ -0,0 +1,313 @@
+package consensus
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+
+	cmproto "github.com/cometbft/cometbft/proto/tendermint/types"
+	coretypes "github.com/cometbft/cometbft/types"
+	"google.golang.org/grpc"
+	"google.golang.org/protobuf/encoding/protowire"
+
+	"github.com/celestiaorg/celestia-openrpc/types/blob"
+)
+
+const (
+	broadcastTxMethod      = "/cosmos.tx.v1beta1.Service/BroadcastTx"
+	getBlockByHeightMethod = "/cosmos.base.tendermint.v1beta1.Service/GetBlockByHeight"
+	getLatestBlockMethod   = "/cosmos.base.tendermint.v1beta1.Service/GetLatestBlock"
+	configMethod           = "/cosmos.base.node.v1beta1.Service/Config"
+	getNodeInfoMethod      = "/cosmos.base.tendermint.v1beta1.Service/GetNodeInfo"
+	accountMethod          = "/cosmos.auth.v1beta1.Query/Account"
+	blobParamsMethod       = "/celestia.blob.v1.Query/Params"
+
+	// gasDenom is the denomination gas prices are paid in.
+	gasDenom = "utia"
+)
+
+// BroadcastMode determines when BroadcastTx returns.
+type BroadcastMode int32
+
+const (
+	// BroadcastSync returns once the transaction passed CheckTx.
+	BroadcastSync BroadcastMode = 2
+	// BroadcastAsync returns immediately after the transaction was handed to the node.
+	BroadcastAsync BroadcastMode = 3
+)
+
+// TxResponse is the result of broadcasting a transaction.
+type TxResponse struct {
+	Height    int64
+	TxHash    string
+	Codespace string
+	Code      uint32
+	RawLog    string
+	GasWanted int64
+	GasUsed   int64
+}
+
+// Client talks to the gRPC server of a consensus node, e.g. celestia-app,
+// bypassing the JSON-RPC gateway of celestia-node.
+type Client struct {
+	conn *grpc.ClientConn
+}
+
+// NewClient connects to the gRPC server of the consensus node at target, e.g. "localhost:9090".
+// Transport credentials must be provided via opts, e.g. grpc.WithTransportCredentials.
+func NewClient(ctx context.Context, target string, opts ...grpc.DialOption) (*Client, error) {
+	conn, err := grpc.DialContext(ctx, target, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return &Client{conn: conn}, nil
+}
+
+// Close closes the connection to the node.
+func (c *Client) Close() error {
+	return c.conn.Close()
+}
+
+// BroadcastTx broadcasts the signed, encoded transaction.
+// A non-zero Code of the response reports the transaction was rejected.
+func (c *Client) BroadcastTx(ctx context.Context, tx []byte, mode BroadcastMode) (*TxResponse, error) {
+	var req []byte
+	req = protowire.AppendTag(req, 1, protowire.BytesType)
+	req = protowire.AppendBytes(req, tx)
+	req = protowire.AppendTag(req, 2, protowire.VarintType)
+	req = protowire.AppendVarint(req, uint64(mode))
+
+	var resp []byte
+	if err := c.invoke(ctx, broadcastTxMethod, req, &resp); err != nil {
+		return nil, err
+	}
+	txResp, err := field(resp, 1)
+	if err != nil {
+		return nil, err
+	}
+	return decodeTxResponse(txResp)
+}
+
+// BroadcastBlobTx wraps the signed transaction paying for the blobs, e.g. built from
+// blob.NewMsgPayForBlobs, into a BlobTx and broadcasts it. Unlike submitting through
+// celestia-node, the State module and the key held by the node aren't involved.
+func (c *Client) BroadcastBlobTx(
+	ctx context.Context,
+	tx []byte,
+	blobs []*blob.Blob,
+	mode BroadcastMode,
+) (*TxResponse, error) {
+	blobTx, err := blob.MarshalBlobTx(tx, blobs...)
+	if err != nil {
+		return nil, err
+	}
+	return c.BroadcastTx(ctx, blobTx, mode)
+}
+
+// GetBlockByHeight returns the block at the given height.
+func (c *Client) GetBlockByHeight(ctx context.Context, height int64) (*coretypes.Block, error) {
+	var req []byte
+	req = protowire.AppendTag(req, 1, protowire.VarintType)
+	//nolint:gosec
+	req = protowire.AppendVarint(req, uint64(height))
+	return c.getBlock(ctx, getBlockByHeightMethod, req)
+}
+
+// GetLatestBlock returns the latest committed block.
+func (c *Client) GetLatestBlock(ctx context.Context) (*coretypes.Block, error) {
+	return c.getBlock(ctx, getLatestBlockMethod, nil)
+}
+
+// MinimumGasPrice returns the minimum gas price in utia accepted by the node, as set in its
+// configuration. Transactions paying less are rejected by the node before reaching the mempool.
+func (c *Client) MinimumGasPrice(ctx context.Context) (float64, error) {
+	var resp []byte
+	if err := c.invoke(ctx, configMethod, nil, &resp); err != nil {
+		return 0, err
+	}
+	price, err := field(resp, 1)
+	if err != nil {
+		return 0, err
+	}
+	return parseGasPrice(string(price))
+}
+
+// GovMaxSquareSize returns the maximum width of the original data square set by governance.
+func (c *Client) GovMaxSquareSize(ctx context.Context) (int, error) {
+	var resp []byte
+	if err := c.invoke(ctx, blobParamsMethod, nil, &resp); err != nil {
+		return 0, err
+	}
+	params, err := field(resp, 1)
+	if err != nil {
+		return 0, err
+	}
+	var size uint64
+	err = walk(params, func(n protowire.Number, typ protowire.Type, b []byte) int {
+		if n != 2 || typ != protowire.VarintType {
+			return protowire.ConsumeFieldValue(n, typ, b)
+		}
+		v, m := protowire.ConsumeVarint(b)
+		size = v
+		return m
+	})
+	if err != nil {
+		return 0, err
+	}
+	if size == 0 || size > math.MaxInt32 {
+		return 0, fmt.Errorf("invalid maximum square size %d", size)
+	}
+	return int(size), nil
+}
+
+// Limits returns the limits enforced by the network on submissions, as of the app version
+// of the latest block and the governance parameters.
+func (c *Client) Limits(ctx context.Context) (blob.Limits, error) {
+	block, err := c.GetLatestBlock(ctx)
+	if err != nil {
+		return blob.Limits{}, fmt.Errorf("getting the latest block: %w", err)
+	}
+	size, err := c.GovMaxSquareSize(ctx)
+	if err != nil {
+		return blob.Limits{}, fmt.Errorf("getting the maximum square size: %w", err)
+	}
+	return blob.NewLimits(block.Header.Version.App, size), nil
+}
+
+// parseGasPrice returns the utia price of the decimal coins, e.g. "0.002000000000000000utia".
+// Nodes not requiring a minimum price return no coins.
+func parseGasPrice(coins string) (float64, error) {
+	if coins == "" {
+		return 0, nil
+	}
+	for _, coin := range strings.Split(coins, ",") {
+		amount, ok := strings.CutSuffix(strings.TrimSpace(coin), gasDenom)
+		if !ok {
+			continue
+		}
+		price, err := strconv.ParseFloat(amount, 64)
+		if err != nil {
+			return 0, fmt.Errorf("parsing gas price %q: %w", coin, err)
+		}
+		return price, nil
+	}
+	return 0, fmt.Errorf("no gas price in %s in %q", gasDenom, coins)
+}
+
+func (c *Client) getBlock(ctx context.Context, method string, req []byte) (*coretypes.Block, error) {
+	var resp []byte
+	if err := c.invoke(ctx, method, req, &resp); err != nil {
+		return nil, err
+	}
+	raw, err := field(resp, 2)
+	if err != nil {
+		return nil, err
+	}
+
+	var pb cmproto.Block
+	if err := pb.Unmarshal(raw); err != nil {
+		return nil, fmt.Errorf("unmarshaling block: %w", err)
+	}
+	return coretypes.BlockFromProto(&pb)
+}
+
+func (c *Client) invoke(ctx context.Context, method string, req []byte, resp *[]byte) error {
+	return c.conn.Invoke(ctx, method, req, resp, grpc.ForceCodec(rawCodec{}))
+}
+
+// rawCodec passes already encoded messages through as is, as the messages
+// of the consensus node are encoded and decoded by hand.
+type rawCodec struct{}
+
+func (rawCodec) Marshal(v interface{}) ([]byte, error) {
+	b, ok := v.([]byte)
+	if !ok {
+		return nil, fmt.Errorf("unexpected message type %T", v)
+	}
+	return b, nil
+}
+
+func (rawCodec) Unmarshal(data []byte, v interface{}) error {
+	b, ok := v.(*[]byte)
+	if !ok {
+		return fmt.Errorf("unexpected message type %T", v)
+	}
+	*b = append((*b)[:0], data...)
+	return nil
+}
+
+func (rawCodec) Name() string {
+	return "raw"
+}
+
+// field returns the value of the length-delimited field with the given number.
+// A missing field is treated as empty.
+func field(msg []byte, num protowire.Number) ([]byte, error) {
+	var value []byte
+	err := walk(msg, func(n protowire.Number, typ protowire.Type, b []byte) int {
+		if n != num || typ != protowire.BytesType {
+			return protowire.ConsumeFieldValue(n, typ, b)
+		}
+		v, m := protowire.ConsumeBytes(b)
+		value = v
+		return m
+	})
+	return value, err
+}
+
+func decodeTxResponse(msg []byte) (*TxResponse, error) {
+	resp := &TxResponse{}
+	err := walk(msg, func(n protowire.Number, typ protowire.Type, b []byte) int {
+		switch {
+		case typ == protowire.VarintType:
+			v, m := protowire.ConsumeVarint(b)
+			//nolint:gosec
+			switch n {
+			case 1:
+				resp.Height = int64(v)
+			case 4:
+				resp.Code = uint32(v)
+			case 9:
+				resp.GasWanted = int64(v)
+			case 10:
+				resp.GasUsed = int64(v)
+			}
+			return m
+		case typ == protowire.BytesType && (n == 2 || n == 3 || n == 6):
+			v, m := protowire.ConsumeString(b)
+			switch n {
+			case 2:
+				resp.TxHash = v
+			case 3:
+				resp.Codespace = v
+			case 6:
+				resp.RawLog = v
+			}
+			return m
+		default:
+			return protowire.ConsumeFieldValue(n, typ, b)
+		}
+	})
+	return resp, err
+}
+
+// walk calls fn for every field of the message with the bytes following its tag.
+// fn returns the length of the consumed value or a negative number on failure.
+func walk(msg []byte, fn func(protowire.Number, protowire.Type, []byte) int) error {
+	for len(msg) > 0 {
+		num, typ, n := protowire.ConsumeTag(msg)
+		if n < 0 {
+			return errors.New("malformed message tag")
+		}
+		msg = msg[n:]
+		m := fn(num, typ, msg)
+		if m < 0 {
+			return fmt.Errorf("malformed field %d", num)
+		}
+		msg = msg[m:]
+	}
+	return nil
+}
@@ -0,0 +1,194 @@
+package consensus
+
+import (
+	"context"
+	"net"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/test/bufconn"
+	"google.golang.org/protobuf/encoding/protowire"
+
+	"github.com/celestiaorg/celestia-openrpc/types/blob"
+	"github.com/celestiaorg/celestia-openrpc/types/share"
+)
+
+func TestDecodeTxResponse(t *testing.T) {
+	var msg []byte
+	msg = protowire.AppendTag(msg, 1, protowire.VarintType)
+	msg = protowire.AppendVarint(msg, 42)
+	msg = protowire.AppendTag(msg, 2, protowire.BytesType)
+	msg = protowire.AppendString(msg, "ABCD")
+	msg = protowire.AppendTag(msg, 3, protowire.BytesType)
+	msg = protowire.AppendString(msg, "sdk")
+	msg = protowire.AppendTag(msg, 4, protowire.VarintType)
+	msg = protowire.AppendVarint(msg, 5)
+	// unknown fields are skipped
+	msg = protowire.AppendTag(msg, 5, protowire.BytesType)
+	msg = protowire.AppendString(msg, "data")
+	msg = protowire.AppendTag(msg, 6, protowire.BytesType)
+	msg = protowire.AppendString(msg, "insufficient funds")
+	msg = protowire.AppendTag(msg, 9, protowire.VarintType)
+	msg = protowire.AppendVarint(msg, 100000)
+	msg = protowire.AppendTag(msg, 10, protowire.VarintType)
+	msg = protowire.AppendVarint(msg, 80000)
+
+	resp, err := decodeTxResponse(msg)
+	require.NoError(t, err)
+	require.Equal(t, &TxResponse{
+		Height:    42,
+		TxHash:    "ABCD",
+		Codespace: "sdk",
+		Code:      5,
+		RawLog:    "insufficient funds",
+		GasWanted: 100000,
+		GasUsed:   80000,
+	}, resp)
+}
+
+func TestDecodeTxResponseMalformed(t *testing.T) {
+	msg := protowire.AppendTag(nil, 2, protowire.BytesType)
+	msg = protowire.AppendVarint(msg, 10) // the length exceeds the message
+	_, err := decodeTxResponse(msg)
+	require.Error(t, err)
+
+	_, err = decodeTxResponse([]byte{0xff})
+	require.Error(t, err)
+}
+
+func TestField(t *testing.T) {
+	var msg []byte
+	msg = protowire.AppendTag(msg, 1, protowire.VarintType)
+	msg = protowire.AppendVarint(msg, 7)
+	msg = protowire.AppendTag(msg, 2, protowire.BytesType)
+	msg = protowire.AppendBytes(msg, []byte("block"))
+
+	value, err := field(msg, 2)
+	require.NoError(t, err)
+	require.Equal(t, []byte("block"), value)
+
+	// a missing field is empty
+	value, err = field(msg, 3)
+	require.NoError(t, err)
+	require.Empty(t, value)
+}
+
+func TestParseGasPrice(t *testing.T) {
+	price, err := parseGasPrice("0.002000000000000000utia")
+	require.NoError(t, err)
+	require.Equal(t, 0.002, price)
+
+	price, err = parseGasPrice("1.5stake,0.1utia")
+	require.NoError(t, err)
+	require.Equal(t, 0.1, price)
+
+	price, err = parseGasPrice("")
+	require.NoError(t, err)
+	require.Zero(t, price)
+
+	_, err = parseGasPrice("1.5stake")
+	require.Error(t, err)
+	_, err = parseGasPrice("xutia")
+	require.Error(t, err)
+}
+
+func TestRawCodec(t *testing.T) {
+	var c rawCodec
+	b, err := c.Marshal([]byte("msg"))
+	require.NoError(t, err)
+	require.Equal(t, []byte("msg"), b)
+	_, err = c.Marshal("msg")
+	require.Error(t, err)
+
+	var out []byte
+	require.NoError(t, c.Unmarshal([]byte("resp"), &out))
+	require.Equal(t, []byte("resp"), out)
+	require.Error(t, c.Unmarshal([]byte("resp"), out))
+}
+
+func TestBroadcastBlobTx(t *testing.T) {
+	var method string
+	var req []byte
+	c := newTestClient(t, func(stream grpc.ServerStream) error {
+		method, _ = grpc.MethodFromServerStream(stream)
+		if err := stream.RecvMsg(&req); err != nil {
+			return err
+		}
+		var txResp []byte
+		txResp = protowire.AppendTag(txResp, 2, protowire.BytesType)
+		txResp = protowire.AppendString(txResp, "ABCD")
+		resp := protowire.AppendTag(nil, 1, protowire.BytesType)
+		return stream.SendMsg(protowire.AppendBytes(resp, txResp))
+	})
+
+	namespace, err := share.NewBlobNamespaceV0([]byte{1, 2, 3, 4})
+	require.NoError(t, err)
+	b, err := blob.NewBlobV0(namespace, []byte("hello"))
+	require.NoError(t, err)
+
+	resp, err := c.BroadcastBlobTx(context.Background(), []byte("signed tx"), []*blob.Blob{b}, BroadcastSync)
+	require.NoError(t, err)
+	require.Equal(t, "ABCD", resp.TxHash)
+	require.Equal(t, broadcastTxMethod, method)
+
+	// the request carries the BlobTx and the mode
+	raw, err := field(req, 1)
+	require.NoError(t, err)
+	btx, err := blob.UnmarshalBlobTx(raw)
+	require.NoError(t, err)
+	require.Equal(t, []byte("signed tx"), btx.Tx)
+	require.Len(t, btx.Blobs, 1)
+	require.Equal(t, b.Commitment, btx.Blobs[0].Commitment)
+
+	// a BlobTx without blobs isn't broadcast
+	req = nil
+	_, err = c.BroadcastBlobTx(context.Background(), []byte("signed tx"), nil, BroadcastSync)
+	require.Error(t, err)
+	require.Nil(t, req)
+}
+
+// newTestClient returns a client of an in-memory server answering every call with handle.
+func newTestClient(t *testing.T, handle func(grpc.ServerStream) error) *Client {
+	t.Helper()
+	lis := bufconn.Listen(1 << 20)
+	srv := grpc.NewServer(
+		grpc.ForceServerCodec(rawCodec{}),
+		grpc.UnknownServiceHandler(func(_ interface{}, stream grpc.ServerStream) error {
+			return handle(stream)
+		}),
+	)
+	go srv.Serve(lis) //nolint:errcheck
+	t.Cleanup(srv.Stop)
+
+	c, err := NewClient(context.Background(), "bufnet",
+		grpc.WithContextDialer(func(ctx context.Context, _ string) (net.Conn, error) {
+			return lis.DialContext(ctx)
+		}),
+		grpc.WithTransportCredentials(insecure.NewCredentials()),
+	)
+	require.NoError(t, err)
+	t.Cleanup(func() { c.Close() })
+	return c
+}
+
+func TestGovMaxSquareSize(t *testing.T) {
+	c := newTestClient(t, func(stream grpc.ServerStream) error {
+		var req []byte
+		if err := stream.RecvMsg(&req); err != nil {
+			return err
+		}
+		var params []byte
+		params = protowire.AppendTag(params, 1, protowire.VarintType)
+		params = protowire.AppendVarint(params, 8)
+		params = protowire.AppendTag(params, 2, protowire.VarintType)
+		params = protowire.AppendVarint(params, 128)
+		resp := protowire.AppendTag(nil, 1, protowire.BytesType)
+		return stream.SendMsg(protowire.AppendBytes(resp, params))
+	})
+
+	size, err := c.GovMaxSquareSize(context.Background())
+	require.NoError(t, err)
+	require.Equal(t, 128, size)
+}
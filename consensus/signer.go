@@ -0,0 +1,113 @@
+package consensus
+
+import (
+	"crypto/sha256"
+	"errors"
+	"fmt"
+
+	"github.com/decred/dcrd/dcrec/secp256k1/v4"
+	"github.com/decred/dcrd/dcrec/secp256k1/v4/ecdsa"
+	"golang.org/x/crypto/ripemd160" //nolint:staticcheck // cosmos addresses are RIPEMD-160 hashes
+
+	"github.com/celestiaorg/celestia-openrpc/types/blob"
+)
+
+// signatureSize is the size of a secp256k1 signature as R || S.
+const signatureSize = 64
+
+// Signer signs transactions with a secp256k1 key held by the client, so the account paying
+// for blobs doesn't need to be held by celestia-node.
+type Signer interface {
+	// Address returns the bech32 address of the account of the key.
+	Address() string
+	// PubKey returns the compressed public key.
+	PubKey() []byte
+	// Sign signs the SHA-256 hash of msg, returning the signature as R || S.
+	Sign(msg []byte) ([]byte, error)
+}
+
+// KeySigner is a Signer holding the private key in memory, e.g. as exported from a keyring
+// with `celestia-appd keys export <name> --unarmored-hex --unsafe`.
+type KeySigner struct {
+	key     *secp256k1.PrivateKey
+	address string
+}
+
+// NewKeySigner returns a signer for the 32 bytes of the secp256k1 private key.
+func NewKeySigner(privKey []byte) (*KeySigner, error) {
+	if len(privKey) != secp256k1.PrivKeyBytesLen {
+		return nil, fmt.Errorf("private key has %d bytes, expected %d", len(privKey), secp256k1.PrivKeyBytesLen)
+	}
+	key := secp256k1.PrivKeyFromBytes(privKey)
+	if key.Key.IsZero() {
+		return nil, errors.New("invalid private key")
+	}
+	return &KeySigner{
+		key:     key,
+		address: pubKeyAddress(key.PubKey().SerializeCompressed()),
+	}, nil
+}
+
+// Address implements Signer.
+func (s *KeySigner) Address() string {
+	return s.address
+}
+
+// PubKey implements Signer.
+func (s *KeySigner) PubKey() []byte {
+	return s.key.PubKey().SerializeCompressed()
+}
+
+// Sign implements Signer.
+func (s *KeySigner) Sign(msg []byte) ([]byte, error) {
+	hash := sha256.Sum256(msg)
+	// the compact signature is prefixed by the byte recovering the public key
+	return ecdsa.SignCompact(s.key, hash[:], true)[1:], nil
+}
+
+// NewKeyringSigner returns a signer for a key held by a keyring, given its compressed public
+// key and a function signing with it. The keys of a cosmos-sdk keyring sign the same way,
+// so their Sign method can be used as is:
+//
+//	sign := func(msg []byte) ([]byte, error) {
+//		sig, _, err := kr.Sign(uid, msg)
+//		return sig, err
+//	}
+func NewKeyringSigner(pubKey []byte, sign func(msg []byte) ([]byte, error)) (Signer, error) {
+	key, err := secp256k1.ParsePubKey(pubKey)
+	if err != nil {
+		return nil, fmt.Errorf("parsing public key: %w", err)
+	}
+	compressed := key.SerializeCompressed()
+	return &keyringSigner{
+		pubKey:  compressed,
+		address: pubKeyAddress(compressed),
+		sign:    sign,
+	}, nil
+}
+
+type keyringSigner struct {
+	pubKey  []byte
+	address string
+	sign    func(msg []byte) ([]byte, error)
+}
+
+func (s *keyringSigner) Address() string {
+	return s.address
+}
+
+func (s *keyringSigner) PubKey() []byte {
+	return s.pubKey
+}
+
+func (s *keyringSigner) Sign(msg []byte) ([]byte, error) {
+	return s.sign(msg)
+}
+
+// pubKeyAddress returns the bech32 address of the account of the compressed public key.
+func pubKeyAddress(pubKey []byte) string {
+	hash := sha256.Sum256(pubKey)
+	hasher := ripemd160.New()
+	hasher.Write(hash[:])
+	return blob.EncodeAddress(hasher.Sum(nil))
+}
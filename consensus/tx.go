@@ -0,0 +1,268 @@
+package consensus
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math"
+	"strconv"
+
+	"google.golang.org/protobuf/encoding/protowire"
+
+	"github.com/celestiaorg/celestia-openrpc/types/appconsts"
+	"github.com/celestiaorg/celestia-openrpc/types/blob"
+)
+
+const (
+	baseAccountTypeURL = "/cosmos.auth.v1beta1.BaseAccount"
+	pubKeyTypeURL      = "/cosmos.crypto.secp256k1.PubKey"
+
+	// signModeDirect signs the protobuf encoding of the transaction.
+	signModeDirect = 1
+)
+
+// Account is the state of an account needed to sign its transactions.
+type Account struct {
+	Address       string
+	AccountNumber uint64
+	Sequence      uint64
+}
+
+// Account returns the account with the bech32 address.
+func (c *Client) Account(ctx context.Context, address string) (*Account, error) {
+	req := protowire.AppendTag(nil, 1, protowire.BytesType)
+	req = protowire.AppendString(req, address)
+
+	var resp []byte
+	if err := c.invoke(ctx, accountMethod, req, &resp); err != nil {
+		return nil, err
+	}
+	account, err := field(resp, 1)
+	if err != nil {
+		return nil, err
+	}
+	typeURL, err := field(account, 1)
+	if err != nil {
+		return nil, err
+	}
+	if string(typeURL) != baseAccountTypeURL {
+		return nil, fmt.Errorf("unsupported account type %q", typeURL)
+	}
+	value, err := field(account, 2)
+	if err != nil {
+		return nil, err
+	}
+
+	acc := &Account{Address: address}
+	err = walk(value, func(n protowire.Number, typ protowire.Type, b []byte) int {
+		if typ != protowire.VarintType || (n != 3 && n != 4) {
+			return protowire.ConsumeFieldValue(n, typ, b)
+		}
+		v, m := protowire.ConsumeVarint(b)
+		if n == 3 {
+			acc.AccountNumber = v
+		} else {
+			acc.Sequence = v
+		}
+		return m
+	})
+	return acc, err
+}
+
+// ChainID returns the ID of the chain of the node, which transactions are signed for.
+func (c *Client) ChainID(ctx context.Context) (string, error) {
+	var resp []byte
+	if err := c.invoke(ctx, getNodeInfoMethod, nil, &resp); err != nil {
+		return "", err
+	}
+	info, err := field(resp, 1)
+	if err != nil {
+		return "", err
+	}
+	network, err := field(info, 4)
+	if err != nil {
+		return "", err
+	}
+	if len(network) == 0 {
+		return "", errors.New("node reported no chain ID")
+	}
+	return string(network), nil
+}
+
+// TxOption is an option of the transactions built by SubmitPayForBlob.
+type TxOption func(cfg *txConfig)
+
+type txConfig struct {
+	gas        uint64
+	gasPrice   float64
+	feeGranter string
+}
+
+// WithGas is an option that allows to specify the gas limit of the transaction.
+// By default, the gas is estimated with blob.EstimateGas.
+func WithGas(gas uint64) TxOption {
+	return func(cfg *txConfig) {
+		cfg.gas = gas
+	}
+}
+
+// WithGasPrice is an option that allows to specify the gas price in utia of the transaction.
+// By default, appconsts.DefaultMinGasPrice is paid, see Client.MinimumGasPrice.
+func WithGasPrice(gasPrice float64) TxOption {
+	return func(cfg *txConfig) {
+		cfg.gasPrice = gasPrice
+	}
+}
+
+// WithFeeGranter is an option that allows to specify the bech32 address of the account
+// paying the fee on behalf of the signer.
+func WithFeeGranter(address string) TxOption {
+	return func(cfg *txConfig) {
+		cfg.feeGranter = address
+	}
+}
+
+// SubmitPayForBlob builds a transaction paying for the blobs, signs it with the signer and
+// broadcasts it along with the blobs, without involving celestia-node. It returns once the
+// transaction passed CheckTx; a non-zero Code of the response reports it was rejected.
+// The account sequence is queried for every transaction, so transactions of the same
+// signer must be submitted one after the other.
+func (c *Client) SubmitPayForBlob(
+	ctx context.Context,
+	signer Signer,
+	blobs []*blob.Blob,
+	opts ...TxOption,
+) (*TxResponse, error) {
+	cfg, msg, err := newPayForBlobs(signer, blobs, opts)
+	if err != nil {
+		return nil, err
+	}
+	account, err := c.Account(ctx, signer.Address())
+	if err != nil {
+		return nil, fmt.Errorf("querying account %s: %w", signer.Address(), err)
+	}
+	chainID, err := c.ChainID(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("querying chain ID: %w", err)
+	}
+
+	tx, err := signPayForBlobs(signer, msg, cfg, chainID, account)
+	if err != nil {
+		return nil, err
+	}
+	return c.BroadcastBlobTx(ctx, tx, blobs, BroadcastSync)
+}
+
+// SignPayForBlobs builds the transaction paying for the blobs and signs it with the signer
+// for the account on the chain, without querying the node, e.g. to review the transaction
+// before it's broadcast. It returns the encoded TxRaw, which SubmitPayForBlob would wrap
+// into a BlobTx along with the blobs.
+func SignPayForBlobs(
+	signer Signer,
+	chainID string,
+	account *Account,
+	blobs []*blob.Blob,
+	opts ...TxOption,
+) ([]byte, error) {
+	if account == nil {
+		return nil, errors.New("no account to sign for")
+	}
+	cfg, msg, err := newPayForBlobs(signer, blobs, opts)
+	if err != nil {
+		return nil, err
+	}
+	return signPayForBlobs(signer, msg, cfg, chainID, account)
+}
+
+// newPayForBlobs applies the options and builds the message paying for the blobs from the
+// account of the signer.
+func newPayForBlobs(signer Signer, blobs []*blob.Blob, opts []TxOption) (txConfig, *blob.MsgPayForBlobs, error) {
+	cfg := txConfig{gasPrice: appconsts.DefaultMinGasPrice}
+	for _, opt := range opts {
+		opt(&cfg)
+	}
+	if cfg.gas == 0 {
+		cfg.gas = blob.EstimateGas(blobs...)
+	}
+	if cfg.gasPrice < 0 || math.IsNaN(cfg.gasPrice) {
+		return cfg, nil, fmt.Errorf("invalid gas price %v", cfg.gasPrice)
+	}
+	msg, err := blob.NewMsgPayForBlobs(signer.Address(), blobs...)
+	return cfg, msg, err
+}
+
+// signPayForBlobs returns the encoded TxRaw of the transaction holding the message,
+// signed in direct mode.
+func signPayForBlobs(
+	signer Signer,
+	msg *blob.MsgPayForBlobs,
+	cfg txConfig,
+	chainID string,
+	account *Account,
+) ([]byte, error) {
+	msgBz, err := msg.Marshal()
+	if err != nil {
+		return nil, err
+	}
+	body := appendBytesField(nil, 1, appendAny(blob.URLMsgPayForBlobs, msgBz))
+
+	// the mode of a single signer
+	modeInfo := appendBytesField(nil, 1, appendVarintField(nil, 1, signModeDirect))
+	var signerInfo []byte
+	signerInfo = appendBytesField(signerInfo, 1, appendAny(pubKeyTypeURL, appendBytesField(nil, 1, signer.PubKey())))
+	signerInfo = appendBytesField(signerInfo, 2, modeInfo)
+	signerInfo = appendVarintField(signerInfo, 3, account.Sequence)
+
+	var coin []byte
+	coin = appendBytesField(coin, 1, []byte(gasDenom))
+	fee := uint64(math.Ceil(cfg.gasPrice * float64(cfg.gas)))
+	coin = appendBytesField(coin, 2, []byte(strconv.FormatUint(fee, 10)))
+	var feeMsg []byte
+	feeMsg = appendBytesField(feeMsg, 1, coin)
+	feeMsg = appendVarintField(feeMsg, 2, cfg.gas)
+	if cfg.feeGranter != "" {
+		feeMsg = appendBytesField(feeMsg, 4, []byte(cfg.feeGranter))
+	}
+
+	var authInfo []byte
+	authInfo = appendBytesField(authInfo, 1, signerInfo)
+	authInfo = appendBytesField(authInfo, 2, feeMsg)
+
+	var signDoc []byte
+	signDoc = appendBytesField(signDoc, 1, body)
+	signDoc = appendBytesField(signDoc, 2, authInfo)
+	signDoc = appendBytesField(signDoc, 3, []byte(chainID))
+	signDoc = appendVarintField(signDoc, 4, account.AccountNumber)
+
+	sig, err := signer.Sign(signDoc)
+	if err != nil {
+		return nil, fmt.Errorf("signing transaction: %w", err)
+	}
+	if len(sig) != signatureSize {
+		return nil, fmt.Errorf("signature has %d bytes, expected %d", len(sig), signatureSize)
+	}
+
+	var txRaw []byte
+	txRaw = appendBytesField(txRaw, 1, body)
+	txRaw = appendBytesField(txRaw, 2, authInfo)
+	return appendBytesField(txRaw, 3, sig), nil
+}
+
+// appendAny returns the encoded google.protobuf.Any holding the encoded message of the type.
+func appendAny(typeURL string, value []byte) []byte {
+	return appendBytesField(appendBytesField(nil, 1, []byte(typeURL)), 2, value)
+}
+
+func appendBytesField(b []byte, num protowire.Number, value []byte) []byte {
+	b = protowire.AppendTag(b, num, protowire.BytesType)
+	return protowire.AppendBytes(b, value)
+}
+
+// appendVarintField appends the varint field, omitting the zero value as proto3 does.
+func appendVarintField(b []byte, num protowire.Number, value uint64) []byte {
+	if value == 0 {
+		return b
+	}
+	b = protowire.AppendTag(b, num, protowire.VarintType)
+	return protowire.AppendVarint(b, value)
+}
@@ -0,0 +1,143 @@
+package consensus
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"testing"
+
+	"github.com/decred/dcrd/dcrec/secp256k1/v4"
+	"github.com/decred/dcrd/dcrec/secp256k1/v4/ecdsa"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/protobuf/encoding/protowire"
+
+	"github.com/celestiaorg/celestia-openrpc/types/blob"
+	"github.com/celestiaorg/celestia-openrpc/types/share"
+)
+
+func TestEncodeAddress(t *testing.T) {
+	addr := []byte{1, 2, 3, 4, 5, 6, 7, 8, 9, 10, 11, 12, 13, 14, 15, 16, 17, 18, 19, 20}
+	require.Equal(t, "celestia1qypqxpq9qcrsszg2pvxq6rs0zqg3yyc5wgawu3", blob.EncodeAddress(addr))
+}
+
+func TestKeySigner(t *testing.T) {
+	_, err := NewKeySigner([]byte{1, 2, 3})
+	require.Error(t, err)
+	_, err = NewKeySigner(make([]byte, 32))
+	require.Error(t, err)
+
+	signer, err := NewKeySigner(bytes.Repeat([]byte{7}, 32))
+	require.NoError(t, err)
+	require.Len(t, signer.PubKey(), 33)
+	require.Equal(t, pubKeyAddress(signer.PubKey()), signer.Address())
+	sig, err := signer.Sign([]byte("msg"))
+	require.NoError(t, err)
+	verifySignature(t, signer.PubKey(), []byte("msg"), sig)
+
+	// a keyring signing with the same key is the same account
+	keyring, err := NewKeyringSigner(signer.PubKey(), signer.Sign)
+	require.NoError(t, err)
+	require.Equal(t, signer.Address(), keyring.Address())
+	_, err = NewKeyringSigner([]byte{1, 2, 3}, signer.Sign)
+	require.Error(t, err)
+}
+
+func TestSubmitPayForBlob(t *testing.T) {
+	signer, err := NewKeySigner(bytes.Repeat([]byte{7}, 32))
+	require.NoError(t, err)
+
+	var broadcast []byte
+	c := newTestClient(t, func(stream grpc.ServerStream) error {
+		method, _ := grpc.MethodFromServerStream(stream)
+		var req []byte
+		if err := stream.RecvMsg(&req); err != nil {
+			return err
+		}
+
+		var resp []byte
+		switch method {
+		case accountMethod:
+			var account []byte
+			account = appendBytesField(account, 1, []byte(signer.Address()))
+			account = appendVarintField(account, 3, 12)
+			account = appendVarintField(account, 4, 5)
+			resp = appendBytesField(nil, 1, appendAny(baseAccountTypeURL, account))
+		case getNodeInfoMethod:
+			resp = appendBytesField(nil, 1, appendBytesField(nil, 4, []byte("mocha-4")))
+		case broadcastTxMethod:
+			broadcast = req
+			resp = appendBytesField(nil, 1, appendBytesField(nil, 2, []byte("ABCD")))
+		}
+		return stream.SendMsg(resp)
+	})
+
+	namespace, err := share.NewBlobNamespaceV0([]byte{1, 2, 3, 4})
+	require.NoError(t, err)
+	b, err := blob.NewBlobV0(namespace, []byte("hello"))
+	require.NoError(t, err)
+
+	resp, err := c.SubmitPayForBlob(context.Background(), signer, []*blob.Blob{b}, WithGas(100_000), WithGasPrice(0.2))
+	require.NoError(t, err)
+	require.Equal(t, "ABCD", resp.TxHash)
+
+	btx, err := blob.UnmarshalBlobTx(fieldOf(t, broadcast, 1))
+	require.NoError(t, err)
+	require.Len(t, btx.Blobs, 1)
+	body := fieldOf(t, btx.Tx, 1)
+	authInfo := fieldOf(t, btx.Tx, 2)
+	sig := fieldOf(t, btx.Tx, 3)
+
+	// the body holds the message paying for the blob
+	msgAny := fieldOf(t, body, 1)
+	require.Equal(t, blob.URLMsgPayForBlobs, string(fieldOf(t, msgAny, 1)))
+	var msg blob.MsgPayForBlobs
+	require.NoError(t, msg.Unmarshal(fieldOf(t, msgAny, 2)))
+	require.Equal(t, signer.Address(), msg.Signer)
+	require.Equal(t, []blob.Commitment{b.Commitment}, msg.ShareCommitments)
+
+	// the fee is paid for the gas at the gas price
+	coin := fieldOf(t, fieldOf(t, authInfo, 2), 1)
+	require.Equal(t, "utia", string(fieldOf(t, coin, 1)))
+	require.Equal(t, "20000", string(fieldOf(t, coin, 2)))
+
+	// the signature covers the chain and the account
+	var signDoc []byte
+	signDoc = appendBytesField(signDoc, 1, body)
+	signDoc = appendBytesField(signDoc, 2, authInfo)
+	signDoc = appendBytesField(signDoc, 3, []byte("mocha-4"))
+	signDoc = appendVarintField(signDoc, 4, 12)
+	verifySignature(t, signer.PubKey(), signDoc, sig)
+
+	signerInfo := fieldOf(t, authInfo, 1)
+	var sequence uint64
+	require.NoError(t, walk(signerInfo, func(n protowire.Number, typ protowire.Type, bz []byte) int {
+		if n == 3 && typ == protowire.VarintType {
+			v, m := protowire.ConsumeVarint(bz)
+			sequence = v
+			return m
+		}
+		return protowire.ConsumeFieldValue(n, typ, bz)
+	}))
+	require.EqualValues(t, 5, sequence)
+}
+
+func verifySignature(t *testing.T, pubKey, msg, sig []byte) {
+	t.Helper()
+	require.Len(t, sig, signatureSize)
+	key, err := secp256k1.ParsePubKey(pubKey)
+	require.NoError(t, err)
+	var r, s secp256k1.ModNScalar
+	r.SetByteSlice(sig[:32])
+	s.SetByteSlice(sig[32:])
+	hash := sha256.Sum256(msg)
+	require.True(t, ecdsa.NewSignature(&r, &s).Verify(hash[:], key))
+}
+
+// fieldOf returns the value of the length-delimited field of the message.
+func fieldOf(t *testing.T, msg []byte, num protowire.Number) []byte {
+	t.Helper()
+	value, err := field(msg, num)
+	require.NoError(t, err)
+	return value
+}
@@ -0,0 +1,89 @@
+package client
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+)
+
+// defaultCrossCheckedMethods are the methods cross-checked if none are provided to WithCrossCheck.
+// They return headers, whose data roots all other data is verified against, and proofs.
+var defaultCrossCheckedMethods = []string{
+	"header.GetByHeight",
+	"header.GetByHash",
+	"header.GetRangeByHeight",
+	"blob.GetProof",
+	"blob.Included",
+	"blob.GetCommitmentProof",
+	"share.GetRange",
+	"da.GetProofs",
+	"da.Validate",
+}
+
+// MismatchError is returned by cross-checked calls whose results differ between the endpoints.
+type MismatchError struct {
+	// Method is the fully-qualified name of the method, e.g. "header.GetByHeight".
+	Method string
+	// Addrs are the addresses of the compared endpoints.
+	Addrs [2]string
+	// Results are the JSON encodings of the results returned by the endpoints.
+	Results [2]json.RawMessage
+}
+
+func (e *MismatchError) Error() string {
+	return fmt.Sprintf("%s: results of %s and %s differ", e.Method, e.Addrs[0], e.Addrs[1])
+}
+
+// crossCheck returns an interceptor performing calls of the given methods against the two
+// most preferred endpoints and comparing their results, failing with a *MismatchError if
+// they differ. Calls which fail on either endpoint fail with the error of the first one.
+func crossCheck(p *proxy, methods []string) interceptor {
+	checked := make(map[string]bool, len(methods))
+	for _, method := range methods {
+		checked[method] = true
+	}
+
+	return func(ctx context.Context, c *call, next invoker) ([]interface{}, error) {
+		if _, pinned := pinnedEndpoint(ctx); pinned || !checked[c.name()] || len(p.endpoints) < 2 {
+			return next(ctx, c)
+		}
+
+		type result struct {
+			out []interface{}
+			err error
+		}
+		ranked := p.rank()
+		var results [2]chan result
+		for i := range results {
+			results[i] = make(chan result, 1)
+			go func(i int) {
+				out, err := next(withEndpoint(ctx, ranked[i]), c)
+				results[i] <- result{out: out, err: err}
+			}(i)
+		}
+		first, second := <-results[0], <-results[1]
+		if first.err != nil {
+			return nil, first.err
+		}
+		if second.err != nil {
+			return nil, second.err
+		}
+
+		mismatch := &MismatchError{
+			Method: c.name(),
+			Addrs:  [2]string{p.endpoints[ranked[0]].addr, p.endpoints[ranked[1]].addr},
+		}
+		var err error
+		if mismatch.Results[0], err = json.Marshal(first.out); err != nil {
+			return nil, fmt.Errorf("encoding results of %s: %w", c.name(), err)
+		}
+		if mismatch.Results[1], err = json.Marshal(second.out); err != nil {
+			return nil, fmt.Errorf("encoding results of %s: %w", c.name(), err)
+		}
+		if !bytes.Equal(mismatch.Results[0], mismatch.Results[1]) {
+			return nil, mismatch
+		}
+		return first.out, nil
+	}
+}
@@ -0,0 +1,84 @@
+package client
+
+import (
+	"context"
+	"errors"
+	"sync/atomic"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestCrossCheck(t *testing.T) {
+	p := testProxy("a", "b")
+	intercept := crossCheck(p, defaultCrossCheckedMethods)
+
+	var pinnedCalls atomic.Int32
+	next := func(ctx context.Context, _ *call) ([]interface{}, error) {
+		if _, pinned := pinnedEndpoint(ctx); pinned {
+			pinnedCalls.Add(1)
+		}
+		return []interface{}{map[string]int{"height": 1}}, nil
+	}
+
+	out, err := intercept(context.Background(), &call{module: "header", method: "GetByHeight", perm: "read"}, next)
+	require.NoError(t, err)
+	require.Equal(t, []interface{}{map[string]int{"height": 1}}, out)
+	require.EqualValues(t, 2, pinnedCalls.Load())
+}
+
+func TestCrossCheckMismatch(t *testing.T) {
+	p := testProxy("honest", "lying")
+	intercept := crossCheck(p, defaultCrossCheckedMethods)
+
+	next := func(ctx context.Context, _ *call) ([]interface{}, error) {
+		idx, _ := pinnedEndpoint(ctx)
+		return []interface{}{p.endpoints[idx].addr == "honest"}, nil
+	}
+
+	_, err := intercept(context.Background(), &call{module: "blob", method: "Included", perm: "read"}, next)
+	var mismatch *MismatchError
+	require.ErrorAs(t, err, &mismatch)
+	require.Equal(t, "blob.Included", mismatch.Method)
+	require.ElementsMatch(t, []string{"honest", "lying"}, mismatch.Addrs[:])
+	require.NotEqual(t, mismatch.Results[0], mismatch.Results[1])
+}
+
+func TestCrossCheckFailure(t *testing.T) {
+	p := testProxy("failing", "healthy")
+	intercept := crossCheck(p, defaultCrossCheckedMethods)
+	errFailed := errors.New("connection refused")
+
+	next := func(ctx context.Context, _ *call) ([]interface{}, error) {
+		idx, _ := pinnedEndpoint(ctx)
+		if p.endpoints[idx].addr == "failing" {
+			return nil, errFailed
+		}
+		return []interface{}{"ok"}, nil
+	}
+
+	_, err := intercept(context.Background(), &call{module: "header", method: "GetByHash", perm: "read"}, next)
+	require.ErrorIs(t, err, errFailed)
+}
+
+func TestCrossCheckSkipsOtherCalls(t *testing.T) {
+	p := testProxy("a", "b")
+	intercept := crossCheck(p, defaultCrossCheckedMethods)
+
+	var calls atomic.Int32
+	next := func(context.Context, *call) ([]interface{}, error) {
+		calls.Add(1)
+		return nil, nil
+	}
+
+	for _, c := range []*call{
+		{module: "blob", method: "Submit", perm: "write"},
+		{module: "header", method: "NetworkHead", perm: "read"},
+	} {
+		_, err := intercept(context.Background(), c, next)
+		require.NoError(t, err)
+	}
+	_, err := intercept(withEndpoint(context.Background(), 1), &call{module: "header", method: "GetByHeight", perm: "read"}, next)
+	require.NoError(t, err)
+	require.EqualValues(t, 3, calls.Load())
+}
@@ -0,0 +1,42 @@
+package client
+
+import (
+	"context"
+	"errors"
+	"fmt"
+)
+
+// DialAndWait constructs a client like NewClient, waiting until the node is up and its RPC
+// is ready, e.g. for services starting alongside the node. Failing connections and readiness
+// checks are retried with the backoff set with WithReconnectBackoff until ctx is done, in
+// which case the error of ctx is returned along with the last failure. Other errors, such as
+// invalid options or rejected tokens, are returned right away.
+func DialAndWait(ctx context.Context, addr string, token string, opts ...Option) (*Client, error) {
+	cfg := newConfig(opts...)
+	backoff := cfg.reconnectBackoff()
+
+	for attempt := 0; ; attempt++ {
+		c, err := NewClient(ctx, addr, token, opts...)
+		if err == nil {
+			var ready bool
+			if ready, err = c.Node.Ready(ctx); err == nil && ready {
+				return c, nil
+			}
+			c.Close()
+			if err == nil {
+				err = ErrNodeNotReady
+			}
+		}
+		if !errors.Is(err, ErrNodeNotReady) && !isTransient(err, true) && !isConnectionError(err) {
+			return nil, err
+		}
+
+		delay := backoff.next(attempt)
+		cfg.logger.InfoContext(ctx, "waiting for node", "addr", addr, "attempt", attempt+1, "delay", delay, "err", err)
+		select {
+		case <-cfg.clock.After(delay):
+		case <-ctx.Done():
+			return nil, fmt.Errorf("waiting for node at %s: %w (last error: %v)", addr, ctx.Err(), err)
+		}
+	}
+}
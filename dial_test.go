@@ -0,0 +1,50 @@
+package client
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestDialAndWait(t *testing.T) {
+	var readyCalls atomic.Int32
+	srv := testNode(t, func(_ *http.Request, method string, _ json.RawMessage) (interface{}, error) {
+		if method != "node.Ready" {
+			return nil, errors.New("method not found")
+		}
+		// the node becomes ready on the third check
+		return readyCalls.Add(1) >= 3, nil
+	})
+
+	clock := &fakeClock{}
+	c, err := DialAndWait(context.Background(), srv.URL, "", WithClock(clock))
+	require.NoError(t, err)
+	defer c.Close()
+	require.EqualValues(t, 3, readyCalls.Load())
+	require.Len(t, clock.delays, 2)
+}
+
+func TestDialAndWaitTimeout(t *testing.T) {
+	srv := testNode(t, nil)
+	addr := srv.URL
+	srv.Close()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+	_, err := DialAndWait(ctx, addr, "", WithReconnectBackoff(time.Millisecond, time.Millisecond))
+	require.ErrorIs(t, err, context.DeadlineExceeded)
+	require.ErrorContains(t, err, "last error")
+}
+
+func TestDialAndWaitPermanentError(t *testing.T) {
+	clock := &fakeClock{}
+	_, err := DialAndWait(context.Background(), "http://localhost:26658", "", WithModules("rollup"), WithClock(clock))
+	require.ErrorContains(t, err, "unknown module rollup")
+	require.Empty(t, clock.delays)
+}
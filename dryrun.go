@@ -0,0 +1,216 @@
+package client
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/celestiaorg/celestia-openrpc/consensus"
+	"github.com/celestiaorg/celestia-openrpc/types/appconsts"
+	"github.com/celestiaorg/celestia-openrpc/types/blob"
+	"github.com/celestiaorg/celestia-openrpc/types/share"
+	"github.com/celestiaorg/celestia-openrpc/types/state"
+)
+
+// ErrDryRun is returned by the submission methods of a client constructed with
+// WithDryRun. It is always wrapped into a DryRunError carrying the prepared submission.
+var ErrDryRun = errors.New("client: dry-run, submission was not broadcast")
+
+// DryRunError is returned instead of broadcasting a submission in dry-run mode.
+type DryRunError struct {
+	Result *DryRunResult
+}
+
+func (e *DryRunError) Error() string {
+	return ErrDryRun.Error()
+}
+
+func (e *DryRunError) Unwrap() error {
+	return ErrDryRun
+}
+
+// DryRunSigner signs the transactions prepared in dry-run mode with a key held by the client,
+// as consensus.Client.SubmitPayForBlob would sign them for the account on the chain.
+type DryRunSigner struct {
+	Signer  consensus.Signer
+	ChainID string
+	// Account holds the account number and sequence the transactions are signed with.
+	Account consensus.Account
+}
+
+// DryRunResult describes a submission as it would be broadcast by the client.
+type DryRunResult struct {
+	// Tx is the encoded BlobTx, i.e. the signed transaction along with the blobs it pays for,
+	// ready to be broadcast. Without a DryRunSigner the transaction is signed by the node,
+	// so Tx is nil.
+	Tx []byte
+	// Msg is the encoded MsgPayForBlobs of the transaction. It is nil unless the signer of
+	// the transaction is known, i.e. a DryRunSigner or a signer address is provided.
+	Msg []byte
+	// Blobs describes the predicted share layout of every blob.
+	Blobs []BlobLayout
+	// Shares is the total number of shares occupied by the blobs.
+	Shares int
+	// MinSquareSize is the width of the smallest square able to fit the blobs.
+	MinSquareSize int
+	// Gas is the gas limit the transaction would be submitted with.
+	Gas uint64
+	// GasPrice is the gas price used to compute the fee.
+	GasPrice float64
+	// Fee is the fee in utia the transaction would pay.
+	Fee uint64
+}
+
+// BlobLayout describes the predicted placement of a single blob in the square.
+type BlobLayout struct {
+	Namespace  share.Namespace
+	Commitment blob.Commitment
+	// Size is the length of the blob data, excluding the signer of share version 1 blobs.
+	Size int
+	// Shares is the number of shares occupied by the blob.
+	Shares int
+	// SubtreeWidth is the width of the subtrees the commitment is built from.
+	// The index of the blob's first share is aligned to it in the square.
+	SubtreeWidth int
+}
+
+// DryRun prepares the submission of the given blobs without broadcasting it.
+// Unless provided in the options, the gas limit is estimated locally and the
+// fee is computed using the default minimum gas price.
+// The transaction is built when the signer address is provided in the options, and
+// signed as well when the signer is provided.
+func DryRun(blobs []*blob.Blob, opts *blob.SubmitOptions, signer *DryRunSigner) (*DryRunResult, error) {
+	req := dryRunRequest{gasPrice: blob.DefaultGasPrice, signer: signer}
+	if opts != nil {
+		req.gas, req.gasPrice = opts.GasLimit(), opts.GasPrice()
+		req.signerAddress, req.feeGranter = opts.SignerAddress(), opts.FeeGranterAddress()
+	}
+	return dryRun(blobs, req)
+}
+
+// dryRunRequest holds the settings of the submission prepared by dryRun.
+type dryRunRequest struct {
+	gas           uint64
+	gasPrice      float64
+	signerAddress string
+	feeGranter    string
+	signer        *DryRunSigner
+}
+
+func dryRun(blobs []*blob.Blob, req dryRunRequest) (*DryRunResult, error) {
+	if len(blobs) == 0 {
+		return nil, errors.New("dry-run: no blobs provided")
+	}
+
+	res := &DryRunResult{
+		Blobs: make([]BlobLayout, len(blobs)),
+	}
+	for i, b := range blobs {
+		if b == nil {
+			return nil, fmt.Errorf("dry-run: blob %d is nil", i)
+		}
+		//nolint:gosec
+		shares := share.SparseSharesNeeded(uint32(sequenceLen(b)))
+		res.Blobs[i] = BlobLayout{
+			Namespace:    share.Namespace(b.Namespace().Bytes()),
+			Commitment:   b.Commitment,
+			Size:         len(b.Data),
+			Shares:       shares,
+			SubtreeWidth: share.SubTreeWidth(shares, appconsts.DefaultSubtreeRootThreshold),
+		}
+		res.Shares += shares
+	}
+	res.MinSquareSize = share.BlobMinSquareSize(res.Shares)
+
+	res.Gas = req.gas
+	if res.Gas == 0 {
+		res.Gas = blob.EstimateGas(blobs...)
+	}
+	res.GasPrice = req.gasPrice
+	if res.GasPrice < 0 {
+		res.GasPrice = appconsts.DefaultMinGasPrice
+	}
+	res.Fee = fee(res.GasPrice, res.Gas)
+
+	if err := buildDryRunTx(res, blobs, req); err != nil {
+		return nil, fmt.Errorf("dry-run: %w", err)
+	}
+	return res, nil
+}
+
+// buildDryRunTx builds the message paying for the blobs once its signer is known, and
+// signs the transaction holding it with the DryRunSigner, if any.
+func buildDryRunTx(res *DryRunResult, blobs []*blob.Blob, req dryRunRequest) error {
+	signerAddress := req.signerAddress
+	if req.signer != nil {
+		if signerAddress != "" && signerAddress != req.signer.Signer.Address() {
+			return fmt.Errorf("signer address %s is not the one of the dry-run signer %s",
+				signerAddress, req.signer.Signer.Address())
+		}
+		signerAddress = req.signer.Signer.Address()
+	}
+	if signerAddress == "" {
+		return nil
+	}
+
+	msg, err := blob.NewMsgPayForBlobs(signerAddress, blobs...)
+	if err != nil {
+		return err
+	}
+	if res.Msg, err = msg.Marshal(); err != nil {
+		return err
+	}
+	if req.signer == nil {
+		return nil
+	}
+
+	opts := []consensus.TxOption{consensus.WithGas(res.Gas), consensus.WithGasPrice(res.GasPrice)}
+	if req.feeGranter != "" {
+		opts = append(opts, consensus.WithFeeGranter(req.feeGranter))
+	}
+	account := req.signer.Account
+	tx, err := consensus.SignPayForBlobs(req.signer.Signer, req.signer.ChainID, &account, blobs, opts...)
+	if err != nil {
+		return err
+	}
+	res.Tx, err = blob.MarshalBlobTx(tx, blobs...)
+	return err
+}
+
+// sequenceLen returns the number of bytes the blob occupies in its shares,
+// including the signer carried by share version 1 blobs.
+func sequenceLen(b *blob.Blob) int {
+	//nolint:gosec
+	if uint8(b.ShareVersion) == appconsts.ShareVersionOne {
+		return appconsts.SignerSize + len(b.Data)
+	}
+	return len(b.Data)
+}
+
+// enableDryRun replaces the submission methods of the client with ones
+// returning a DryRunError instead of broadcasting.
+func enableDryRun(c *Client, signer *DryRunSigner) {
+	c.Blob.Submit = func(_ context.Context, blobs []*blob.Blob, opts *blob.SubmitOptions) (uint64, error) {
+		res, err := DryRun(blobs, opts, signer)
+		if err != nil {
+			return 0, err
+		}
+		return 0, &DryRunError{Result: res}
+	}
+	c.State.SubmitPayForBlob = func(
+		_ context.Context,
+		blobs []*blob.Blob,
+		config *state.TxConfig,
+	) (*state.TxResponse, error) {
+		req := dryRunRequest{gasPrice: state.DefaultGasPrice, signer: signer}
+		if config != nil {
+			req.gas, req.gasPrice = config.GasLimit(), config.GasPrice()
+			req.signerAddress, req.feeGranter = config.SignerAddress(), config.FeeGranterAddress()
+		}
+		res, err := dryRun(blobs, req)
+		if err != nil {
+			return nil, err
+		}
+		return nil, &DryRunError{Result: res}
+	}
+}
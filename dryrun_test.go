@@ -0,0 +1,169 @@
+package client
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"strings"
+	"testing"
+
+	"github.com/decred/dcrd/dcrec/secp256k1/v4"
+	"github.com/decred/dcrd/dcrec/secp256k1/v4/ecdsa"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/encoding/protowire"
+
+	"github.com/celestiaorg/celestia-openrpc/consensus"
+	"github.com/celestiaorg/celestia-openrpc/types/appconsts"
+	"github.com/celestiaorg/celestia-openrpc/types/blob"
+)
+
+func TestDryRunLayout(t *testing.T) {
+	small := testBlob(t, "hello")
+	large := testBlob(t, strings.Repeat("x", 1000))
+
+	res, err := DryRun([]*blob.Blob{small, large}, blob.NewSubmitOptions(blob.WithGasPrice(0.002)), nil)
+	require.NoError(t, err)
+	require.Len(t, res.Blobs, 2)
+	require.Equal(t, 1, res.Blobs[0].Shares)
+	// 478 bytes fit into the first share and 482 into every further one
+	require.Equal(t, 3, res.Blobs[1].Shares)
+	require.Equal(t, 4, res.Shares)
+	require.Equal(t, 2, res.MinSquareSize)
+	require.Equal(t, blob.EstimateGas(small, large), res.Gas)
+	require.Equal(t, fee(0.002, res.Gas), res.Fee)
+}
+
+func TestDryRunShareVersionOne(t *testing.T) {
+	data := strings.Repeat("x", appconsts.FirstSparseShareContentSize-1)
+	v0 := testBlob(t, data)
+	v1 := testBlob(t, data)
+	v1.ShareVersion = uint32(appconsts.ShareVersionOne)
+
+	res, err := DryRun([]*blob.Blob{v0, v1}, nil, nil)
+	require.NoError(t, err)
+	require.Equal(t, 1, res.Blobs[0].Shares)
+	// the signer pushes the data of the second blob into a continuation share
+	require.Equal(t, 2, res.Blobs[1].Shares)
+	require.Equal(t, len(data), res.Blobs[1].Size)
+	require.Greater(t, blob.EstimateGas(v1), blob.EstimateGas(v0))
+}
+
+func TestEstimateGas(t *testing.T) {
+	// a share of 512 bytes at 8 gas per byte, 70 bytes of blob info at 10 gas per byte and the fixed cost
+	require.EqualValues(t, 4096+700+75_000, blob.EstimateGas(testBlob(t, "hello")))
+	require.EqualValues(t, 3*4096+2*700+75_000, blob.EstimateGas(testBlob(t, "hello"), testBlob(t, strings.Repeat("x", 1000))))
+	require.EqualValues(t, appconsts.PFBGasFixedCost, blob.EstimateGas())
+}
+
+func TestCalculateBlobCost(t *testing.T) {
+	cost, err := blob.CalculateBlobCost([]int{5, 1000})
+	require.NoError(t, err)
+	require.Equal(t, 3, cost.Shares)
+	require.Equal(t, 2, cost.SquareSize)
+	// the gas is the one estimated for the blobs themselves
+	require.Equal(t, blob.EstimateGas(testBlob(t, "hello"), testBlob(t, strings.Repeat("x", 1000))), cost.Gas)
+	require.EqualValues(t, 178, cost.Fee(0.002))
+
+	_, err = blob.CalculateBlobCost([]int{5, 0})
+	require.ErrorIs(t, err, blob.ErrInvalidSize)
+}
+
+func TestDryRunKeepsProvidedGas(t *testing.T) {
+	res, err := DryRun([]*blob.Blob{testBlob(t, "hello")}, blob.NewSubmitOptions(blob.WithGas(123_456)), nil)
+	require.NoError(t, err)
+	require.EqualValues(t, 123_456, res.Gas)
+}
+
+func TestDryRunRejectsEmpty(t *testing.T) {
+	_, err := DryRun(nil, nil, nil)
+	require.Error(t, err)
+	_, err = DryRun([]*blob.Blob{nil}, nil, nil)
+	require.Error(t, err)
+}
+
+func TestEnableDryRun(t *testing.T) {
+	c := &Client{}
+	c.Blob.Submit = func(context.Context, []*blob.Blob, *blob.SubmitOptions) (uint64, error) {
+		t.Fatal("submission was broadcast")
+		return 0, nil
+	}
+	enableDryRun(c, nil)
+
+	_, err := c.Blob.Submit(context.Background(), []*blob.Blob{testBlob(t, "hello")}, nil)
+	require.ErrorIs(t, err, ErrDryRun)
+	var dryRunErr *DryRunError
+	require.ErrorAs(t, err, &dryRunErr)
+	require.Equal(t, 1, dryRunErr.Result.Shares)
+}
+
+func TestDryRunSigned(t *testing.T) {
+	signer, err := consensus.NewKeySigner(bytes.Repeat([]byte{7}, 32))
+	require.NoError(t, err)
+	b := testBlob(t, "hello")
+	drySigner := &DryRunSigner{
+		Signer:  signer,
+		ChainID: "mocha-4",
+		Account: consensus.Account{Address: signer.Address(), AccountNumber: 12, Sequence: 5},
+	}
+
+	res, err := DryRun([]*blob.Blob{b}, blob.NewSubmitOptions(blob.WithGasPrice(0.002)), drySigner)
+	require.NoError(t, err)
+	btx, err := blob.UnmarshalBlobTx(res.Tx)
+	require.NoError(t, err)
+	require.Len(t, btx.Blobs, 1)
+	require.True(t, b.Equal(btx.Blobs[0]))
+
+	// the body holds the message paying for the blob
+	body, authInfo, sig := protoField(t, btx.Tx, 1), protoField(t, btx.Tx, 2), protoField(t, btx.Tx, 3)
+	msgAny := protoField(t, body, 1)
+	require.Equal(t, blob.URLMsgPayForBlobs, string(protoField(t, msgAny, 1)))
+	require.Equal(t, res.Msg, protoField(t, msgAny, 2))
+	var msg blob.MsgPayForBlobs
+	require.NoError(t, msg.Unmarshal(res.Msg))
+	require.Equal(t, signer.Address(), msg.Signer)
+	require.Equal(t, []blob.Commitment{b.Commitment}, msg.ShareCommitments)
+
+	// the signature covers the chain and the account
+	var signDoc []byte
+	for num, value := range [][]byte{body, authInfo, []byte("mocha-4")} {
+		signDoc = protowire.AppendTag(signDoc, protowire.Number(num+1), protowire.BytesType)
+		signDoc = protowire.AppendBytes(signDoc, value)
+	}
+	signDoc = protowire.AppendTag(signDoc, 4, protowire.VarintType)
+	signDoc = protowire.AppendVarint(signDoc, 12)
+	pubKey, err := secp256k1.ParsePubKey(signer.PubKey())
+	require.NoError(t, err)
+	var r, s secp256k1.ModNScalar
+	r.SetByteSlice(sig[:32])
+	s.SetByteSlice(sig[32:])
+	hash := sha256.Sum256(signDoc)
+	require.True(t, ecdsa.NewSignature(&r, &s).Verify(hash[:], pubKey))
+
+	// the message is built without signer as long as its address is known
+	res, err = DryRun([]*blob.Blob{b}, blob.NewSubmitOptions(blob.WithSignerAddress(signer.Address())), nil)
+	require.NoError(t, err)
+	require.Nil(t, res.Tx)
+	require.NotEmpty(t, res.Msg)
+	_, err = DryRun([]*blob.Blob{b}, blob.NewSubmitOptions(blob.WithSignerAddress("celestia1other")), drySigner)
+	require.Error(t, err)
+}
+
+// protoField returns the value of the length-delimited field of the encoded protobuf message.
+func protoField(t *testing.T, msg []byte, num protowire.Number) []byte {
+	t.Helper()
+	for len(msg) > 0 {
+		n, typ, m := protowire.ConsumeTag(msg)
+		require.Positive(t, m)
+		msg = msg[m:]
+		if n == num && typ == protowire.BytesType {
+			value, m := protowire.ConsumeBytes(msg)
+			require.GreaterOrEqual(t, m, 0)
+			return value
+		}
+		m = protowire.ConsumeFieldValue(n, typ, msg)
+		require.GreaterOrEqual(t, m, 0)
+		msg = msg[m:]
+	}
+	t.Fatalf("field %d not found", num)
+	return nil
+}
@@ -0,0 +1,204 @@
+package client
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"strings"
+	"syscall"
+
+	"github.com/filecoin-project/go-jsonrpc"
+
+	"github.com/celestiaorg/celestia-openrpc/types/blob"
+)
+
+var (
+	// ErrBlobNotFound is returned if there are no blobs under the requested namespace and commitment.
+	ErrBlobNotFound = blob.ErrBlobNotFound
+	// ErrHeaderNotFound is returned if the node doesn't have the requested header.
+	ErrHeaderNotFound = errors.New("header: not found")
+	// ErrHeightFromFuture is returned for heights the node hasn't synced up to yet.
+	ErrHeightFromFuture = errors.New("header: height is from the future")
+	// ErrOutsideSamplingWindow is returned for heights which are outside the sampling
+	// window of the node and whose data is pruned.
+	ErrOutsideSamplingWindow = errors.New("share: height is outside the sampling window")
+	// ErrModuleNotEnabled is returned by methods of modules excluded with WithModules.
+	ErrModuleNotEnabled = errors.New("module is not enabled")
+)
+
+// nodeErrors maps fragments of the messages of errors returned by the node to the sentinel errors.
+var nodeErrors = []struct {
+	fragment string
+	sentinel error
+}{
+	{"blob: not found", ErrBlobNotFound},
+	{"header: not found", ErrHeaderNotFound},
+	{"from the future", ErrHeightFromFuture},
+	{"outside the sampling window", ErrOutsideSamplingWindow},
+}
+
+// RPCError is an error object returned by the node in a JSON-RPC response.
+type RPCError struct {
+	Code    int
+	Message string
+	// Data holds additional information about the error, if the node provided any.
+	Data json.RawMessage
+}
+
+func (e *RPCError) Error() string {
+	// mirror the formatting of the errors returned by the underlying JSON-RPC client
+	if e.Code >= -32768 && e.Code <= -32000 {
+		return fmt.Sprintf("RPC error (%d): %s", e.Code, e.Message)
+	}
+	return e.Message
+}
+
+// errorObject is the encoding of the error object of a JSON-RPC response. Additional
+// information is carried in data, or in meta by nodes built on go-jsonrpc.
+type errorObject struct {
+	Code    int             `json:"code"`
+	Message string          `json:"message"`
+	Data    json.RawMessage `json:"data,omitempty"`
+	Meta    json.RawMessage `json:"meta,omitempty"`
+}
+
+func (o *errorObject) rpcError() *RPCError {
+	data := o.Data
+	if len(data) == 0 || string(data) == "null" {
+		data = o.Meta
+	}
+	return &RPCError{Code: o.Code, Message: o.Message, Data: data}
+}
+
+// jsonrpcError is implemented by the error objects of JSON-RPC responses returned by the
+// underlying JSON-RPC client, whose type is unexported.
+type jsonrpcError interface {
+	error
+	ErrorCode() jsonrpc.ErrorCode
+	ErrorMessage() string
+	ErrorData() json.RawMessage
+}
+
+// asRPCError converts the error objects returned by the underlying JSON-RPC client, also when
+// wrapped, e.g. in a *jsonrpc.ErrClient, into an RPCError. Any other error is returned as is.
+func asRPCError(err error) error {
+	var obj jsonrpcError
+	if !errors.As(err, &obj) {
+		return err
+	}
+	data := obj.ErrorData()
+	if len(data) == 0 || string(data) == "null" {
+		data = nil
+	}
+	return &RPCError{Code: int(obj.ErrorCode()), Message: obj.ErrorMessage(), Data: data}
+}
+
+// nodeError is an error returned by the node, matching the sentinel error it maps to.
+type nodeError struct {
+	err      error
+	sentinel error
+}
+
+func (e *nodeError) Error() string {
+	return e.err.Error()
+}
+
+func (e *nodeError) Unwrap() []error {
+	return []error{e.err, e.sentinel}
+}
+
+// mapNodeError wraps the error returned by the node to match the sentinel error it maps to, if any.
+func mapNodeError(err error) error {
+	if err == nil {
+		return nil
+	}
+	msg := err.Error()
+	for _, e := range nodeErrors {
+		if strings.Contains(msg, e.fragment) {
+			return &nodeError{err: err, sentinel: e.sentinel}
+		}
+	}
+	return err
+}
+
+// mapErrors is an interceptor exposing the errors returned by the node as RPCError
+// and mapping them to the sentinel errors.
+func mapErrors(ctx context.Context, c *call, next invoker) ([]interface{}, error) {
+	out, err := next(ctx, c)
+	if err != nil {
+		err = mapNodeError(asRPCError(err))
+	}
+	return out, err
+}
+
+// wsConnectionClosed is the message of the error returned by the node
+// for calls made while the WebSocket connection is down.
+const wsConnectionClosed = "websocket connection closed"
+
+// isConnectionError reports whether the error was caused by the connection
+// to the node rather than by the node handling the call.
+func isConnectionError(err error) bool {
+	if err == nil || rejectedLocally(err) {
+		return false
+	}
+	var (
+		clientErr *jsonrpc.ErrClient
+		connErr   *jsonrpc.RPCConnectionError
+	)
+	return errors.As(err, &clientErr) ||
+		errors.As(err, &connErr) ||
+		errors.Is(err, context.DeadlineExceeded) ||
+		strings.Contains(err.Error(), wsConnectionClosed)
+}
+
+// rejectedLocally reports whether the request or its response was rejected by the client
+// itself, which neither goes away when retried nor tells anything about the connection.
+func rejectedLocally(err error) bool {
+	return errors.Is(err, ErrStrictDecoding) ||
+		errors.Is(err, ErrRequestTooLarge) ||
+		errors.Is(err, ErrResponseTooLarge)
+}
+
+// transientStatuses are the HTTP statuses of failed requests worth retrying.
+var transientStatuses = []string{"http status 429", "http status 502", "http status 503", "http status 504"}
+
+// isTransient reports whether the call failed for a reason which may not persist,
+// such as a timeout, a dropped connection or an overloaded node. A connection dropped
+// while awaiting the response is only transient for idempotent calls, as the node may
+// have applied a call such as blob.Submit by then.
+func isTransient(err error, idempotent bool) bool {
+	if err == nil || rejectedLocally(err) {
+		return false
+	}
+
+	var (
+		connErr *jsonrpc.RPCConnectionError
+		netErr  net.Error
+	)
+	switch {
+	// checked first, as the errors of the HTTP client wrapping them are net errors too
+	case errors.Is(err, io.ErrUnexpectedEOF),
+		errors.Is(err, io.EOF):
+		return idempotent
+	case errors.As(err, &connErr),
+		errors.As(err, &netErr),
+		errors.Is(err, context.DeadlineExceeded),
+		errors.Is(err, syscall.ECONNRESET),
+		errors.Is(err, syscall.ECONNREFUSED):
+		return true
+	}
+
+	msg := err.Error()
+	if strings.Contains(msg, wsConnectionClosed) {
+		return true
+	}
+	for _, status := range transientStatuses {
+		if strings.Contains(msg, status) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,108 @@
+package client
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"testing"
+
+	"github.com/filecoin-project/go-jsonrpc"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMapNodeError(t *testing.T) {
+	for msg, sentinel := range map[string]error{
+		"blob: not found":   ErrBlobNotFound,
+		"header: not found": ErrHeaderNotFound,
+		"header: given height is from the future: 10 > 5":      ErrHeightFromFuture,
+		"getting EDS: height 3 is outside the sampling window": ErrOutsideSamplingWindow,
+	} {
+		err := mapNodeError(errors.New(msg))
+		require.ErrorIs(t, err, sentinel, msg)
+		// the message of the node is kept
+		require.EqualError(t, err, msg)
+	}
+
+	err := errors.New("share: invalid namespace")
+	require.Equal(t, err, mapNodeError(err))
+	require.NoError(t, mapNodeError(nil))
+}
+
+func TestMapNodeErrorKeepsCause(t *testing.T) {
+	cause := &RPCError{Code: 1, Message: "header: not found"}
+	err := mapNodeError(cause)
+
+	var rpcErr *RPCError
+	require.ErrorAs(t, err, &rpcErr)
+	require.Same(t, cause, rpcErr)
+	require.ErrorIs(t, err, ErrHeaderNotFound)
+}
+
+func TestMapErrors(t *testing.T) {
+	next, _ := failingInvoker(1, errors.New("blob: not found"))
+	_, err := mapErrors(context.Background(), &call{module: "blob", method: "Get", perm: "read"}, next)
+	require.ErrorIs(t, err, ErrBlobNotFound)
+
+	_, err = mapErrors(context.Background(), &call{module: "blob", method: "Get", perm: "read"}, next)
+	require.NoError(t, err)
+}
+
+func TestRPCErrorFromResponse(t *testing.T) {
+	meta := json.RawMessage(`{"height":10}`)
+	srv := testNode(t, func(_ *http.Request, method string, _ json.RawMessage) (interface{}, error) {
+		if method != "header.GetByHeight" {
+			return nil, errors.New("method not found")
+		}
+		return nil, &RPCError{Code: 1, Message: "header: not found", Data: meta}
+	})
+
+	c, err := NewClient(context.Background(), srv.URL, "")
+	require.NoError(t, err)
+	defer c.Close()
+
+	_, err = c.Header.GetByHeight(context.Background(), 10)
+	var rpcErr *RPCError
+	require.ErrorAs(t, err, &rpcErr)
+	require.Equal(t, 1, rpcErr.Code)
+	require.Equal(t, "header: not found", rpcErr.Message)
+	require.JSONEq(t, string(meta), string(rpcErr.Data))
+	require.ErrorIs(t, err, ErrHeaderNotFound)
+}
+
+func TestErrorObject(t *testing.T) {
+	var obj errorObject
+	require.NoError(t, json.Unmarshal([]byte(`{"code":-32000,"message":"failed","data":{"a":1},"meta":{"b":2}}`), &obj))
+	// data takes precedence over meta
+	require.Equal(t, &RPCError{Code: -32000, Message: "failed", Data: json.RawMessage(`{"a":1}`)}, obj.rpcError())
+
+	obj = errorObject{}
+	require.NoError(t, json.Unmarshal([]byte(`{"code":1,"message":"failed","meta":{"b":2}}`), &obj))
+	require.JSONEq(t, `{"b":2}`, string(obj.rpcError().Data))
+
+}
+
+// respError mimics the error objects returned by the underlying JSON-RPC client.
+type respError struct {
+	code    jsonrpc.ErrorCode
+	message string
+	data    json.RawMessage
+}
+
+func (e *respError) Error() string                { return e.message }
+func (e *respError) ErrorCode() jsonrpc.ErrorCode { return e.code }
+func (e *respError) ErrorMessage() string         { return e.message }
+func (e *respError) ErrorData() json.RawMessage   { return e.data }
+
+func TestAsRPCError(t *testing.T) {
+	err := fmt.Errorf("calling header.GetByHeight: %w", &respError{code: 1, message: "failed", data: json.RawMessage(`{"a":1}`)})
+	require.Equal(t, &RPCError{Code: 1, Message: "failed", Data: json.RawMessage(`{"a":1}`)}, asRPCError(err))
+
+	err = &respError{code: -32000, message: "failed", data: json.RawMessage("null")}
+	require.Equal(t, &RPCError{Code: -32000, Message: "failed"}, asRPCError(err))
+
+	// other errors are returned as is
+	err = errors.New("failed")
+	require.Equal(t, err, asRPCError(err))
+}
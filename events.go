@@ -0,0 +1,68 @@
+package client
+
+import "fmt"
+
+// ConnectionEventType is the type of a ConnectionEvent.
+type ConnectionEventType int
+
+const (
+	// Connected is emitted once the client connected to an endpoint, and again once a call
+	// to the endpoint succeeded after it was Disconnected.
+	Connected ConnectionEventType = iota + 1
+	// Disconnected is emitted once a call to a connected endpoint failed because of the
+	// connection, e.g. as it was dropped or timed out.
+	Disconnected
+	// Reconnecting is emitted before every attempt to re-create a subscription which was
+	// closed by a dropped connection.
+	Reconnecting
+	// Resubscribed is emitted once a subscription was re-created.
+	Resubscribed
+)
+
+func (t ConnectionEventType) String() string {
+	switch t {
+	case Connected:
+		return "connected"
+	case Disconnected:
+		return "disconnected"
+	case Reconnecting:
+		return "reconnecting"
+	case Resubscribed:
+		return "resubscribed"
+	default:
+		return fmt.Sprintf("ConnectionEventType(%d)", int(t))
+	}
+}
+
+// ConnectionEvent describes a change of the connectivity of the client, as passed to the
+// handler of WithConnectionEvents.
+type ConnectionEvent struct {
+	Type ConnectionEventType
+	// Addr is the address of the endpoint of Connected and Disconnected events.
+	Addr string
+	// Method is the subscription method of Reconnecting and Resubscribed events,
+	// e.g. "header.Subscribe".
+	Method string
+	// Attempt is the number of the attempt of Reconnecting events, starting at 1.
+	Attempt int
+	// Err is the error the call failed with for Disconnected events, and the one the
+	// previous attempt failed with for Reconnecting events.
+	Err error
+}
+
+// trackConnection emits Connected and Disconnected events for the endpoint based on the
+// outcome of a call, if events are enabled.
+func (ep *endpoint) trackConnection(err error) {
+	if ep.events == nil {
+		return
+	}
+	if isConnectionError(err) {
+		if ep.down.CompareAndSwap(false, true) {
+			ep.events(ConnectionEvent{Type: Disconnected, Addr: ep.addr, Err: err})
+		}
+		return
+	}
+	if ep.down.CompareAndSwap(true, false) {
+		ep.events(ConnectionEvent{Type: Connected, Addr: ep.addr})
+	}
+}
@@ -0,0 +1,79 @@
+package client
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestConnectionEvents(t *testing.T) {
+	srv := testNode(t, func(*http.Request, string, json.RawMessage) (interface{}, error) {
+		return nil, errors.New("method not found")
+	})
+
+	var events []ConnectionEvent
+	c, err := NewClient(context.Background(), srv.URL, "", WithConnectionEvents(func(e ConnectionEvent) {
+		events = append(events, e)
+	}))
+	require.NoError(t, err)
+	defer c.Close()
+	require.Equal(t, []ConnectionEvent{{Type: Connected, Addr: srv.URL}}, events)
+}
+
+func TestTrackConnection(t *testing.T) {
+	var events []ConnectionEvent
+	ep := &endpoint{addr: "memory", events: func(e ConnectionEvent) {
+		events = append(events, e)
+	}}
+
+	ep.trackConnection(nil)
+	require.Empty(t, events)
+
+	// only the first failure of a connected endpoint is reported
+	ep.trackConnection(context.DeadlineExceeded)
+	ep.trackConnection(context.DeadlineExceeded)
+	require.Equal(t, []ConnectionEvent{{Type: Disconnected, Addr: "memory", Err: context.DeadlineExceeded}}, events)
+
+	// errors returned by the node show the connection is back
+	ep.trackConnection(errors.New("blob: not found"))
+	require.Len(t, events, 2)
+	require.Equal(t, ConnectionEvent{Type: Connected, Addr: "memory"}, events[1])
+
+	// endpoints without a handler are ignored
+	(&endpoint{}).trackConnection(context.DeadlineExceeded)
+}
+
+func TestResubscribeEvents(t *testing.T) {
+	var buf bytes.Buffer
+	r := testResubscriber(&buf)
+	var events []ConnectionEvent
+	r.events = func(e ConnectionEvent) { events = append(events, e) }
+
+	first, second := make(chan uint64), make(chan uint64, 1)
+	close(first)
+	second <- 1
+	subscribe, _ := subscriptions(first, second)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	out, err := resubscribe(ctx, r, "header.Subscribe", subscribe, nil)
+	require.NoError(t, err)
+	require.EqualValues(t, 1, <-out)
+	cancel()
+	close(second)
+	drain(out)
+
+	require.Equal(t, []ConnectionEvent{
+		{Type: Reconnecting, Method: "header.Subscribe", Attempt: 1},
+		{Type: Resubscribed, Method: "header.Subscribe"},
+	}, events)
+}
+
+func TestConnectionEventTypeString(t *testing.T) {
+	require.Equal(t, "disconnected", Disconnected.String())
+	require.Equal(t, "ConnectionEventType(0)", ConnectionEventType(0).String())
+}
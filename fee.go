@@ -0,0 +1,131 @@
+package client
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/celestiaorg/celestia-openrpc/types/appconsts"
+	"github.com/celestiaorg/celestia-openrpc/types/blob"
+)
+
+// FeeTier trades the cost of a submission for how quickly it is included, by paying
+// a gas price above the minimum one of the network.
+type FeeTier int
+
+const (
+	// FeeSlow pays the minimum gas price, so it is the first to wait when blocks are full.
+	FeeSlow FeeTier = iota
+	// FeeNormal pays slightly above the minimum gas price.
+	FeeNormal
+	// FeeFast pays well above the minimum gas price to be prioritized in busy blocks.
+	FeeFast
+)
+
+func (t FeeTier) String() string {
+	switch t {
+	case FeeSlow:
+		return "slow"
+	case FeeNormal:
+		return "normal"
+	case FeeFast:
+		return "fast"
+	default:
+		return fmt.Sprintf("FeeTier(%d)", int(t))
+	}
+}
+
+// DefaultFeeMultipliers are the factors the minimum gas price is multiplied by for each tier.
+var DefaultFeeMultipliers = map[FeeTier]float64{
+	FeeSlow:   1,
+	FeeNormal: 1.2,
+	FeeFast:   2,
+}
+
+// GasPriceFunc returns the minimum gas price of the network in utia,
+// e.g. consensus.Client.MinimumGasPrice.
+type GasPriceFunc func(ctx context.Context) (float64, error)
+
+// FeeEstimate is the recommended gas limit and price of a submission.
+type FeeEstimate struct {
+	Tier FeeTier
+	// Gas is the gas limit estimated with blob.EstimateGas.
+	Gas uint64
+	// GasPrice is the minimum gas price of the network scaled by the multiplier of the tier.
+	GasPrice float64
+	// Fee is the fee in utia paid at most.
+	Fee uint64
+}
+
+// SubmitOptions returns options submitting with the estimated gas limit and price,
+// on top of the given ones.
+func (e *FeeEstimate) SubmitOptions(opts ...blob.ConfigOption) *blob.SubmitOptions {
+	return blob.NewSubmitOptions(append(opts, blob.WithGas(e.Gas), blob.WithGasPrice(e.GasPrice))...)
+}
+
+// FeeOption is the functional option that is applied to the fee estimator
+// to configure its parameters.
+type FeeOption func(e *FeeEstimator)
+
+// WithFeeMultiplier is an option that allows to specify the factor the minimum gas price
+// is multiplied by for the tier, instead of the one of DefaultFeeMultipliers.
+func WithFeeMultiplier(tier FeeTier, multiplier float64) FeeOption {
+	return func(e *FeeEstimator) {
+		e.multipliers[tier] = multiplier
+	}
+}
+
+// FeeEstimator recommends fees for submissions, combining the gas they are estimated
+// to use with the minimum gas price of the network.
+type FeeEstimator struct {
+	gasPrice    GasPriceFunc
+	multipliers map[FeeTier]float64
+}
+
+// NewFeeEstimator returns a fee estimator querying the minimum gas price of the network
+// with gasPrice. If gasPrice is nil or reports no minimum, the default minimum gas price
+// of celestia-app is used instead.
+func NewFeeEstimator(gasPrice GasPriceFunc, opts ...FeeOption) *FeeEstimator {
+	e := &FeeEstimator{
+		gasPrice:    gasPrice,
+		multipliers: make(map[FeeTier]float64, len(DefaultFeeMultipliers)),
+	}
+	for tier, multiplier := range DefaultFeeMultipliers {
+		e.multipliers[tier] = multiplier
+	}
+	for _, opt := range opts {
+		opt(e)
+	}
+	return e
+}
+
+// Estimate returns the recommended fee of the tier for submitting the blobs.
+func (e *FeeEstimator) Estimate(ctx context.Context, tier FeeTier, blobs ...*blob.Blob) (*FeeEstimate, error) {
+	multiplier, ok := e.multipliers[tier]
+	if !ok {
+		return nil, fmt.Errorf("fee estimation: unknown tier %s", tier)
+	}
+	if len(blobs) == 0 {
+		return nil, errors.New("fee estimation: no blobs provided")
+	}
+
+	minGasPrice := float64(0)
+	if e.gasPrice != nil {
+		var err error
+		minGasPrice, err = e.gasPrice(ctx)
+		if err != nil {
+			return nil, fmt.Errorf("fee estimation: getting minimum gas price: %w", err)
+		}
+	}
+	if minGasPrice <= 0 {
+		minGasPrice = appconsts.DefaultMinGasPrice
+	}
+
+	est := &FeeEstimate{
+		Tier:     tier,
+		Gas:      blob.EstimateGas(blobs...),
+		GasPrice: minGasPrice * multiplier,
+	}
+	est.Fee = fee(est.GasPrice, est.Gas)
+	return est, nil
+}
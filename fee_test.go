@@ -0,0 +1,61 @@
+package client
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/celestiaorg/celestia-openrpc/types/appconsts"
+	"github.com/celestiaorg/celestia-openrpc/types/blob"
+)
+
+func TestFeeEstimator(t *testing.T) {
+	ctx := context.Background()
+	b := testBlob(t, "hello")
+	e := NewFeeEstimator(func(context.Context) (float64, error) {
+		return 0.004, nil
+	}, WithFeeMultiplier(FeeFast, 3))
+
+	slow, err := e.Estimate(ctx, FeeSlow, b)
+	require.NoError(t, err)
+	require.Equal(t, blob.EstimateGas(b), slow.Gas)
+	require.Equal(t, 0.004, slow.GasPrice)
+	require.Equal(t, fee(0.004, slow.Gas), slow.Fee)
+
+	normal, err := e.Estimate(ctx, FeeNormal, b)
+	require.NoError(t, err)
+	require.InDelta(t, 0.004*DefaultFeeMultipliers[FeeNormal], normal.GasPrice, 1e-12)
+
+	fast, err := e.Estimate(ctx, FeeFast, b)
+	require.NoError(t, err)
+	require.InDelta(t, 0.012, fast.GasPrice, 1e-12)
+	require.Greater(t, fast.Fee, normal.Fee)
+
+	opts := fast.SubmitOptions(blob.WithKeyName("key"))
+	require.Equal(t, fast.Gas, opts.GasLimit())
+	require.Equal(t, fast.GasPrice, opts.GasPrice())
+	require.Equal(t, "key", opts.KeyName())
+
+	_, err = e.Estimate(ctx, FeeTier(7), b)
+	require.Error(t, err)
+	_, err = e.Estimate(ctx, FeeSlow)
+	require.Error(t, err)
+}
+
+func TestFeeEstimatorGasPrice(t *testing.T) {
+	ctx := context.Background()
+	b := testBlob(t, "hello")
+
+	// without a minimum gas price, the default one of celestia-app is used
+	est, err := NewFeeEstimator(nil).Estimate(ctx, FeeSlow, b)
+	require.NoError(t, err)
+	require.Equal(t, appconsts.DefaultMinGasPrice, est.GasPrice)
+
+	failing := NewFeeEstimator(func(context.Context) (float64, error) {
+		return 0, errors.New("unavailable")
+	})
+	_, err = failing.Estimate(ctx, FeeSlow, b)
+	require.Error(t, err)
+}
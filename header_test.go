@@ -0,0 +1,53 @@
+package client
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestRequestHeaders(t *testing.T) {
+	var (
+		mu     sync.Mutex
+		header http.Header
+	)
+	srv := testNode(t, func(r *http.Request, method string, _ json.RawMessage) (interface{}, error) {
+		if method != "node.Ready" {
+			return nil, errors.New("method not found")
+		}
+		mu.Lock()
+		header = r.Header.Clone()
+		mu.Unlock()
+		return true, nil
+	})
+
+	c, err := NewClient(context.Background(), srv.URL, "token",
+		WithHeader("X-Api-Key", "key"), WithUserAgent("rollup/1.0"))
+	require.NoError(t, err)
+	defer c.Close()
+
+	_, err = c.Node.Ready(context.Background())
+	require.NoError(t, err)
+
+	mu.Lock()
+	defer mu.Unlock()
+	require.Equal(t, "key", header.Get("X-Api-Key"))
+	require.Equal(t, "rollup/1.0", header.Get("User-Agent"))
+	require.Equal(t, "Bearer token", header.Get(AuthKey))
+}
+
+func TestAuthHeader(t *testing.T) {
+	base := http.Header{"X-Api-Key": {"key"}}
+	header := authHeader(base, "token")
+	require.Equal(t, "Bearer token", header.Get(AuthKey))
+	require.Equal(t, "key", header.Get("X-Api-Key"))
+	// the base header is left untouched
+	require.Empty(t, base.Get(AuthKey))
+
+	require.Empty(t, authHeader(nil, "").Get(AuthKey))
+}
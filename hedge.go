@@ -0,0 +1,84 @@
+package client
+
+import (
+	"context"
+	"time"
+)
+
+// defaultHedgedMethods are the read-only methods hedged if none are provided to WithHedging.
+var defaultHedgedMethods = []string{
+	"header.GetByHeight",
+	"header.GetByHash",
+	"share.GetEDS",
+	"share.GetSharesByNamespace",
+	"blob.Get",
+	"blob.GetAll",
+}
+
+// hedge returns an interceptor firing read-only calls of the given methods against the
+// second most preferred endpoint if the first one hasn't responded within the delay,
+// returning the first successful response.
+func hedge(p *proxy, delay time.Duration, methods []string, clock Clock) interceptor {
+	hedged := make(map[string]bool, len(methods))
+	for _, method := range methods {
+		hedged[method] = true
+	}
+
+	return func(ctx context.Context, c *call, next invoker) ([]interface{}, error) {
+		if _, pinned := pinnedEndpoint(ctx); pinned || c.perm != "read" || !hedged[c.name()] || len(p.endpoints) < 2 {
+			return next(ctx, c)
+		}
+
+		ctx, cancel := context.WithCancel(ctx)
+		defer cancel()
+
+		type result struct {
+			out []interface{}
+			err error
+		}
+		results := make(chan result, 2)
+		launch := func(idx int) {
+			go func() {
+				out, err := next(withEndpoint(ctx, idx), c)
+				results <- result{out: out, err: err}
+			}()
+		}
+
+		ranked := p.rank()
+		launch(ranked[0])
+		pending, secondary := 1, false
+
+		timer := clock.NewTimer(delay)
+		defer timer.Stop()
+
+		var firstErr error
+		for {
+			select {
+			case <-timer.C():
+				if !secondary {
+					launch(ranked[1])
+					pending, secondary = pending+1, true
+				}
+			case res := <-results:
+				pending--
+				if res.err == nil {
+					return res.out, nil
+				}
+				if firstErr == nil {
+					firstErr = res.err
+				}
+				if !secondary {
+					// don't wait for the delay if the first endpoint failed already
+					launch(ranked[1])
+					pending, secondary = pending+1, true
+					continue
+				}
+				if pending == 0 {
+					return nil, firstErr
+				}
+			case <-ctx.Done():
+				return nil, ctx.Err()
+			}
+		}
+	}
+}
@@ -0,0 +1,104 @@
+package client
+
+import (
+	"context"
+	"errors"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+// testProxy returns a proxy over endpoints with the given addresses, which aren't connected.
+func testProxy(addrs ...string) *proxy {
+	endpoints := make([]*endpoint, len(addrs))
+	for i, addr := range addrs {
+		endpoints[i] = &endpoint{addr: addr}
+	}
+	return newProxy(endpoints)
+}
+
+func TestHedge(t *testing.T) {
+	p := testProxy("slow", "fast")
+	intercept := hedge(p, 20*time.Millisecond, defaultHedgedMethods, realClock{})
+
+	next := func(ctx context.Context, _ *call) ([]interface{}, error) {
+		idx, _ := pinnedEndpoint(ctx)
+		if p.endpoints[idx].addr == "slow" {
+			select {
+			case <-time.After(time.Second):
+			case <-ctx.Done():
+				return nil, ctx.Err()
+			}
+		}
+		return []interface{}{p.endpoints[idx].addr}, nil
+	}
+
+	start := time.Now()
+	out, err := intercept(context.Background(), &call{module: "header", method: "GetByHeight", perm: "read"}, next)
+	require.NoError(t, err)
+	require.Equal(t, []interface{}{"fast"}, out)
+	require.Less(t, time.Since(start), 500*time.Millisecond)
+}
+
+func TestHedgeAfterFailure(t *testing.T) {
+	p := testProxy("failing", "healthy")
+	intercept := hedge(p, time.Hour, defaultHedgedMethods, realClock{})
+
+	next := func(ctx context.Context, _ *call) ([]interface{}, error) {
+		idx, _ := pinnedEndpoint(ctx)
+		if p.endpoints[idx].addr == "failing" {
+			return nil, errors.New("connection refused")
+		}
+		return []interface{}{"ok"}, nil
+	}
+
+	// the second endpoint is tried right away instead of after the delay
+	out, err := intercept(context.Background(), &call{module: "blob", method: "GetAll", perm: "read"}, next)
+	require.NoError(t, err)
+	require.Equal(t, []interface{}{"ok"}, out)
+}
+
+func TestHedgeReturnsFirstError(t *testing.T) {
+	p := testProxy("a", "b")
+	intercept := hedge(p, time.Millisecond, defaultHedgedMethods, realClock{})
+	errFirst := errors.New("first")
+
+	next := func(ctx context.Context, _ *call) ([]interface{}, error) {
+		idx, _ := pinnedEndpoint(ctx)
+		if idx == p.rank()[0] {
+			return nil, errFirst
+		}
+		time.Sleep(10 * time.Millisecond)
+		return nil, errors.New("second")
+	}
+
+	_, err := intercept(context.Background(), &call{module: "share", method: "GetEDS", perm: "read"}, next)
+	require.ErrorIs(t, err, errFirst)
+}
+
+func TestHedgeSkipsOtherCalls(t *testing.T) {
+	p := testProxy("a", "b")
+	intercept := hedge(p, time.Millisecond, defaultHedgedMethods, realClock{})
+
+	var calls, pinnedCalls atomic.Int32
+	next := func(ctx context.Context, _ *call) ([]interface{}, error) {
+		calls.Add(1)
+		if _, pinned := pinnedEndpoint(ctx); pinned {
+			pinnedCalls.Add(1)
+		}
+		time.Sleep(10 * time.Millisecond)
+		return nil, nil
+	}
+
+	for _, c := range []*call{
+		{module: "blob", method: "Submit", perm: "write"},
+		{module: "header", method: "NetworkHead", perm: "read"},
+	} {
+		_, err := intercept(context.Background(), c, next)
+		require.NoError(t, err)
+	}
+	require.EqualValues(t, 2, calls.Load())
+	require.Zero(t, pinnedCalls.Load())
+}
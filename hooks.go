@@ -0,0 +1,55 @@
+package client
+
+import (
+	"context"
+	"log/slog"
+	"time"
+)
+
+// CallInfo describes a request made to a node, as passed to a CallHook.
+type CallInfo struct {
+	// Method is the name of the JSON-RPC method, e.g. "header.GetByHeight".
+	Method string
+	// Params holds the parameters of the request, excluding the context.
+	Params []interface{}
+	// Result holds the results of the request, excluding the error.
+	Result   []interface{}
+	Duration time.Duration
+	Err      error
+	// RequestID is the ID of the request, if the client attaches them or the caller set one.
+	RequestID string
+}
+
+// CallHook is notified about every request made to a node. Retried and hedged calls
+// result in several requests. Hooks must not modify the params or results.
+type CallHook func(ctx context.Context, info CallInfo)
+
+// notify returns an interceptor notifying the hooks about the outcome of every call.
+func notify(hooks []CallHook) interceptor {
+	return func(ctx context.Context, c *call, next invoker) ([]interface{}, error) {
+		start := time.Now()
+		out, err := next(ctx, c)
+		id, _ := RequestID(ctx)
+		info := CallInfo{
+			Method:    c.name(),
+			Params:    c.args,
+			Result:    out,
+			Duration:  time.Since(start),
+			Err:       err,
+			RequestID: id,
+		}
+		for _, hook := range hooks {
+			hook(ctx, info)
+		}
+		return out, err
+	}
+}
+
+// logSlowCalls returns a CallHook logging calls which took longer than threshold.
+func logSlowCalls(log *slog.Logger, threshold time.Duration) CallHook {
+	return func(ctx context.Context, info CallInfo) {
+		if info.Duration > threshold {
+			log.WarnContext(ctx, "slow call", "method", info.Method, "duration", info.Duration, "err", info.Err)
+		}
+	}
+}
@@ -0,0 +1,47 @@
+package client
+
+import (
+	"bytes"
+	"context"
+	"log/slog"
+	"syscall"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestNotify(t *testing.T) {
+	var infos []CallInfo
+	hook := func(_ context.Context, info CallInfo) {
+		infos = append(infos, info)
+	}
+	intercept := notify([]CallHook{hook, hook})
+
+	next, _ := failingInvoker(1, syscall.ECONNRESET)
+	c := &call{module: "header", method: "GetByHeight", perm: "read", args: []interface{}{uint64(10)}}
+	_, err := intercept(context.Background(), c, next)
+	require.ErrorIs(t, err, syscall.ECONNRESET)
+	out, err := intercept(context.Background(), c, next)
+	require.NoError(t, err)
+
+	// every hook is notified about every call
+	require.Len(t, infos, 4)
+	require.Equal(t, "header.GetByHeight", infos[0].Method)
+	require.Equal(t, []interface{}{uint64(10)}, infos[0].Params)
+	require.ErrorIs(t, infos[0].Err, syscall.ECONNRESET)
+	require.Equal(t, out, infos[2].Result)
+	require.NoError(t, infos[2].Err)
+}
+
+func TestLogSlowCalls(t *testing.T) {
+	var buf bytes.Buffer
+	hook := logSlowCalls(slog.New(slog.NewTextHandler(&buf, nil)), 100*time.Millisecond)
+
+	hook(context.Background(), CallInfo{Method: "share.GetEDS", Duration: 10 * time.Millisecond})
+	require.Empty(t, buf.String())
+
+	hook(context.Background(), CallInfo{Method: "share.GetEDS", Duration: time.Second})
+	require.Contains(t, buf.String(), "slow call")
+	require.Contains(t, buf.String(), "share.GetEDS")
+}
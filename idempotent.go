@@ -0,0 +1,160 @@
+package client
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log/slog"
+
+	"github.com/celestiaorg/celestia-openrpc/types/blob"
+	"github.com/celestiaorg/celestia-openrpc/types/state"
+)
+
+// SubmitReceipt records the inclusion of a blob submitted through the client.
+type SubmitReceipt struct {
+	// Height is the height the blob was included at.
+	Height uint64 `json:"height"`
+	// TxHash is the hash of the transaction paying for the blob. It's unknown
+	// for blobs submitted with blob.Submit.
+	TxHash string `json:"tx_hash,omitempty"`
+	// Start and End are the indexes of the first share of the blob and the one after its
+	// last in the original data square. End is zero if the blob wasn't located, which is
+	// only done by WithReceiptStore.
+	Start int `json:"start"`
+	End   int `json:"end"`
+	// Fee is the fee in utia paid for the transaction including the blob, if known.
+	Fee uint64 `json:"fee,omitempty"`
+}
+
+// SubmissionStore records the blobs included through the client by their commitment, which
+// identifies the namespace and the data of a blob, e.g. to skip resubmissions, to audit
+// submissions or to prove the inclusion of blobs later on. Implementations must be safe for
+// concurrent use.
+type SubmissionStore interface {
+	// Get returns the receipt of the blob with the commitment, if it was included before.
+	Get(commitment blob.Commitment) (SubmitReceipt, bool)
+	// Put records the inclusion of the blob with the commitment.
+	Put(commitment blob.Commitment, receipt SubmitReceipt) error
+}
+
+// NewMemorySubmissionStore returns a SubmissionStore keeping the receipts of the size most
+// recently included blobs in memory, which protects against resubmissions by the process.
+func NewMemorySubmissionStore(size int) SubmissionStore {
+	return &cacheSubmissionStore{cache: NewLRUCache(size)}
+}
+
+// NewFileSubmissionStore returns a SubmissionStore persisting the receipts in dir, which
+// protects against resubmissions after restarts. The directory is created if it does not exist.
+func NewFileSubmissionStore(dir string) (SubmissionStore, error) {
+	cache, err := NewFileCache(dir)
+	if err != nil {
+		return nil, err
+	}
+	return &cacheSubmissionStore{cache: cache}, nil
+}
+
+// cacheSubmissionStore is a SubmissionStore keeping the receipts encoded as JSON in a Cache.
+type cacheSubmissionStore struct {
+	cache Cache
+}
+
+// Get implements SubmissionStore.
+func (s *cacheSubmissionStore) Get(commitment blob.Commitment) (SubmitReceipt, bool) {
+	var receipt SubmitReceipt
+	bz, ok := s.cache.Get(submissionKey(commitment))
+	return receipt, ok && json.Unmarshal(bz, &receipt) == nil
+}
+
+// Put implements SubmissionStore.
+func (s *cacheSubmissionStore) Put(commitment blob.Commitment, receipt SubmitReceipt) error {
+	bz, err := json.Marshal(receipt)
+	if err != nil {
+		return err
+	}
+	return s.cache.Put(submissionKey(commitment), bz)
+}
+
+func submissionKey(commitment blob.Commitment) string {
+	return fmt.Sprintf("submissions/%x", []byte(commitment))
+}
+
+// submitOnce wraps the submission methods of the client to skip the blobs recorded in the
+// store, returning the original receipt if all of them were included before.
+func submitOnce(c *Client, store SubmissionStore, log *slog.Logger) {
+	submit := c.Blob.Submit
+	c.Blob.Submit = func(ctx context.Context, blobs []*blob.Blob, opts *blob.SubmitOptions) (uint64, error) {
+		pending, receipt := unsubmitted(store, blobs)
+		// empty submissions are left to the node to reject
+		if len(blobs) > 0 && len(pending) == 0 {
+			log.InfoContext(ctx, "skipping submission of blobs included before", "height", receipt.Height)
+			return receipt.Height, nil
+		}
+		height, err := submit(ctx, pending, opts)
+		if err != nil {
+			return height, err
+		}
+		recordSubmission(ctx, store, log, pending, SubmitReceipt{Height: height})
+		return height, nil
+	}
+
+	submitPayForBlob := c.State.SubmitPayForBlob
+	c.State.SubmitPayForBlob = func(
+		ctx context.Context,
+		blobs []*blob.Blob,
+		config *state.TxConfig,
+	) (*state.TxResponse, error) {
+		pending, receipt := unsubmitted(store, blobs)
+		// empty submissions are left to the node to reject
+		if len(blobs) > 0 && len(pending) == 0 {
+			log.InfoContext(ctx, "skipping submission of blobs included before",
+				"height", receipt.Height, "tx_hash", receipt.TxHash)
+			//nolint:gosec
+			return &state.TxResponse{Height: int64(receipt.Height), TxHash: receipt.TxHash}, nil
+		}
+		resp, err := submitPayForBlob(ctx, pending, config)
+		if err != nil || resp == nil || resp.Code != 0 {
+			return resp, err
+		}
+		//nolint:gosec
+		recordSubmission(ctx, store, log, pending, SubmitReceipt{Height: uint64(resp.Height), TxHash: resp.TxHash})
+		return resp, nil
+	}
+}
+
+// unsubmitted returns the blobs which weren't included before, along with the receipt of
+// the latest inclusion of the others.
+func unsubmitted(store SubmissionStore, blobs []*blob.Blob) ([]*blob.Blob, SubmitReceipt) {
+	pending := make([]*blob.Blob, 0, len(blobs))
+	var latest SubmitReceipt
+	for _, b := range blobs {
+		receipt, ok := store.Get(b.Commitment)
+		if !ok {
+			pending = append(pending, b)
+			continue
+		}
+		if receipt.Height >= latest.Height {
+			latest = receipt
+		}
+	}
+	return pending, latest
+}
+
+// recordSubmission records the inclusion of the blobs, keeping the receipts recorded while
+// submitting them, e.g. by WithReceiptStore. As the blobs were included already, failing to
+// record them is only logged.
+func recordSubmission(
+	ctx context.Context,
+	store SubmissionStore,
+	log *slog.Logger,
+	blobs []*blob.Blob,
+	receipt SubmitReceipt,
+) {
+	for _, b := range blobs {
+		if recorded, ok := store.Get(b.Commitment); ok && recorded.Height == receipt.Height {
+			continue
+		}
+		if err := store.Put(b.Commitment, receipt); err != nil {
+			log.WarnContext(ctx, "recording submitted blob failed", "height", receipt.Height, "err", err)
+		}
+	}
+}
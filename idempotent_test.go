@@ -0,0 +1,87 @@
+package client
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/celestiaorg/celestia-openrpc/types/blob"
+	"github.com/celestiaorg/celestia-openrpc/types/state"
+)
+
+func TestSubmitOnce(t *testing.T) {
+	a, b, c := testBlob(t, "a"), testBlob(t, "b"), testBlob(t, "c")
+
+	var submitted [][]*blob.Blob
+	var fail error
+	client := &Client{}
+	client.Blob.Submit = func(_ context.Context, blobs []*blob.Blob, _ *blob.SubmitOptions) (uint64, error) {
+		submitted = append(submitted, blobs)
+		//nolint:gosec
+		return uint64(len(submitted)), fail
+	}
+	submitOnce(client, NewMemorySubmissionStore(10), discardLogger)
+
+	height, err := client.Blob.Submit(context.Background(), []*blob.Blob{a, b}, nil)
+	require.NoError(t, err)
+	require.EqualValues(t, 1, height)
+
+	// resubmitting the same blobs returns the original height
+	height, err = client.Blob.Submit(context.Background(), []*blob.Blob{a, b}, nil)
+	require.NoError(t, err)
+	require.EqualValues(t, 1, height)
+	require.Len(t, submitted, 1)
+
+	// only the blobs which weren't included are submitted
+	height, err = client.Blob.Submit(context.Background(), []*blob.Blob{a, c}, nil)
+	require.NoError(t, err)
+	require.EqualValues(t, 2, height)
+	require.Equal(t, []*blob.Blob{c}, submitted[1])
+
+	// failed submissions aren't recorded
+	fail = errors.New("insufficient funds")
+	d := testBlob(t, "d")
+	_, err = client.Blob.Submit(context.Background(), []*blob.Blob{d}, nil)
+	require.Error(t, err)
+	_, err = client.Blob.Submit(context.Background(), []*blob.Blob{d}, nil)
+	require.Error(t, err)
+	require.Len(t, submitted, 4)
+}
+
+func TestSubmitOncePayForBlob(t *testing.T) {
+	store, err := NewFileSubmissionStore(t.TempDir())
+	require.NoError(t, err)
+
+	calls := 0
+	client := &Client{}
+	client.State.SubmitPayForBlob = func(context.Context, []*blob.Blob, *state.TxConfig) (*state.TxResponse, error) {
+		calls++
+		if calls == 1 {
+			return &state.TxResponse{Code: codeInsufficientFee, RawLog: "insufficient fee"}, nil
+		}
+		return &state.TxResponse{Height: 7, TxHash: "ABCD"}, nil
+	}
+	submitOnce(client, store, discardLogger)
+
+	blobs := []*blob.Blob{testBlob(t, "hello")}
+	// rejected transactions aren't recorded
+	resp, err := client.State.SubmitPayForBlob(context.Background(), blobs, nil)
+	require.NoError(t, err)
+	require.EqualValues(t, codeInsufficientFee, resp.Code)
+
+	resp, err = client.State.SubmitPayForBlob(context.Background(), blobs, nil)
+	require.NoError(t, err)
+	require.Equal(t, "ABCD", resp.TxHash)
+
+	resp, err = client.State.SubmitPayForBlob(context.Background(), blobs, nil)
+	require.NoError(t, err)
+	require.EqualValues(t, 7, resp.Height)
+	require.Equal(t, "ABCD", resp.TxHash)
+	require.Equal(t, 2, calls)
+
+	receipt, ok := store.Get(blobs[0].Commitment)
+	require.True(t, ok)
+	require.Equal(t, SubmitReceipt{Height: 7, TxHash: "ABCD"}, receipt)
+}
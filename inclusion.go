@@ -0,0 +1,88 @@
+package client
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/celestiaorg/celestia-openrpc/types/blob"
+	"github.com/celestiaorg/celestia-openrpc/types/share"
+)
+
+// InclusionResult is the outcome of the verification of the inclusion of a blob.
+type InclusionResult struct {
+	// Height is the height the blob is verified to be included at.
+	Height uint64
+	// DataRoot is the data root of the header the proof was verified against.
+	DataRoot []byte
+	// Included is whether the blob is proven to be included.
+	Included bool
+	// Start and End are the indexes of the first share of the blob and the one after
+	// its last in the original data square, if the blob is included.
+	Start, End int
+	// Err is why the blob isn't proven to be included: blob.ErrBlobNotFound if the node
+	// doesn't have it, and blob.ErrInvalidProof if the proof of the node doesn't verify.
+	Err error
+}
+
+// VerifyInclusion checks locally that a blob with the commitment is included in the block
+// at the height, in a single call: the commitment proof of the blob is retrieved and verified
+// against the data root of the header, without retrieving the blob itself. Blobs which aren't
+// proven to be included are reported in the result, errors are only returned if the header
+// or proof can't be retrieved.
+func (c *Client) VerifyInclusion(
+	ctx context.Context,
+	height uint64,
+	namespace share.Namespace,
+	commitment blob.Commitment,
+) (*InclusionResult, error) {
+	eh, err := c.Header.GetByHeight(ctx, height)
+	if err != nil {
+		return nil, fmt.Errorf("getting header to verify inclusion: %w", err)
+	}
+	res := &InclusionResult{Height: height, DataRoot: eh.DataHash}
+
+	proof, err := c.Blob.GetCommitmentProof(ctx, height, namespace, commitment)
+	switch {
+	case errors.Is(err, blob.ErrBlobNotFound):
+		res.Err = err
+		return res, nil
+	case errors.Is(err, ErrVerificationFailed):
+		res.Err = fmt.Errorf("%w: %v", blob.ErrInvalidProof, err)
+		return res, nil
+	case err != nil:
+		return nil, fmt.Errorf("getting commitment proof: %w", err)
+	}
+
+	if res.Err = verifyCommitmentProof(proof, namespace, commitment, eh.DataHash); res.Err != nil {
+		return res, nil
+	}
+	width, err := squareWidth(eh)
+	if err != nil {
+		return nil, err
+	}
+	if res.Start, res.End, res.Err = proofShareRange(proof, width); res.Err != nil {
+		res.Err = fmt.Errorf("%w: %v", blob.ErrInvalidProof, res.Err)
+		return res, nil
+	}
+	res.Included = true
+	return res, nil
+}
+
+// verifyCommitmentProof checks that the proof proves the commitment of the namespace
+// to be included under the data root.
+func verifyCommitmentProof(
+	proof *blob.CommitmentProof,
+	namespace share.Namespace,
+	commitment blob.Commitment,
+	dataRoot []byte,
+) error {
+	if proof == nil || proof.NamespaceVersion != namespace.Version() || !bytes.Equal(proof.NamespaceID, namespace.ID()) {
+		return fmt.Errorf("%w: proof isn't for namespace %s", blob.ErrInvalidProof, namespace)
+	}
+	if !proof.GenerateCommitment().Equal(commitment) {
+		return fmt.Errorf("%w: proof is for another commitment", blob.ErrInvalidProof)
+	}
+	return proof.Verify(dataRoot)
+}
@@ -0,0 +1,55 @@
+package client
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/celestiaorg/celestia-openrpc/types/blob"
+	"github.com/celestiaorg/celestia-openrpc/types/header"
+	"github.com/celestiaorg/celestia-openrpc/types/share"
+)
+
+func TestClientVerifyInclusion(t *testing.T) {
+	pb := newProvenBlock(t)
+	namespace := share.Namespace(pb.blob.Namespace().Bytes())
+	ctx := context.Background()
+
+	c := pb.client([]*blob.Blob{pb.blob}, pb.shares)
+	res, err := c.VerifyInclusion(ctx, 1, namespace, pb.blob.Commitment)
+	require.NoError(t, err)
+	require.True(t, res.Included)
+	require.NoError(t, res.Err)
+	require.Equal(t, []byte(pb.header.DataHash), res.DataRoot)
+	require.Equal(t, 0, res.Start)
+	require.Equal(t, len(pb.shares), res.End)
+
+	res, err = c.VerifyInclusion(ctx, 1, namespace, testBlob(t, "other").Commitment)
+	require.NoError(t, err)
+	require.False(t, res.Included)
+	require.ErrorIs(t, res.Err, blob.ErrInvalidProof)
+
+	// the proof is verified even if the client doesn't verify proofs
+	c = &Client{}
+	untrusted := *pb.header
+	untrusted.DataHash = []byte("untrusted")
+	c.Header.GetByHeight = func(context.Context, uint64) (*header.ExtendedHeader, error) {
+		return &untrusted, nil
+	}
+	c.Blob.GetCommitmentProof = func(context.Context, uint64, share.Namespace, []byte) (*blob.CommitmentProof, error) {
+		return pb.commitmentProof(pb.shares), nil
+	}
+	res, err = c.VerifyInclusion(ctx, 1, namespace, pb.blob.Commitment)
+	require.NoError(t, err)
+	require.False(t, res.Included)
+	require.ErrorIs(t, res.Err, blob.ErrInvalidProof)
+
+	c.Blob.GetCommitmentProof = func(context.Context, uint64, share.Namespace, []byte) (*blob.CommitmentProof, error) {
+		return nil, blob.ErrBlobNotFound
+	}
+	res, err = c.VerifyInclusion(ctx, 1, namespace, pb.blob.Commitment)
+	require.NoError(t, err)
+	require.False(t, res.Included)
+	require.ErrorIs(t, res.Err, blob.ErrBlobNotFound)
+}
@@ -0,0 +1,33 @@
+package client
+
+import (
+	"context"
+	"errors"
+)
+
+// ErrTooManyInFlight is returned for calls rejected because the limit of calls in flight is reached.
+var ErrTooManyInFlight = errors.New("too many calls in flight")
+
+// limitInFlight returns an interceptor bounding the number of calls in flight to the
+// capacity of the semaphore. Excess calls wait for a slot, unless reject is set, in
+// which case they fail with ErrTooManyInFlight.
+func limitInFlight(sem chan struct{}, reject bool) interceptor {
+	return func(ctx context.Context, c *call, next invoker) ([]interface{}, error) {
+		if reject {
+			select {
+			case sem <- struct{}{}:
+			default:
+				return nil, ErrTooManyInFlight
+			}
+		} else {
+			select {
+			case sem <- struct{}{}:
+			case <-ctx.Done():
+				return nil, ctx.Err()
+			}
+		}
+		defer func() { <-sem }()
+
+		return next(ctx, c)
+	}
+}
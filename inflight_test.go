@@ -0,0 +1,73 @@
+package client
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+// blockingInvoker returns an invoker signalling started once a call arrives
+// and blocking until release is closed.
+func blockingInvoker() (next invoker, started chan struct{}, release chan struct{}) {
+	started, release = make(chan struct{}, 16), make(chan struct{})
+	return func(context.Context, *call) ([]interface{}, error) {
+		started <- struct{}{}
+		<-release
+		return nil, nil
+	}, started, release
+}
+
+func TestLimitInFlightQueues(t *testing.T) {
+	intercept := limitInFlight(make(chan struct{}, 1), false)
+	next, started, release := blockingInvoker()
+	c := &call{module: "header", method: "GetByHeight", perm: "read"}
+
+	done := make(chan error, 2)
+	for i := 0; i < 2; i++ {
+		go func() {
+			_, err := intercept(context.Background(), c, next)
+			done <- err
+		}()
+	}
+
+	<-started
+	select {
+	case <-started:
+		t.Fatal("second call started while the first one was in flight")
+	case <-time.After(20 * time.Millisecond):
+	}
+	close(release)
+	require.NoError(t, <-done)
+	require.NoError(t, <-done)
+}
+
+func TestLimitInFlightRejects(t *testing.T) {
+	intercept := limitInFlight(make(chan struct{}, 1), true)
+	next, started, release := blockingInvoker()
+	c := &call{module: "header", method: "GetByHeight", perm: "read"}
+
+	done := make(chan error, 1)
+	go func() {
+		_, err := intercept(context.Background(), c, next)
+		done <- err
+	}()
+	<-started
+
+	_, err := intercept(context.Background(), c, next)
+	require.ErrorIs(t, err, ErrTooManyInFlight)
+	close(release)
+	require.NoError(t, <-done)
+}
+
+func TestLimitInFlightCancelled(t *testing.T) {
+	sem := make(chan struct{}, 1)
+	sem <- struct{}{}
+	intercept := limitInFlight(sem, false)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	_, err := intercept(ctx, &call{module: "header", method: "GetByHeight", perm: "read"}, nil)
+	require.ErrorIs(t, err, context.DeadlineExceeded)
+}
@@ -0,0 +1,28 @@
+package client
+
+import (
+	"github.com/celestiaorg/celestia-openrpc/types/blob"
+	"github.com/celestiaorg/celestia-openrpc/types/da"
+	"github.com/celestiaorg/celestia-openrpc/types/das"
+	"github.com/celestiaorg/celestia-openrpc/types/fraud"
+	"github.com/celestiaorg/celestia-openrpc/types/header"
+	"github.com/celestiaorg/celestia-openrpc/types/node"
+	"github.com/celestiaorg/celestia-openrpc/types/p2p"
+	"github.com/celestiaorg/celestia-openrpc/types/share"
+	"github.com/celestiaorg/celestia-openrpc/types/state"
+)
+
+// The interfaces of the modules. The modules of the Client are turned into them with the
+// Wrap function of their package, e.g. blob.Wrap(&c.Blob), while NewAPI turns any
+// implementation back into a module, e.g. to wrap it with custom middleware.
+type (
+	BlobAPI   = blob.Module
+	DAAPI     = da.Module
+	DASAPI    = das.Module
+	FraudAPI  = fraud.Module
+	HeaderAPI = header.Module
+	NodeAPI   = node.Module
+	P2PAPI    = p2p.Module
+	ShareAPI  = share.Module
+	StateAPI  = state.Module
+)
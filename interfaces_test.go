@@ -0,0 +1,122 @@
+package client
+
+import (
+	"context"
+	"reflect"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/celestiaorg/celestia-openrpc/types/blob"
+	"github.com/celestiaorg/celestia-openrpc/types/da"
+	"github.com/celestiaorg/celestia-openrpc/types/das"
+	"github.com/celestiaorg/celestia-openrpc/types/fraud"
+	"github.com/celestiaorg/celestia-openrpc/types/header"
+	"github.com/celestiaorg/celestia-openrpc/types/node"
+	"github.com/celestiaorg/celestia-openrpc/types/p2p"
+	"github.com/celestiaorg/celestia-openrpc/types/share"
+	"github.com/celestiaorg/celestia-openrpc/types/state"
+)
+
+// recordMethods fills every method of the module with one recording its name in called.
+func recordMethods(module interface{}, called *string) {
+	v := reflect.ValueOf(module).Elem()
+	for i := 0; i < v.NumField(); i++ {
+		name, fnType := v.Type().Field(i).Name, v.Field(i).Type()
+		v.Field(i).Set(reflect.MakeFunc(fnType, func([]reflect.Value) []reflect.Value {
+			*called = name
+			return errorResults(fnType, nil)
+		}))
+	}
+}
+
+// callMethods calls every method of the module with zero arguments, checking that it ends
+// up at the method of the same name recorded by recordMethods.
+func callMethods(t *testing.T, module interface{}, called *string) {
+	v := reflect.ValueOf(module).Elem()
+	require.Positive(t, v.NumField())
+	for i := 0; i < v.NumField(); i++ {
+		fn := v.Field(i)
+		require.False(t, fn.IsNil(), v.Type().Field(i).Name)
+
+		args := make([]reflect.Value, fn.Type().NumIn())
+		for j := range args {
+			if in := fn.Type().In(j); in == contextType {
+				args[j] = reflect.ValueOf(context.Background())
+			} else {
+				args[j] = reflect.Zero(in)
+			}
+		}
+		*called = ""
+		if fn.Type().IsVariadic() {
+			fn.CallSlice(args)
+		} else {
+			fn.Call(args)
+		}
+		require.Equal(t, v.Type().Field(i).Name, *called)
+	}
+}
+
+func TestModuleInterfaces(t *testing.T) {
+	for name, roundTrip := range map[string]func(called *string) interface{}{
+		"blob": func(called *string) interface{} {
+			var api blob.API
+			recordMethods(&api, called)
+			out := blob.NewAPI(blob.Wrap(&api))
+			return &out
+		},
+		"da": func(called *string) interface{} {
+			var api da.API
+			recordMethods(&api, called)
+			out := da.NewAPI(da.Wrap(&api))
+			return &out
+		},
+		"das": func(called *string) interface{} {
+			var api das.API
+			recordMethods(&api, called)
+			out := das.NewAPI(das.Wrap(&api))
+			return &out
+		},
+		"fraud": func(called *string) interface{} {
+			var api fraud.API
+			recordMethods(&api, called)
+			out := fraud.NewAPI(fraud.Wrap(&api))
+			return &out
+		},
+		"header": func(called *string) interface{} {
+			var api header.API
+			recordMethods(&api, called)
+			out := header.NewAPI(header.Wrap(&api))
+			return &out
+		},
+		"node": func(called *string) interface{} {
+			var api node.API
+			recordMethods(&api, called)
+			out := node.NewAPI(node.Wrap(&api))
+			return &out
+		},
+		"p2p": func(called *string) interface{} {
+			var api p2p.API
+			recordMethods(&api, called)
+			out := p2p.NewAPI(p2p.Wrap(&api))
+			return &out
+		},
+		"share": func(called *string) interface{} {
+			var api share.API
+			recordMethods(&api, called)
+			out := share.NewAPI(share.Wrap(&api))
+			return &out
+		},
+		"state": func(called *string) interface{} {
+			var api state.API
+			recordMethods(&api, called)
+			out := state.NewAPI(state.Wrap(&api))
+			return &out
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			var called string
+			callMethods(t, roundTrip(&called), &called)
+		})
+	}
+}
@@ -0,0 +1,134 @@
+package client
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/gorilla/websocket"
+	"github.com/stretchr/testify/require"
+)
+
+func TestKeepAliveOption(t *testing.T) {
+	cfg := newConfig(WithKeepAlive(5*time.Second, 30*time.Second))
+	require.Equal(t, 5*time.Second, cfg.pingInterval)
+	require.Equal(t, 30*time.Second, cfg.connTimeout)
+	require.Len(t, cfg.rpcOptions(), 2)
+
+	// the timeout is raised above twice the interval, so a single lost pong isn't fatal
+	cfg = newConfig(WithKeepAlive(10*time.Second, time.Second))
+	require.Greater(t, cfg.connTimeout, 20*time.Second)
+}
+
+func TestKeepAliveDisabled(t *testing.T) {
+	cfg := newConfig(WithKeepAlive(0, 0))
+	require.Zero(t, cfg.pingInterval)
+	require.Empty(t, cfg.rpcOptions())
+}
+
+// steppedClock is a Clock whose timers fire when the test sends on fire, while its time
+// only moves when the test advances it.
+type steppedClock struct {
+	fire chan time.Time
+
+	mu  sync.Mutex
+	now time.Time
+}
+
+func (c *steppedClock) Now() time.Time {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.now
+}
+
+func (c *steppedClock) After(time.Duration) <-chan time.Time {
+	return c.fire
+}
+
+func (c *steppedClock) NewTimer(time.Duration) Timer {
+	return chanTimer(c.fire)
+}
+
+func (c *steppedClock) advance(d time.Duration) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.now = c.now.Add(d)
+}
+
+func TestKeepAliveClock(t *testing.T) {
+	pings := make(chan struct{}, 10)
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		conn, err := (&websocket.Upgrader{}).Upgrade(w, r, nil)
+		if err != nil {
+			return
+		}
+		defer conn.Close()
+		// the node went silent and doesn't answer pings
+		conn.SetPingHandler(func(string) error {
+			pings <- struct{}{}
+			return nil
+		})
+		for {
+			if _, _, err := conn.ReadMessage(); err != nil {
+				return
+			}
+		}
+	}))
+	defer srv.Close()
+
+	clock := &steppedClock{fire: make(chan time.Time)}
+	relay, err := newWSRelay("ws"+strings.TrimPrefix(srv.URL, "http"), wsOptions{
+		pingInterval: time.Minute,
+		connTimeout:  3 * time.Minute,
+		clock:        clock,
+	})
+	require.NoError(t, err)
+	defer relay.close()
+	conn, _, err := websocket.DefaultDialer.Dial(relay.addr(), nil)
+	require.NoError(t, err)
+	defer conn.Close()
+
+	pongs, closed := make(chan struct{}, 1), make(chan error, 1)
+	conn.SetPongHandler(func(string) error {
+		pongs <- struct{}{}
+		return nil
+	})
+	go func() {
+		for {
+			if _, _, err := conn.ReadMessage(); err != nil {
+				closed <- err
+				return
+			}
+		}
+	}()
+
+	// the pings of go-jsonrpc are answered by the relay
+	require.NoError(t, conn.WriteControl(websocket.PingMessage, nil, time.Now().Add(time.Second)))
+	select {
+	case <-pongs:
+	case <-time.After(5 * time.Second):
+		t.Fatal("ping not answered")
+	}
+
+	// the relay pings the node itself, as timed by the clock
+	clock.advance(time.Minute)
+	clock.fire <- clock.Now()
+	select {
+	case <-pings:
+	case <-time.After(5 * time.Second):
+		t.Fatal("node not pinged")
+	}
+
+	// and closes the connection once the node was silent for the timeout
+	clock.advance(3 * time.Minute)
+	clock.fire <- clock.Now()
+	select {
+	case err := <-closed:
+		require.Error(t, err)
+	case <-time.After(5 * time.Second):
+		t.Fatal("connection not closed")
+	}
+}
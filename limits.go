@@ -0,0 +1,80 @@
+package client
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+
+	"github.com/celestiaorg/celestia-openrpc/types/blob"
+)
+
+var (
+	// ErrRequestTooLarge is returned for requests exceeding the size set with WithMaxRequestSize.
+	ErrRequestTooLarge = errors.New("request exceeds the maximum size")
+	// ErrResponseTooLarge is returned for responses exceeding the size set with WithMaxResponseSize.
+	ErrResponseTooLarge = errors.New("response exceeds the maximum size")
+)
+
+// limitedRoundTripper rejects requests and responses exceeding the maximum sizes.
+// Zero disables the respective limit.
+type limitedRoundTripper struct {
+	next            http.RoundTripper
+	maxRequestSize  int64
+	maxResponseSize int64
+}
+
+func (rt *limitedRoundTripper) RoundTrip(req *http.Request) (*http.Response, error) {
+	if rt.maxRequestSize > 0 && req.ContentLength > rt.maxRequestSize {
+		if req.Body != nil {
+			req.Body.Close()
+		}
+		return nil, fmt.Errorf("%w: %d > %d bytes", ErrRequestTooLarge, req.ContentLength, rt.maxRequestSize)
+	}
+
+	resp, err := rt.next.RoundTrip(req)
+	if err != nil || rt.maxResponseSize <= 0 {
+		return resp, err
+	}
+	if resp.ContentLength > rt.maxResponseSize {
+		resp.Body.Close()
+		return nil, fmt.Errorf("%w: %d > %d bytes", ErrResponseTooLarge, resp.ContentLength, rt.maxResponseSize)
+	}
+	resp.Body = &limitedBody{ReadCloser: resp.Body, remaining: rt.maxResponseSize, max: rt.maxResponseSize}
+	return resp, nil
+}
+
+// limitedBody fails reads once more than max bytes were read from the body.
+type limitedBody struct {
+	io.ReadCloser
+	remaining int64
+	max       int64
+}
+
+func (b *limitedBody) Read(p []byte) (int, error) {
+	if b.remaining < 0 {
+		return 0, fmt.Errorf("%w: more than %d bytes", ErrResponseTooLarge, b.max)
+	}
+	// read one byte past the limit to tell responses of exactly max bytes apart
+	if int64(len(p)) > b.remaining+1 {
+		p = p[:b.remaining+1]
+	}
+	n, err := b.ReadCloser.Read(p)
+	b.remaining -= int64(n)
+	if b.remaining < 0 {
+		return n, fmt.Errorf("%w: more than %d bytes", ErrResponseTooLarge, b.max)
+	}
+	return n, err
+}
+
+// Limits returns the limits enforced by the network on submissions, as of the app version
+// of the head of the node. The node doesn't expose the governance parameters, so the
+// default maximum square size is assumed; consensus.Client.Limits queries it instead.
+func (c *Client) Limits(ctx context.Context) (blob.Limits, error) {
+	head, err := c.Header.LocalHead(ctx)
+	if err != nil {
+		return blob.Limits{}, fmt.Errorf("getting the head: %w", err)
+	}
+	return blob.DefaultLimits(head.Version.App), nil
+}
@@ -0,0 +1,144 @@
+package client
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/celestiaorg/celestia-openrpc/mocks"
+	"github.com/celestiaorg/celestia-openrpc/rpctest"
+	"github.com/celestiaorg/celestia-openrpc/types/appconsts"
+	"github.com/celestiaorg/celestia-openrpc/types/blob"
+	"github.com/celestiaorg/celestia-openrpc/types/header"
+	"github.com/celestiaorg/celestia-openrpc/types/share"
+)
+
+func TestLimitedBody(t *testing.T) {
+	body := &limitedBody{ReadCloser: io.NopCloser(strings.NewReader("12345")), remaining: 5, max: 5}
+	b, err := io.ReadAll(body)
+	require.NoError(t, err)
+	require.Equal(t, "12345", string(b))
+
+	body = &limitedBody{ReadCloser: io.NopCloser(strings.NewReader("123456")), remaining: 5, max: 5}
+	_, err = io.ReadAll(body)
+	require.ErrorIs(t, err, ErrResponseTooLarge)
+}
+
+func TestLimitedRoundTripper(t *testing.T) {
+	rt := &limitedRoundTripper{
+		next: roundTripperFunc(func(*http.Request) (*http.Response, error) {
+			return &http.Response{StatusCode: http.StatusOK, ContentLength: -1, Body: io.NopCloser(strings.NewReader("0123456789"))}, nil
+		}),
+		maxRequestSize:  4,
+		maxResponseSize: 8,
+	}
+
+	req, err := http.NewRequest(http.MethodPost, "http://localhost", bytes.NewReader([]byte("12345")))
+	require.NoError(t, err)
+	_, err = rt.RoundTrip(req)
+	require.ErrorIs(t, err, ErrRequestTooLarge)
+
+	req, err = http.NewRequest(http.MethodPost, "http://localhost", bytes.NewReader([]byte("1234")))
+	require.NoError(t, err)
+	resp, err := rt.RoundTrip(req)
+	require.NoError(t, err)
+	_, err = io.ReadAll(resp.Body)
+	require.ErrorIs(t, err, ErrResponseTooLarge)
+}
+
+func TestSizeLimits(t *testing.T) {
+	srv := testNode(t, func(_ *http.Request, method string, _ json.RawMessage) (interface{}, error) {
+		switch method {
+		case "node.Ready":
+			return true, nil
+		case "p2p.Peers":
+			return []string{strings.Repeat("a", 1024)}, nil
+		default:
+			return nil, errors.New("method not found")
+		}
+	})
+
+	rt := &countingRoundTripper{}
+	c, err := NewClient(context.Background(), srv.URL, "",
+		WithMaxResponseSize(512), WithTransport(HTTPTransport{Client: &http.Client{Transport: rt}}))
+	require.NoError(t, err)
+	defer c.Close()
+
+	_, err = c.Node.Ready(context.Background())
+	require.NoError(t, err)
+	_, err = c.P2P.Peers(context.Background())
+	require.ErrorIs(t, err, ErrResponseTooLarge)
+	// the limits wrap the client of the transport
+	require.Positive(t, rt.requests)
+}
+
+func TestSizeLimitsWebSocket(t *testing.T) {
+	srv := rpctest.NewServer()
+	defer srv.Close()
+	small, large := testBlob(t, "small"), testBlob(t, strings.Repeat("l", 4096))
+	smallHeight, err := srv.Blobs.API().Submit(context.Background(), []*blob.Blob{small}, nil)
+	require.NoError(t, err)
+	largeHeight, err := srv.Blobs.API().Submit(context.Background(), []*blob.Blob{large}, nil)
+	require.NoError(t, err)
+
+	c, err := NewClient(context.Background(), srv.WebSocketURL(), "", WithMaxResponseSize(2048))
+	require.NoError(t, err)
+	defer c.Close()
+
+	namespace := share.Namespace(small.Namespace().Bytes())
+	got, err := c.Blob.Get(context.Background(), smallHeight, namespace, small.Commitment)
+	require.NoError(t, err)
+	require.True(t, small.Equal(got))
+	_, err = c.Blob.Get(context.Background(), largeHeight, namespace, large.Commitment)
+	require.Error(t, err)
+
+	// the connection is re-established for the following calls
+	require.Eventually(t, func() bool {
+		_, err := c.Blob.Get(context.Background(), smallHeight, namespace, small.Commitment)
+		return err == nil
+	}, 5*time.Second, 50*time.Millisecond)
+}
+
+func TestClientLimits(t *testing.T) {
+	c := &Client{}
+	c.Header.LocalHead = func(context.Context) (*header.ExtendedHeader, error) {
+		eh := mocks.NewHeader(7)
+		eh.Version.App = appconsts.LatestVersion
+		return eh, nil
+	}
+	limits, err := c.Limits(context.Background())
+	require.NoError(t, err)
+	require.Equal(t, blob.Limits{
+		AppVersion:    appconsts.LatestVersion,
+		MaxSquareSize: appconsts.DefaultGovMaxSquareSize,
+		MaxTxSize:     appconsts.DefaultMaxTxSize,
+		MaxBlobSize:   appconsts.DefaultMaxBytes,
+	}, limits)
+
+	// governance can't raise the square size beyond the bound of the app version
+	limits = blob.NewLimits(appconsts.LatestVersion, 1024)
+	require.Equal(t, appconsts.SquareSizeUpperBound(appconsts.LatestVersion), limits.MaxSquareSize)
+	require.Equal(t, appconsts.DefaultMaxTxSize-appconsts.BytesPerBlobInfo, limits.MaxBlobSize)
+}
+
+func TestLimitsValidateBlobs(t *testing.T) {
+	limits := blob.NewLimits(appconsts.LatestVersion, 2)
+	require.NoError(t, limits.ValidateBlobs(testBlob(t, "a"), testBlob(t, strings.Repeat("b", 1000))))
+
+	err := limits.ValidateBlobs(testBlob(t, strings.Repeat("a", 2000)))
+	require.ErrorIs(t, err, blob.ErrInvalidSize)
+
+	// the blobs fit on their own, but not together
+	big := strings.Repeat("a", 1000)
+	err = limits.ValidateBlobs(testBlob(t, big), testBlob(t, big))
+	require.ErrorIs(t, err, blob.ErrInvalidSize)
+	require.ErrorContains(t, err, "shares")
+}
@@ -0,0 +1,71 @@
+package client
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"log/slog"
+	"net/http"
+	"sync"
+	"syscall"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+// syncBuffer is a bytes.Buffer safe for concurrent use.
+type syncBuffer struct {
+	mu  sync.Mutex
+	buf bytes.Buffer
+}
+
+func (b *syncBuffer) Write(p []byte) (int, error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return b.buf.Write(p)
+}
+
+func (b *syncBuffer) String() string {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return b.buf.String()
+}
+
+func TestLogger(t *testing.T) {
+	srv := testNode(t, func(_ *http.Request, method string, _ json.RawMessage) (interface{}, error) {
+		if method != "node.Ready" {
+			return nil, errors.New("method not found")
+		}
+		time.Sleep(20 * time.Millisecond)
+		return true, nil
+	})
+
+	var buf syncBuffer
+	logger := slog.New(slog.NewTextHandler(&buf, &slog.HandlerOptions{Level: slog.LevelDebug}))
+	c, err := NewClient(context.Background(), srv.URL, "",
+		WithLogger(logger), WithSlowCallThreshold(time.Millisecond))
+	require.NoError(t, err)
+	require.Contains(t, buf.String(), "connected to node")
+	require.Contains(t, buf.String(), "skipping API version check")
+
+	_, err = c.Node.Ready(context.Background())
+	require.NoError(t, err)
+	require.Contains(t, buf.String(), "slow call")
+	require.Contains(t, buf.String(), "method=node.Ready")
+
+	c.Close()
+	require.Contains(t, buf.String(), "client closed")
+}
+
+func TestLoggerRetries(t *testing.T) {
+	var buf syncBuffer
+	intercept := retry(map[string]RetryPolicy{"": fastRetry}, nil, slog.New(slog.NewTextHandler(&buf, nil)), realClock{})
+	next, _ := failingInvoker(1, syscall.ECONNRESET)
+
+	_, err := intercept(context.Background(), &call{module: "header", method: "GetByHeight", perm: "read"}, next)
+	require.NoError(t, err)
+	require.Contains(t, buf.String(), "retrying call")
+	require.Contains(t, buf.String(), "attempt=1")
+}
@@ -0,0 +1,398 @@
+package client
+
+import (
+	"context"
+	"errors"
+	"math"
+	"sync"
+	"time"
+
+	"github.com/celestiaorg/celestia-openrpc/types/blob"
+	"github.com/celestiaorg/celestia-openrpc/types/share"
+	"github.com/celestiaorg/celestia-openrpc/types/state"
+)
+
+// headerLookupTimeout bounds the time spent fetching the inclusion header
+// to compute the time-to-inclusion of a submission.
+const headerLookupTimeout = 5 * time.Second
+
+// MetricsHook receives observations about submissions made through the client.
+// Implementations must be safe for concurrent use.
+type MetricsHook interface {
+	ObserveSubmit(SubmitObservation)
+}
+
+// RetrievalHook is implemented by metrics hooks which also receive observations about
+// the blobs retrieved through the client. Implementations must be safe for concurrent use.
+type RetrievalHook interface {
+	ObserveRetrieval(RetrievalObservation)
+}
+
+// RetrievalObservation describes a single retrieval of blobs made through the client.
+type RetrievalObservation struct {
+	// Method is the name of the method used for the retrieval, e.g. "blob.GetAll".
+	Method string
+	// Height is the height the blobs were retrieved at.
+	Height uint64
+	// Namespaces are the namespaces the blobs were requested for.
+	Namespaces []share.Namespace
+	// Blobs describes every retrieved blob.
+	Blobs []BlobObservation
+	// Err is the error returned by the retrieval, if any. Blobs not being found isn't an error.
+	Err error
+}
+
+// SubmitObservation describes a single submission made through the client.
+type SubmitObservation struct {
+	// Method is the name of the method used for the submission,
+	// e.g. "blob.Submit" or "state.SubmitPayForBlob".
+	Method string
+	// Blobs describes every blob that was part of the submission.
+	Blobs []BlobObservation
+	// Height is the height the blobs were included at. Zero if the submission failed.
+	Height uint64
+	// TxHash is the hash of the PayForBlobs transaction, if reported by the node.
+	TxHash string
+	// TimeToInclusion is the time passed between the start of the submission and
+	// the timestamp of the block the blobs were included in. Falls back to the
+	// ConfirmationLatency if the inclusion header could not be retrieved.
+	TimeToInclusion time.Duration
+	// ConfirmationLatency is the time passed between the start of the submission
+	// and the node confirming the inclusion back to the client.
+	ConfirmationLatency time.Duration
+	// GasEstimated is the gas limit requested for the transaction, if known.
+	GasEstimated uint64
+	// GasUsed is the gas consumed by the transaction, if reported by the node.
+	GasUsed uint64
+	// Fee is the fee paid for the transaction in utia, if known.
+	Fee uint64
+	// Err is the error returned by the submission, if any.
+	Err error
+}
+
+// BlobObservation describes a single blob that was part of a submission.
+type BlobObservation struct {
+	Namespace  share.Namespace
+	Commitment blob.Commitment
+	Size       int
+}
+
+// LatencyStats aggregates a series of durations.
+type LatencyStats struct {
+	Count uint64
+	Total time.Duration
+	Min   time.Duration
+	Max   time.Duration
+}
+
+// Mean returns the average of all observed durations.
+func (l LatencyStats) Mean() time.Duration {
+	if l.Count == 0 {
+		return 0
+	}
+	//nolint:gosec
+	return l.Total / time.Duration(l.Count)
+}
+
+func (l *LatencyStats) observe(d time.Duration) {
+	if l.Count == 0 || d < l.Min {
+		l.Min = d
+	}
+	if d > l.Max {
+		l.Max = d
+	}
+	l.Count++
+	l.Total += d
+}
+
+// SubmitStats is a snapshot of the aggregated submission metrics.
+type SubmitStats struct {
+	// Submissions is the total number of submissions, including failed ones.
+	Submissions uint64
+	// Failures is the number of submissions that returned an error.
+	Failures uint64
+	// Blobs is the number of successfully submitted blobs.
+	Blobs uint64
+	// Bytes is the total size of successfully submitted blob data.
+	Bytes uint64
+	// LastHeight is the most recent inclusion height.
+	LastHeight uint64
+
+	TimeToInclusion     LatencyStats
+	ConfirmationLatency LatencyStats
+
+	GasEstimated uint64
+	GasUsed      uint64
+	FeePaid      uint64
+}
+
+// NamespaceStats is a snapshot of the metrics of the blobs of a namespace, which
+// attributes the costs of data availability to the rollup using the namespace.
+type NamespaceStats struct {
+	// BlobsSubmitted and BytesSubmitted count the successfully submitted blobs and their data.
+	BlobsSubmitted uint64
+	BytesSubmitted uint64
+	// FeePaid is the share of the fees of the submissions paid for the namespace, split
+	// between the namespaces of a submission in proportion to the size of their blobs.
+	FeePaid uint64
+	// SubmitFailures is the number of failed submissions holding blobs of the namespace.
+	SubmitFailures uint64
+	// BlobsRetrieved and BytesRetrieved count the retrieved blobs and their data.
+	BlobsRetrieved uint64
+	BytesRetrieved uint64
+	// RetrievalFailures is the number of failed retrievals requesting the namespace.
+	RetrievalFailures uint64
+}
+
+// SubmitSummary is an in-memory MetricsHook which aggregates submission
+// observations into a queryable summary. It implements RetrievalHook to
+// aggregate the retrievals of every namespace as well.
+type SubmitSummary struct {
+	mu         sync.Mutex
+	stats      SubmitStats
+	namespaces map[string]*NamespaceStats
+}
+
+// NewSubmitSummary creates an empty SubmitSummary.
+func NewSubmitSummary() *SubmitSummary {
+	return &SubmitSummary{namespaces: make(map[string]*NamespaceStats)}
+}
+
+// ObserveSubmit implements MetricsHook.
+func (s *SubmitSummary) ObserveSubmit(obs SubmitObservation) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.stats.Submissions++
+	if obs.Err != nil {
+		s.stats.Failures++
+		seen := make(map[string]bool)
+		for _, b := range obs.Blobs {
+			if ns := b.Namespace.String(); !seen[ns] {
+				seen[ns] = true
+				s.namespace(ns).SubmitFailures++
+			}
+		}
+		return
+	}
+
+	var total uint64
+	for _, b := range obs.Blobs {
+		s.stats.Blobs++
+		//nolint:gosec
+		s.stats.Bytes += uint64(b.Size)
+		//nolint:gosec
+		total += uint64(b.Size)
+	}
+	for _, b := range obs.Blobs {
+		ns := s.namespace(b.Namespace.String())
+		ns.BlobsSubmitted++
+		//nolint:gosec
+		ns.BytesSubmitted += uint64(b.Size)
+		if total > 0 {
+			//nolint:gosec
+			ns.FeePaid += obs.Fee * uint64(b.Size) / total
+		}
+	}
+	if obs.Height > s.stats.LastHeight {
+		s.stats.LastHeight = obs.Height
+	}
+	s.stats.TimeToInclusion.observe(obs.TimeToInclusion)
+	s.stats.ConfirmationLatency.observe(obs.ConfirmationLatency)
+	s.stats.GasEstimated += obs.GasEstimated
+	s.stats.GasUsed += obs.GasUsed
+	s.stats.FeePaid += obs.Fee
+}
+
+// ObserveRetrieval implements RetrievalHook.
+func (s *SubmitSummary) ObserveRetrieval(obs RetrievalObservation) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if obs.Err != nil {
+		for _, namespace := range obs.Namespaces {
+			s.namespace(namespace.String()).RetrievalFailures++
+		}
+		return
+	}
+	for _, b := range obs.Blobs {
+		ns := s.namespace(b.Namespace.String())
+		ns.BlobsRetrieved++
+		//nolint:gosec
+		ns.BytesRetrieved += uint64(b.Size)
+	}
+}
+
+// Stats returns a snapshot of the aggregated metrics.
+func (s *SubmitSummary) Stats() SubmitStats {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.stats
+}
+
+// NamespaceStats returns a snapshot of the aggregated metrics of every namespace
+// observed so far, keyed by the string representation of the namespace.
+func (s *SubmitSummary) NamespaceStats() map[string]NamespaceStats {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	stats := make(map[string]NamespaceStats, len(s.namespaces))
+	for ns, st := range s.namespaces {
+		stats[ns] = *st
+	}
+	return stats
+}
+
+// namespace returns the metrics of the namespace, creating them if needed.
+// Must be called with the lock held.
+func (s *SubmitSummary) namespace(ns string) *NamespaceStats {
+	st, ok := s.namespaces[ns]
+	if !ok {
+		st = &NamespaceStats{}
+		s.namespaces[ns] = st
+	}
+	return st
+}
+
+// instrumentSubmit wraps the submission methods of the client to report
+// observations to the given hook.
+func instrumentSubmit(c *Client, hook MetricsHook) {
+	submit := c.Blob.Submit
+	c.Blob.Submit = func(ctx context.Context, blobs []*blob.Blob, opts *blob.SubmitOptions) (uint64, error) {
+		start := time.Now()
+		height, err := submit(ctx, blobs, opts)
+		obs := SubmitObservation{
+			Method:              "blob.Submit",
+			Blobs:               observeBlobs(blobs),
+			Height:              height,
+			ConfirmationLatency: time.Since(start),
+			Err:                 err,
+		}
+		if opts != nil {
+			obs.GasEstimated = opts.GasLimit()
+			obs.Fee = fee(opts.GasPrice(), opts.GasLimit())
+		}
+		c.reportSubmit(hook, start, obs)
+		return height, err
+	}
+
+	submitPFB := c.State.SubmitPayForBlob
+	c.State.SubmitPayForBlob = func(
+		ctx context.Context,
+		blobs []*blob.Blob,
+		config *state.TxConfig,
+	) (*state.TxResponse, error) {
+		start := time.Now()
+		resp, err := submitPFB(ctx, blobs, config)
+		obs := SubmitObservation{
+			Method:              "state.SubmitPayForBlob",
+			Blobs:               observeBlobs(blobs),
+			ConfirmationLatency: time.Since(start),
+			Err:                 err,
+		}
+		if resp != nil {
+			//nolint:gosec
+			obs.Height = uint64(resp.Height)
+			obs.TxHash = resp.TxHash
+			//nolint:gosec
+			obs.GasEstimated = uint64(resp.GasWanted)
+			//nolint:gosec
+			obs.GasUsed = uint64(resp.GasUsed)
+			if config != nil {
+				obs.Fee = fee(config.GasPrice(), obs.GasEstimated)
+			}
+		}
+		c.reportSubmit(hook, start, obs)
+		return resp, err
+	}
+}
+
+// observeRetrievals wraps the blob retrieval methods of the client to report
+// observations to the given hook.
+func observeRetrievals(c *Client, hook RetrievalHook) {
+	get := c.Blob.Get
+	c.Blob.Get = func(
+		ctx context.Context,
+		height uint64,
+		namespace share.Namespace,
+		commitment blob.Commitment,
+	) (*blob.Blob, error) {
+		b, err := get(ctx, height, namespace, commitment)
+		obs := RetrievalObservation{
+			Method:     "blob.Get",
+			Height:     height,
+			Namespaces: []share.Namespace{namespace},
+			Err:        retrievalErr(err),
+		}
+		if err == nil {
+			obs.Blobs = observeBlobs([]*blob.Blob{b})
+		}
+		hook.ObserveRetrieval(obs)
+		return b, err
+	}
+
+	getAll := c.Blob.GetAll
+	c.Blob.GetAll = func(ctx context.Context, height uint64, namespaces []share.Namespace) ([]*blob.Blob, error) {
+		blobs, err := getAll(ctx, height, namespaces)
+		hook.ObserveRetrieval(RetrievalObservation{
+			Method:     "blob.GetAll",
+			Height:     height,
+			Namespaces: namespaces,
+			Blobs:      observeBlobs(blobs),
+			Err:        retrievalErr(err),
+		})
+		return blobs, err
+	}
+}
+
+// retrievalErr returns the error of a retrieval, ignoring blobs not being found.
+func retrievalErr(err error) error {
+	if errors.Is(err, blob.ErrBlobNotFound) {
+		return nil
+	}
+	return err
+}
+
+// reportSubmit resolves the time-to-inclusion of the observation and passes it to the hook.
+// The inclusion header is fetched asynchronously so the submission itself is not delayed.
+func (c *Client) reportSubmit(hook MetricsHook, start time.Time, obs SubmitObservation) {
+	obs.TimeToInclusion = obs.ConfirmationLatency
+	if obs.Err != nil || obs.Height == 0 || c.Header.GetByHeight == nil {
+		hook.ObserveSubmit(obs)
+		return
+	}
+
+	go func() {
+		ctx, cancel := context.WithTimeout(context.Background(), headerLookupTimeout)
+		defer cancel()
+
+		eh, err := c.Header.GetByHeight(ctx, obs.Height)
+		if err == nil && eh != nil {
+			obs.TimeToInclusion = max(eh.Time().Sub(start), 0)
+		}
+		hook.ObserveSubmit(obs)
+	}()
+}
+
+func observeBlobs(blobs []*blob.Blob) []BlobObservation {
+	obs := make([]BlobObservation, 0, len(blobs))
+	for _, b := range blobs {
+		if b == nil {
+			continue
+		}
+		obs = append(obs, BlobObservation{
+			Namespace:  share.Namespace(b.Namespace().Bytes()),
+			Commitment: b.Commitment,
+			Size:       len(b.Data),
+		})
+	}
+	return obs
+}
+
+// fee returns the fee in utia paid for the given gas price and gas limit.
+// Returns zero if either of them is unknown.
+func fee(gasPrice float64, gas uint64) uint64 {
+	if gasPrice < 0 || gas == 0 {
+		return 0
+	}
+	return uint64(math.Ceil(gasPrice * float64(gas)))
+}
@@ -0,0 +1,162 @@
+package client
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/celestiaorg/celestia-openrpc/types/blob"
+	"github.com/celestiaorg/celestia-openrpc/types/header"
+	"github.com/celestiaorg/celestia-openrpc/types/share"
+)
+
+// hookFunc adapts a function to the MetricsHook interface.
+type hookFunc func(SubmitObservation)
+
+func (f hookFunc) ObserveSubmit(obs SubmitObservation) {
+	f(obs)
+}
+
+func testBlob(t *testing.T, data string) *blob.Blob {
+	t.Helper()
+	namespace, err := share.NewBlobNamespaceV0([]byte{1, 2, 3, 4})
+	require.NoError(t, err)
+	b, err := blob.NewBlobV0(namespace, []byte(data))
+	require.NoError(t, err)
+	return b
+}
+
+func TestSubmitSummary(t *testing.T) {
+	summary := NewSubmitSummary()
+	summary.ObserveSubmit(SubmitObservation{
+		Blobs:               []BlobObservation{{Size: 10}, {Size: 20}},
+		Height:              7,
+		TimeToInclusion:     3 * time.Second,
+		ConfirmationLatency: time.Second,
+		GasEstimated:        1000,
+		GasUsed:             800,
+		Fee:                 2,
+	})
+	summary.ObserveSubmit(SubmitObservation{
+		Blobs:               []BlobObservation{{Size: 5}},
+		Height:              5,
+		TimeToInclusion:     time.Second,
+		ConfirmationLatency: 3 * time.Second,
+	})
+	summary.ObserveSubmit(SubmitObservation{Err: errors.New("failed")})
+
+	stats := summary.Stats()
+	require.EqualValues(t, 3, stats.Submissions)
+	require.EqualValues(t, 1, stats.Failures)
+	require.EqualValues(t, 3, stats.Blobs)
+	require.EqualValues(t, 35, stats.Bytes)
+	require.EqualValues(t, 7, stats.LastHeight)
+	require.EqualValues(t, 1000, stats.GasEstimated)
+	require.EqualValues(t, 800, stats.GasUsed)
+	require.EqualValues(t, 2, stats.FeePaid)
+	require.Equal(t, time.Second, stats.TimeToInclusion.Min)
+	require.Equal(t, 3*time.Second, stats.TimeToInclusion.Max)
+	require.Equal(t, 2*time.Second, stats.ConfirmationLatency.Mean())
+}
+
+func TestNamespaceStats(t *testing.T) {
+	rollup := testBlob(t, "rollup")
+	otherNs, err := share.NewBlobNamespaceV0([]byte{9, 9})
+	require.NoError(t, err)
+	other, err := blob.NewBlobV0(otherNs, []byte("other"))
+	require.NoError(t, err)
+
+	summary := NewSubmitSummary()
+	c := &Client{}
+	c.Blob.GetAll = func(_ context.Context, height uint64, _ []share.Namespace) ([]*blob.Blob, error) {
+		if height == 2 {
+			return nil, errors.New("unavailable")
+		}
+		return []*blob.Blob{rollup, other}, nil
+	}
+	observeRetrievals(c, summary)
+
+	summary.ObserveSubmit(SubmitObservation{Blobs: observeBlobs([]*blob.Blob{rollup, rollup, other}), Height: 1, Fee: 170})
+	summary.ObserveSubmit(SubmitObservation{Blobs: observeBlobs([]*blob.Blob{other}), Err: errors.New("failed")})
+	namespaces := []share.Namespace{share.Namespace(rollup.Namespace().Bytes()), otherNs}
+	_, err = c.Blob.GetAll(context.Background(), 1, namespaces)
+	require.NoError(t, err)
+	_, err = c.Blob.GetAll(context.Background(), 2, namespaces)
+	require.Error(t, err)
+
+	stats := summary.NamespaceStats()
+	require.Len(t, stats, 2)
+	require.Equal(t, NamespaceStats{
+		BlobsSubmitted:    2,
+		BytesSubmitted:    12,
+		FeePaid:           120,
+		BlobsRetrieved:    1,
+		BytesRetrieved:    6,
+		RetrievalFailures: 1,
+	}, stats[namespaces[0].String()])
+	require.Equal(t, NamespaceStats{
+		BlobsSubmitted:    1,
+		BytesSubmitted:    5,
+		FeePaid:           50,
+		SubmitFailures:    1,
+		BlobsRetrieved:    1,
+		BytesRetrieved:    5,
+		RetrievalFailures: 1,
+	}, stats[otherNs.String()])
+}
+
+func TestInstrumentSubmit(t *testing.T) {
+	start := time.Now()
+	c := &Client{}
+	c.Blob.Submit = func(context.Context, []*blob.Blob, *blob.SubmitOptions) (uint64, error) {
+		return 42, nil
+	}
+	c.Header.GetByHeight = func(_ context.Context, height uint64) (*header.ExtendedHeader, error) {
+		require.EqualValues(t, 42, height)
+		return &header.ExtendedHeader{RawHeader: header.RawHeader{Time: start.Add(time.Hour)}}, nil
+	}
+
+	observed := make(chan SubmitObservation, 1)
+	instrumentSubmit(c, hookFunc(func(obs SubmitObservation) { observed <- obs }))
+
+	b := testBlob(t, "hello")
+	height, err := c.Blob.Submit(context.Background(), []*blob.Blob{b}, blob.NewSubmitOptions(blob.WithGasPrice(0.002)))
+	require.NoError(t, err)
+	require.EqualValues(t, 42, height)
+
+	obs := <-observed
+	require.Equal(t, "blob.Submit", obs.Method)
+	require.EqualValues(t, 42, obs.Height)
+	require.Len(t, obs.Blobs, 1)
+	require.Equal(t, len("hello"), obs.Blobs[0].Size)
+	require.Equal(t, b.Commitment, obs.Blobs[0].Commitment)
+	// the inclusion time is taken from the header rather than the confirmation
+	require.Greater(t, obs.TimeToInclusion, 59*time.Minute)
+}
+
+func TestInstrumentSubmitFailure(t *testing.T) {
+	submitErr := errors.New("out of gas")
+	c := &Client{}
+	c.Blob.Submit = func(context.Context, []*blob.Blob, *blob.SubmitOptions) (uint64, error) {
+		return 0, submitErr
+	}
+
+	var obs SubmitObservation
+	instrumentSubmit(c, hookFunc(func(o SubmitObservation) { obs = o }))
+
+	_, err := c.Blob.Submit(context.Background(), []*blob.Blob{testBlob(t, "hello")}, nil)
+	require.ErrorIs(t, err, submitErr)
+	// failures are reported synchronously, without looking up the header
+	require.ErrorIs(t, obs.Err, submitErr)
+	require.Zero(t, obs.Height)
+}
+
+func TestFee(t *testing.T) {
+	require.EqualValues(t, 200, fee(0.002, 100_000))
+	require.EqualValues(t, 1, fee(0.0001, 1))
+	require.Zero(t, fee(-1, 100_000))
+	require.Zero(t, fee(0.002, 0))
+}
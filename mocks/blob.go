@@ -0,0 +1,139 @@
+package mocks
+
+import (
+	"bytes"
+	"context"
+	"sync"
+
+	"github.com/celestiaorg/celestia-openrpc/types/blob"
+	"github.com/celestiaorg/celestia-openrpc/types/share"
+)
+
+// BlobStore is an in-memory fake of the blob module. Every submission is included
+// at the next height. Subscribers falling behind by more than 64 responses miss the
+// following ones instead of blocking submissions.
+type BlobStore struct {
+	mu     sync.Mutex
+	height uint64
+	blobs  map[uint64][]*blob.Blob
+	subs   []*blobSubscription
+}
+
+type blobSubscription struct {
+	*subscription[*blob.SubscriptionResponse]
+	namespace share.Namespace
+}
+
+// NewBlobStore returns an empty BlobStore.
+func NewBlobStore() *BlobStore {
+	return &BlobStore{blobs: make(map[uint64][]*blob.Blob)}
+}
+
+// Height returns the height of the latest submission.
+func (s *BlobStore) Height() uint64 {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.height
+}
+
+// API returns the blob module backed by the store. Proofs aren't supported
+// and the methods returning them fail with ErrNotMocked.
+func (s *BlobStore) API() *blob.API {
+	return Stub(&blob.API{
+		Submit:    s.submit,
+		Get:       s.get,
+		GetAll:    s.getAll,
+		Included:  s.included,
+		Subscribe: s.subscribe,
+	})
+}
+
+func (s *BlobStore) submit(_ context.Context, blobs []*blob.Blob, _ *blob.SubmitOptions) (uint64, error) {
+	s.mu.Lock()
+	s.height++
+	height := s.height
+	s.blobs[height] = append([]*blob.Blob(nil), blobs...)
+	subs := s.subs
+	s.mu.Unlock()
+
+	for _, sub := range subs {
+		resp := &blob.SubscriptionResponse{Height: height}
+		for _, b := range blobs {
+			if namespaceOf(b).Equals(sub.namespace) {
+				resp.Blobs = append(resp.Blobs, b)
+			}
+		}
+		sub.send(resp)
+	}
+	return height, nil
+}
+
+func (s *BlobStore) get(
+	_ context.Context,
+	height uint64,
+	namespace share.Namespace,
+	commitment blob.Commitment,
+) (*blob.Blob, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	for _, b := range s.blobs[height] {
+		if namespaceOf(b).Equals(namespace) && bytes.Equal(b.Commitment, commitment) {
+			return b, nil
+		}
+	}
+	return nil, blob.ErrBlobNotFound
+}
+
+func (s *BlobStore) getAll(_ context.Context, height uint64, namespaces []share.Namespace) ([]*blob.Blob, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	var blobs []*blob.Blob
+	for _, b := range s.blobs[height] {
+		for _, namespace := range namespaces {
+			if namespaceOf(b).Equals(namespace) {
+				blobs = append(blobs, b)
+				break
+			}
+		}
+	}
+	if len(blobs) == 0 {
+		return nil, blob.ErrBlobNotFound
+	}
+	return blobs, nil
+}
+
+func (s *BlobStore) included(
+	ctx context.Context,
+	height uint64,
+	namespace share.Namespace,
+	_ *blob.Proof,
+	commitment blob.Commitment,
+) (bool, error) {
+	_, err := s.get(ctx, height, namespace, commitment)
+	return err == nil, nil
+}
+
+func (s *BlobStore) subscribe(ctx context.Context, namespace share.Namespace) (<-chan *blob.SubscriptionResponse, error) {
+	sub := &blobSubscription{subscription: newSubscription[*blob.SubscriptionResponse](), namespace: namespace}
+	s.mu.Lock()
+	s.subs = append(s.subs, sub)
+	s.mu.Unlock()
+
+	go func() {
+		<-ctx.Done()
+		s.mu.Lock()
+		for i, other := range s.subs {
+			if other == sub {
+				s.subs = append(s.subs[:i:i], s.subs[i+1:]...)
+				break
+			}
+		}
+		s.mu.Unlock()
+		sub.close()
+	}()
+	return sub.ch, nil
+}
+
+func namespaceOf(b *blob.Blob) share.Namespace {
+	return share.Namespace(b.Namespace().Bytes())
+}
@@ -0,0 +1,121 @@
+package mocks
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/celestiaorg/celestia-openrpc/types/blob"
+	"github.com/celestiaorg/celestia-openrpc/types/header"
+	"github.com/celestiaorg/celestia-openrpc/types/share"
+)
+
+func testBlob(t *testing.T, id byte, data string) (*blob.Blob, share.Namespace) {
+	t.Helper()
+	namespace, err := share.NewBlobNamespaceV0([]byte{id})
+	require.NoError(t, err)
+	b, err := blob.NewBlobV0(namespace, []byte(data))
+	require.NoError(t, err)
+	return b, namespace
+}
+
+func TestBlobStore(t *testing.T) {
+	ctx := context.Background()
+	store := NewBlobStore()
+	api := store.API()
+
+	b1, ns1 := testBlob(t, 1, "first")
+	b2, ns2 := testBlob(t, 2, "second")
+	height, err := api.Submit(ctx, []*blob.Blob{b1, b2}, nil)
+	require.NoError(t, err)
+	require.EqualValues(t, 1, height)
+	require.EqualValues(t, 1, store.Height())
+
+	got, err := api.Get(ctx, height, ns1, b1.Commitment)
+	require.NoError(t, err)
+	require.Equal(t, b1, got)
+	_, err = api.Get(ctx, height, ns2, b1.Commitment)
+	require.ErrorIs(t, err, blob.ErrBlobNotFound)
+
+	all, err := api.GetAll(ctx, height, []share.Namespace{ns2})
+	require.NoError(t, err)
+	require.Equal(t, []*blob.Blob{b2}, all)
+	_, err = api.GetAll(ctx, height+1, []share.Namespace{ns2})
+	require.ErrorIs(t, err, blob.ErrBlobNotFound)
+
+	included, err := api.Included(ctx, height, ns1, nil, b1.Commitment)
+	require.NoError(t, err)
+	require.True(t, included)
+
+	_, err = api.GetProof(ctx, height, ns1, b1.Commitment)
+	require.ErrorIs(t, err, ErrNotMocked)
+}
+
+func TestBlobStoreSubscribe(t *testing.T) {
+	store := NewBlobStore()
+	api := store.API()
+	b1, ns1 := testBlob(t, 1, "first")
+	b2, _ := testBlob(t, 2, "second")
+
+	ctx, cancel := context.WithCancel(context.Background())
+	sub, err := api.Subscribe(ctx, ns1)
+	require.NoError(t, err)
+
+	_, err = api.Submit(context.Background(), []*blob.Blob{b1, b2}, nil)
+	require.NoError(t, err)
+	resp := <-sub
+	require.EqualValues(t, 1, resp.Height)
+	require.Equal(t, []*blob.Blob{b1}, resp.Blobs)
+
+	cancel()
+	select {
+	case _, ok := <-sub:
+		require.False(t, ok)
+	case <-time.After(time.Second):
+		t.Fatal("subscription wasn't closed")
+	}
+}
+
+func TestBlobStoreStalledSubscriber(t *testing.T) {
+	store := NewBlobStore()
+	api := store.API()
+	b, namespace := testBlob(t, 1, "data")
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	sub, err := api.Subscribe(ctx, namespace)
+	require.NoError(t, err)
+
+	// submissions don't block on a subscriber which doesn't read
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		for i := 0; i < 2*subscriptionBuffer; i++ {
+			_, _ = api.Submit(context.Background(), []*blob.Blob{b}, nil)
+		}
+	}()
+	select {
+	case <-done:
+	case <-time.After(time.Second):
+		t.Fatal("submissions blocked on the subscriber")
+	}
+	require.Len(t, sub, subscriptionBuffer)
+	require.EqualValues(t, 1, (<-sub).Height)
+}
+
+func TestStub(t *testing.T) {
+	api := Stub(&header.API{})
+	_, err := api.LocalHead(context.Background())
+	require.ErrorIs(t, err, ErrNotMocked)
+
+	head := &header.ExtendedHeader{}
+	api.LocalHead = func(context.Context) (*header.ExtendedHeader, error) {
+		return head, nil
+	}
+	// replaced methods are kept when stubbing again
+	got, err := Stub(api).LocalHead(context.Background())
+	require.NoError(t, err)
+	require.Same(t, head, got)
+}
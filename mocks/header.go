@@ -0,0 +1,194 @@
+package mocks
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+
+	libhead "github.com/celestiaorg/go-header"
+
+	"github.com/celestiaorg/celestia-openrpc/types/core"
+	"github.com/celestiaorg/celestia-openrpc/types/header"
+)
+
+// ErrHeaderNotFound is returned for headers which weren't appended to the HeaderStore.
+var ErrHeaderNotFound = errors.New("header: not found")
+
+// HeaderStore is an in-memory fake of the header module serving the headers appended
+// to it. The latest appended header is both the local and the network head. Like with
+// BlobStore, subscribers falling behind miss headers instead of blocking appends.
+type HeaderStore struct {
+	mu      sync.Mutex
+	headers map[uint64]*header.ExtendedHeader
+	head    *header.ExtendedHeader
+	// appended is closed and replaced whenever a header is appended
+	appended chan struct{}
+	subs     []*subscription[*header.ExtendedHeader]
+}
+
+// NewHeaderStore returns an empty HeaderStore.
+func NewHeaderStore() *HeaderStore {
+	return &HeaderStore{
+		headers:  make(map[uint64]*header.ExtendedHeader),
+		appended: make(chan struct{}),
+	}
+}
+
+// NewHeader returns a header at the given height, carrying just enough data to be
+// encoded, decoded and identified by its height and hash.
+func NewHeader(height uint64) *header.ExtendedHeader {
+	hash := []byte(fmt.Sprintf("%032d", height))
+	return &header.ExtendedHeader{
+		RawHeader: header.RawHeader{
+			ChainID: "private",
+			Height:  int64(height),
+			LastBlockID: core.BlockID{
+				Hash: []byte(fmt.Sprintf("%032d", height-1)),
+			},
+		},
+		Commit: &core.Commit{
+			Height:  int64(height),
+			BlockID: core.BlockID{Hash: hash},
+		},
+		ValidatorSet: &core.ValidatorSet{},
+		DAH:          &header.DataAvailabilityHeader{},
+	}
+}
+
+// Append adds the headers to the store, making the highest of them the head if
+// it's above the current one.
+func (s *HeaderStore) Append(headers ...*header.ExtendedHeader) {
+	s.mu.Lock()
+	for _, h := range headers {
+		s.headers[h.Height()] = h
+		if s.head == nil || h.Height() > s.head.Height() {
+			s.head = h
+		}
+	}
+	close(s.appended)
+	s.appended = make(chan struct{})
+	subs := s.subs
+	s.mu.Unlock()
+
+	for _, sub := range subs {
+		for _, h := range headers {
+			sub.send(h)
+		}
+	}
+}
+
+// Head returns the height of the head, or 0 if the store is empty.
+func (s *HeaderStore) Head() uint64 {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.head == nil {
+		return 0
+	}
+	return s.head.Height()
+}
+
+// API returns the header module backed by the store. The sync state isn't
+// tracked and SyncState fails with ErrNotMocked.
+func (s *HeaderStore) API() *header.API {
+	return Stub(&header.API{
+		LocalHead:        s.localHead,
+		GetByHash:        s.getByHash,
+		GetRangeByHeight: s.getRangeByHeight,
+		GetByHeight:      s.getByHeight,
+		WaitForHeight:    s.waitForHeight,
+		SyncWait:         func(context.Context) error { return nil },
+		NetworkHead:      s.localHead,
+		Subscribe:        s.subscribe,
+	})
+}
+
+func (s *HeaderStore) localHead(context.Context) (*header.ExtendedHeader, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.head == nil {
+		return nil, ErrHeaderNotFound
+	}
+	return s.head, nil
+}
+
+func (s *HeaderStore) getByHash(_ context.Context, hash libhead.Hash) (*header.ExtendedHeader, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	for _, h := range s.headers {
+		if bytes.Equal(h.Hash(), hash) {
+			return h, nil
+		}
+	}
+	return nil, ErrHeaderNotFound
+}
+
+func (s *HeaderStore) getByHeight(_ context.Context, height uint64) (*header.ExtendedHeader, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.head == nil || height > s.head.Height() {
+		return nil, fmt.Errorf("header: height %d is from the future", height)
+	}
+	h, ok := s.headers[height]
+	if !ok {
+		return nil, ErrHeaderNotFound
+	}
+	return h, nil
+}
+
+// getRangeByHeight returns the headers from the one following from up to, excluding, to.
+func (s *HeaderStore) getRangeByHeight(
+	ctx context.Context,
+	from *header.ExtendedHeader,
+	to uint64,
+) ([]*header.ExtendedHeader, error) {
+	var headers []*header.ExtendedHeader
+	for height := from.Height() + 1; height < to; height++ {
+		h, err := s.getByHeight(ctx, height)
+		if err != nil {
+			return nil, err
+		}
+		headers = append(headers, h)
+	}
+	return headers, nil
+}
+
+func (s *HeaderStore) waitForHeight(ctx context.Context, height uint64) (*header.ExtendedHeader, error) {
+	for {
+		s.mu.Lock()
+		h, ok := s.headers[height]
+		appended := s.appended
+		s.mu.Unlock()
+		if ok {
+			return h, nil
+		}
+
+		select {
+		case <-appended:
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		}
+	}
+}
+
+func (s *HeaderStore) subscribe(ctx context.Context) (<-chan *header.ExtendedHeader, error) {
+	sub := newSubscription[*header.ExtendedHeader]()
+	s.mu.Lock()
+	s.subs = append(s.subs, sub)
+	s.mu.Unlock()
+
+	go func() {
+		<-ctx.Done()
+		s.mu.Lock()
+		for i, other := range s.subs {
+			if other == sub {
+				s.subs = append(s.subs[:i:i], s.subs[i+1:]...)
+				break
+			}
+		}
+		s.mu.Unlock()
+		sub.close()
+	}()
+	return sub.ch, nil
+}
@@ -0,0 +1,82 @@
+package mocks
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/celestiaorg/celestia-openrpc/types/header"
+)
+
+func TestHeaderStore(t *testing.T) {
+	ctx := context.Background()
+	store := NewHeaderStore()
+	api := store.API()
+
+	_, err := api.LocalHead(ctx)
+	require.ErrorIs(t, err, ErrHeaderNotFound)
+
+	h1, h2, h3 := NewHeader(1), NewHeader(2), NewHeader(3)
+	store.Append(h1, h3, h2)
+	require.EqualValues(t, 3, store.Head())
+
+	head, err := api.NetworkHead(ctx)
+	require.NoError(t, err)
+	require.Same(t, h3, head)
+
+	got, err := api.GetByHeight(ctx, 2)
+	require.NoError(t, err)
+	require.Same(t, h2, got)
+	_, err = api.GetByHeight(ctx, 4)
+	require.ErrorContains(t, err, "from the future")
+
+	got, err = api.GetByHash(ctx, h1.Hash())
+	require.NoError(t, err)
+	require.Same(t, h1, got)
+
+	headers, err := api.GetRangeByHeight(ctx, h1, 3)
+	require.NoError(t, err)
+	require.Equal(t, []*header.ExtendedHeader{h2}, headers)
+
+	_, err = api.SyncState(ctx)
+	require.ErrorIs(t, err, ErrNotMocked)
+}
+
+func TestHeaderStoreWaitForHeight(t *testing.T) {
+	store := NewHeaderStore()
+	api := store.API()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	_, err := api.WaitForHeight(ctx, 1)
+	require.ErrorIs(t, err, context.DeadlineExceeded)
+
+	h := NewHeader(1)
+	go store.Append(h)
+	got, err := api.WaitForHeight(context.Background(), 1)
+	require.NoError(t, err)
+	require.Same(t, h, got)
+}
+
+func TestHeaderStoreSubscribe(t *testing.T) {
+	store := NewHeaderStore()
+	api := store.API()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	sub, err := api.Subscribe(ctx)
+	require.NoError(t, err)
+
+	h := NewHeader(1)
+	store.Append(h)
+	require.Same(t, h, <-sub)
+
+	cancel()
+	select {
+	case _, ok := <-sub:
+		require.False(t, ok)
+	case <-time.After(time.Second):
+		t.Fatal("subscription wasn't closed")
+	}
+}
@@ -0,0 +1,42 @@
+// Package mocks provides stand-ins for the modules of the client in tests. Stub turns any
+// module into one whose methods fail with ErrNotMocked until replaced, while BlobStore and
+// HeaderStore are in-memory fakes of the blob and header modules. Other modules have no fakes
+// with behaviour of their own.
+package mocks
+
+import (
+	"errors"
+	"reflect"
+)
+
+// ErrNotMocked is returned by methods of stubbed modules which weren't replaced.
+var ErrNotMocked = errors.New("mocks: method not mocked")
+
+// Stub fills every nil method of the module, a pointer to one of the API structs, with one
+// returning ErrNotMocked. Methods can be replaced before or after stubbing, e.g.
+//
+//	api := mocks.Stub(&header.API{})
+//	api.LocalHead = func(context.Context) (*header.ExtendedHeader, error) { return head, nil }
+func Stub[T any](module *T) *T {
+	v := reflect.ValueOf(module).Elem()
+	for i := 0; i < v.NumField(); i++ {
+		field := v.Field(i)
+		if field.Kind() != reflect.Func || !field.IsNil() {
+			continue
+		}
+		fnType := field.Type()
+		field.Set(reflect.MakeFunc(fnType, func([]reflect.Value) []reflect.Value {
+			out := make([]reflect.Value, fnType.NumOut())
+			for i := range out {
+				out[i] = reflect.Zero(fnType.Out(i))
+			}
+			if n := fnType.NumOut(); n > 0 && fnType.Out(n-1) == errorType {
+				out[n-1] = reflect.ValueOf(&ErrNotMocked).Elem()
+			}
+			return out
+		}))
+	}
+	return module
+}
+
+var errorType = reflect.TypeOf((*error)(nil)).Elem()
@@ -0,0 +1,38 @@
+package mocks
+
+import "sync"
+
+// subscriptionBuffer is the number of items buffered for every subscription.
+const subscriptionBuffer = 64
+
+// subscription delivers items to a subscriber, dropping those which don't fit
+// into the buffer instead of blocking the sender.
+type subscription[T any] struct {
+	// mu guards sending on ch against it being closed
+	mu     sync.Mutex
+	ch     chan T
+	closed bool
+}
+
+func newSubscription[T any]() *subscription[T] {
+	return &subscription[T]{ch: make(chan T, subscriptionBuffer)}
+}
+
+func (sub *subscription[T]) send(item T) {
+	sub.mu.Lock()
+	defer sub.mu.Unlock()
+	if sub.closed {
+		return
+	}
+	select {
+	case sub.ch <- item:
+	default:
+	}
+}
+
+func (sub *subscription[T]) close() {
+	sub.mu.Lock()
+	defer sub.mu.Unlock()
+	sub.closed = true
+	close(sub.ch)
+}
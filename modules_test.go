@@ -0,0 +1,102 @@
+package client
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+// readyNode returns a node answering node.Ready and recording the called methods.
+func readyNode(t *testing.T) (addr string, methods func() []string) {
+	var (
+		mu     sync.Mutex
+		called []string
+	)
+	srv := testNode(t, func(_ *http.Request, method string, _ json.RawMessage) (interface{}, error) {
+		mu.Lock()
+		called = append(called, method)
+		mu.Unlock()
+		if method != "node.Ready" {
+			return nil, errors.New("method not found")
+		}
+		return true, nil
+	})
+	return srv.URL, func() []string {
+		mu.Lock()
+		defer mu.Unlock()
+		return append([]string(nil), called...)
+	}
+}
+
+func TestWithModules(t *testing.T) {
+	addr, methods := readyNode(t)
+	c, err := NewClient(context.Background(), addr, "", WithModules("node"))
+	require.NoError(t, err)
+	defer c.Close()
+
+	ready, err := c.Node.Ready(context.Background())
+	require.NoError(t, err)
+	require.True(t, ready)
+
+	_, err = c.Header.NetworkHead(context.Background())
+	require.ErrorIs(t, err, ErrModuleNotEnabled)
+	require.NotContains(t, methods(), "header.NetworkHead")
+}
+
+func TestWithModulesUnknown(t *testing.T) {
+	_, err := NewClient(context.Background(), "http://localhost:26658", "", WithModules("node", "rollup"))
+	require.ErrorContains(t, err, "unknown module rollup")
+}
+
+func TestDialedModules(t *testing.T) {
+	modules := (&Client{}).modules()
+
+	dialed, err := newConfig().dialedModules(modules)
+	require.NoError(t, err)
+	require.Equal(t, modules, dialed)
+
+	dialed, err = newConfig(WithModules("blob"), WithModules("header")).dialedModules(modules)
+	require.NoError(t, err)
+	require.Len(t, dialed, 2)
+	require.Contains(t, dialed, "blob")
+	require.Contains(t, dialed, "header")
+}
+
+// rollupAPI is an extension module of a fork of the node.
+type rollupAPI struct {
+	Ready  func(context.Context) (bool, error) `perm:"read"`
+	Commit func(context.Context, []byte) error `perm:"write"`
+}
+
+func TestWithModuleExtension(t *testing.T) {
+	srv := testNode(t, func(_ *http.Request, method string, _ json.RawMessage) (interface{}, error) {
+		if method != "rollup.Ready" {
+			return nil, errors.New("method not found")
+		}
+		return true, nil
+	})
+
+	var rollup rollupAPI
+	c, err := NewClient(context.Background(), srv.URL, "", WithModule("rollup", &rollup))
+	require.NoError(t, err)
+	defer c.Close()
+
+	ready, err := rollup.Ready(context.Background())
+	require.NoError(t, err)
+	require.True(t, ready)
+	require.Equal(t, permWrite, c.methodPerm("rollup.Commit"))
+}
+
+func TestWithModuleInvalid(t *testing.T) {
+	var rollup rollupAPI
+	_, err := NewClient(context.Background(), "http://localhost:26658", "", WithModule("blob", &rollup))
+	require.ErrorContains(t, err, "module blob is already registered")
+
+	_, err = NewClient(context.Background(), "http://localhost:26658", "", WithModule("rollup", rollup))
+	require.ErrorContains(t, err, "must be a pointer to a struct")
+}
@@ -0,0 +1,243 @@
+package nsarchive
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	client "github.com/celestiaorg/celestia-openrpc"
+	"github.com/celestiaorg/celestia-openrpc/types/appconsts"
+	"github.com/celestiaorg/celestia-openrpc/types/blob"
+	"github.com/celestiaorg/celestia-openrpc/types/share"
+)
+
+const (
+	// ManifestFile is the name of the manifest file within an archive directory.
+	ManifestFile = "manifest.json"
+	// BlobsDir is the name of the directory holding the blob data within an archive directory.
+	BlobsDir = "blobs"
+)
+
+// Manifest describes the contents of an archive.
+type Manifest struct {
+	Namespace  string  `json:"namespace"`
+	FromHeight uint64  `json:"from_height"`
+	ToHeight   uint64  `json:"to_height"`
+	Entries    []Entry `json:"entries"`
+}
+
+// Entry describes a single archived blob.
+type Entry struct {
+	Height       uint64 `json:"height"`
+	Commitment   string `json:"commitment"`
+	ShareVersion uint32 `json:"share_version"`
+	// Signer is the address of the account which signed the blob, for share version 1 blobs.
+	Signer []byte `json:"signer,omitempty"`
+	// File is the path of the blob data relative to the archive directory.
+	// The name of the file is the SHA-256 hash of the data.
+	File  string      `json:"file"`
+	Proof *blob.Proof `json:"proof,omitempty"`
+}
+
+// Export dumps all blobs of the namespace within the given inclusive range of heights
+// into dir, together with their inclusion proofs, and writes the manifest.
+func Export(
+	ctx context.Context,
+	c *client.Client,
+	namespace share.Namespace,
+	fromHeight, toHeight uint64,
+	dir string,
+) (*Manifest, error) {
+	if fromHeight == 0 || fromHeight > toHeight {
+		return nil, fmt.Errorf("invalid height range [%d, %d]", fromHeight, toHeight)
+	}
+	if err := os.MkdirAll(filepath.Join(dir, BlobsDir), 0o750); err != nil {
+		return nil, err
+	}
+
+	manifest := &Manifest{
+		Namespace:  namespace.String(),
+		FromHeight: fromHeight,
+		ToHeight:   toHeight,
+	}
+	for height := fromHeight; height <= toHeight; height++ {
+		blobs, err := c.Blob.GetAll(ctx, height, []share.Namespace{namespace})
+		if err != nil {
+			if errors.Is(err, blob.ErrBlobNotFound) {
+				continue
+			}
+			return nil, fmt.Errorf("getting blobs at height %d: %w", height, err)
+		}
+
+		for _, b := range blobs {
+			proof, err := c.Blob.GetProof(ctx, height, namespace, b.Commitment)
+			if err != nil {
+				return nil, fmt.Errorf("getting proof at height %d: %w", height, err)
+			}
+			file, err := writeBlob(dir, b.Data)
+			if err != nil {
+				return nil, err
+			}
+			manifest.Entries = append(manifest.Entries, Entry{
+				Height:       height,
+				Commitment:   hex.EncodeToString(b.Commitment),
+				ShareVersion: b.ShareVersion,
+				Signer:       b.Signer(),
+				File:         file,
+				Proof:        proof,
+			})
+		}
+	}
+
+	bz, err := json.MarshalIndent(manifest, "", "  ")
+	if err != nil {
+		return nil, err
+	}
+	if err := os.WriteFile(filepath.Join(dir, ManifestFile), bz, 0o600); err != nil {
+		return nil, err
+	}
+	return manifest, nil
+}
+
+// ReadManifest reads the manifest of the archive in dir.
+func ReadManifest(dir string) (*Manifest, error) {
+	bz, err := os.ReadFile(filepath.Join(dir, ManifestFile))
+	if err != nil {
+		return nil, err
+	}
+	var manifest Manifest
+	if err := json.Unmarshal(bz, &manifest); err != nil {
+		return nil, fmt.Errorf("unmarshalling manifest: %w", err)
+	}
+	return &manifest, nil
+}
+
+// Verify checks the integrity of the archive in dir. Every blob file must match its
+// content address and recompute to the recorded commitment. If c is not nil, the recorded
+// proofs are additionally checked against the node.
+func Verify(ctx context.Context, c *client.Client, dir string) error {
+	manifest, err := ReadManifest(dir)
+	if err != nil {
+		return err
+	}
+	namespace, err := ParseNamespace(manifest.Namespace)
+	if err != nil {
+		return err
+	}
+
+	for _, entry := range manifest.Entries {
+		b, err := loadBlob(dir, namespace, entry)
+		if err != nil {
+			return err
+		}
+		if c == nil || entry.Proof == nil {
+			continue
+		}
+		included, err := c.Blob.Included(ctx, entry.Height, namespace, entry.Proof, b.Commitment)
+		if err != nil {
+			return fmt.Errorf("verifying inclusion of %s: %w", entry.Commitment, err)
+		}
+		if !included {
+			return fmt.Errorf("blob %s is not included at height %d", entry.Commitment, entry.Height)
+		}
+	}
+	return nil
+}
+
+// Resubmit submits the blobs of the archive in dir again. Blobs included at the same
+// height are submitted together. The returned map holds the new inclusion height
+// for every archived height.
+func Resubmit(
+	ctx context.Context,
+	c *client.Client,
+	dir string,
+	opts *blob.SubmitOptions,
+) (map[uint64]uint64, error) {
+	manifest, err := ReadManifest(dir)
+	if err != nil {
+		return nil, err
+	}
+	namespace, err := ParseNamespace(manifest.Namespace)
+	if err != nil {
+		return nil, err
+	}
+
+	heights := make(map[uint64]uint64)
+	var batch []*blob.Blob
+	for i, entry := range manifest.Entries {
+		b, err := loadBlob(dir, namespace, entry)
+		if err != nil {
+			return heights, err
+		}
+		batch = append(batch, b)
+
+		if i+1 < len(manifest.Entries) && manifest.Entries[i+1].Height == entry.Height {
+			continue
+		}
+		height, err := c.Blob.Submit(ctx, batch, opts)
+		if err != nil {
+			return heights, fmt.Errorf("resubmitting blobs of height %d: %w", entry.Height, err)
+		}
+		heights[entry.Height] = height
+		batch = nil
+	}
+	return heights, nil
+}
+
+// writeBlob stores the data under its content address and returns the path
+// relative to the archive directory.
+func writeBlob(dir string, data []byte) (string, error) {
+	sum := sha256.Sum256(data)
+	file := filepath.Join(BlobsDir, hex.EncodeToString(sum[:]))
+	if err := os.WriteFile(filepath.Join(dir, file), data, 0o600); err != nil {
+		return "", err
+	}
+	return file, nil
+}
+
+// loadBlob reads the blob of the entry and checks it against its content address
+// and the recorded commitment.
+func loadBlob(dir string, namespace share.Namespace, entry Entry) (*blob.Blob, error) {
+	data, err := os.ReadFile(filepath.Join(dir, filepath.Clean(entry.File)))
+	if err != nil {
+		return nil, err
+	}
+	sum := sha256.Sum256(data)
+	if filepath.Base(entry.File) != hex.EncodeToString(sum[:]) {
+		return nil, fmt.Errorf("blob file %s does not match its content", entry.File)
+	}
+
+	var b *blob.Blob
+	//nolint:gosec
+	if uint8(entry.ShareVersion) == appconsts.ShareVersionOne {
+		b, err = blob.NewBlobV1(namespace, data, entry.Signer)
+	} else {
+		//nolint:gosec
+		b, err = blob.NewBlob(uint8(entry.ShareVersion), namespace, data)
+	}
+	if err != nil {
+		return nil, err
+	}
+	if hex.EncodeToString(b.Commitment) != entry.Commitment {
+		return nil, fmt.Errorf("blob file %s does not match commitment %s", entry.File, entry.Commitment)
+	}
+	return b, nil
+}
+
+// ParseNamespace parses either a full hex encoded namespace or the hex encoded
+// ID of a version 0 namespace.
+func ParseNamespace(s string) (share.Namespace, error) {
+	bz, err := hex.DecodeString(s)
+	if err != nil {
+		return nil, fmt.Errorf("decoding namespace: %w", err)
+	}
+	if len(bz) == appconsts.NamespaceSize {
+		return share.NamespaceFromBytes(bz)
+	}
+	return share.NewBlobNamespaceV0(bz)
+}
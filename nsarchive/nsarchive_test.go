@@ -0,0 +1,132 @@
+package nsarchive
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	client "github.com/celestiaorg/celestia-openrpc"
+	"github.com/celestiaorg/celestia-openrpc/mocks"
+	"github.com/celestiaorg/celestia-openrpc/types/blob"
+	"github.com/celestiaorg/celestia-openrpc/types/share"
+)
+
+// storeClient returns a client whose blob module is served by the store.
+func storeClient(store *mocks.BlobStore) *client.Client {
+	c := &client.Client{Blob: *store.API()}
+	c.Blob.GetProof = func(context.Context, uint64, share.Namespace, blob.Commitment) (*blob.Proof, error) {
+		return &blob.Proof{}, nil
+	}
+	return c
+}
+
+func TestExportVerifyResubmit(t *testing.T) {
+	ctx := context.Background()
+	namespace, err := ParseNamespace("0102030405")
+	require.NoError(t, err)
+	other, err := ParseNamespace("0a0b")
+	require.NoError(t, err)
+
+	store := mocks.NewBlobStore()
+	c := storeClient(store)
+	var blobs []*blob.Blob
+	for _, data := range []string{"one", "two", "three"} {
+		b, err := blob.NewBlobV0(namespace, []byte(data))
+		require.NoError(t, err)
+		blobs = append(blobs, b)
+	}
+	unrelated, err := blob.NewBlobV0(other, []byte("unrelated"))
+	require.NoError(t, err)
+
+	_, err = c.Blob.Submit(ctx, []*blob.Blob{blobs[0], unrelated}, nil)
+	require.NoError(t, err)
+	_, err = c.Blob.Submit(ctx, []*blob.Blob{unrelated}, nil)
+	require.NoError(t, err)
+	_, err = c.Blob.Submit(ctx, blobs[1:], nil)
+	require.NoError(t, err)
+
+	dir := t.TempDir()
+	manifest, err := Export(ctx, c, namespace, 1, store.Height(), dir)
+	require.NoError(t, err)
+	require.Len(t, manifest.Entries, 3)
+	require.EqualValues(t, 1, manifest.Entries[0].Height)
+	require.EqualValues(t, 3, manifest.Entries[2].Height)
+
+	require.NoError(t, Verify(ctx, nil, dir))
+
+	target := mocks.NewBlobStore()
+	heights, err := Resubmit(ctx, storeClient(target), dir, blob.NewSubmitOptions())
+	require.NoError(t, err)
+	// blobs of the same height are resubmitted together
+	require.Equal(t, map[uint64]uint64{1: 1, 3: 2}, heights)
+	got, err := target.API().GetAll(ctx, 2, []share.Namespace{namespace})
+	require.NoError(t, err)
+	require.Len(t, got, 2)
+}
+
+func TestVerifyDetectsTampering(t *testing.T) {
+	ctx := context.Background()
+	namespace, err := ParseNamespace("0102030405")
+	require.NoError(t, err)
+	b, err := blob.NewBlobV0(namespace, []byte("data"))
+	require.NoError(t, err)
+
+	store := mocks.NewBlobStore()
+	c := storeClient(store)
+	_, err = c.Blob.Submit(ctx, []*blob.Blob{b}, nil)
+	require.NoError(t, err)
+
+	dir := t.TempDir()
+	manifest, err := Export(ctx, c, namespace, 1, 1, dir)
+	require.NoError(t, err)
+	require.NoError(t, os.WriteFile(filepath.Join(dir, manifest.Entries[0].File), []byte("tampered"), 0o600))
+	require.Error(t, Verify(ctx, nil, dir))
+}
+
+func TestExportShareVersionOne(t *testing.T) {
+	ctx := context.Background()
+	namespace, err := ParseNamespace("0102030405")
+	require.NoError(t, err)
+	signer := []byte{1, 2, 3, 4, 5, 6, 7, 8, 9, 10, 11, 12, 13, 14, 15, 16, 17, 18, 19, 20}
+	b, err := blob.NewBlobV1(namespace, []byte("signed"), signer)
+	require.NoError(t, err)
+
+	store := mocks.NewBlobStore()
+	_, err = storeClient(store).Blob.Submit(ctx, []*blob.Blob{b}, nil)
+	require.NoError(t, err)
+	dir := t.TempDir()
+	manifest, err := Export(ctx, storeClient(store), namespace, 1, 1, dir)
+	require.NoError(t, err)
+	require.Equal(t, signer, manifest.Entries[0].Signer)
+
+	// the signer is read back from the manifest
+	require.NoError(t, Verify(ctx, nil, dir))
+	target := mocks.NewBlobStore()
+	_, err = Resubmit(ctx, storeClient(target), dir, blob.NewSubmitOptions())
+	require.NoError(t, err)
+	got, err := target.API().GetAll(ctx, 1, []share.Namespace{namespace})
+	require.NoError(t, err)
+	require.Len(t, got, 1)
+	require.True(t, b.Equal(got[0]))
+}
+
+func TestExportRejectsInvalidRange(t *testing.T) {
+	namespace, err := ParseNamespace("01")
+	require.NoError(t, err)
+	_, err = Export(context.Background(), storeClient(mocks.NewBlobStore()), namespace, 5, 4, t.TempDir())
+	require.Error(t, err)
+}
+
+func TestParseNamespace(t *testing.T) {
+	short, err := ParseNamespace("deadbeef")
+	require.NoError(t, err)
+	full, err := ParseNamespace(short.String())
+	require.NoError(t, err)
+	require.Equal(t, short, full)
+
+	_, err = ParseNamespace("not hex")
+	require.Error(t, err)
+}
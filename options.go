@@ -0,0 +1,830 @@
+package client
+
+import (
+	"fmt"
+	"io"
+	"log/slog"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/filecoin-project/go-jsonrpc"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// Option is the functional option that is applied to the Client during
+// construction to configure optional behaviour.
+type Option func(cfg *config)
+
+// config collects the optional settings applied by NewClient.
+type config struct {
+	// metrics receives observations about submissions made through the client.
+	metrics MetricsHook
+	// audit records submissions and retrievals made through the client.
+	audit AuditLogger
+	// dryRun prevents submissions from being broadcast, while dryRunSigner signs the
+	// transactions prepared instead.
+	dryRun       bool
+	dryRunSigner *DryRunSigner
+	// resubmitPolicy resubmits transactions failing with a recoverable error. Nil disables it.
+	resubmitPolicy *ResubmitPolicy
+	// submissions records the blobs included through the client to skip resubmissions.
+	submissions SubmissionStore
+	// receipts records the receipts of the blobs included through the client.
+	receipts SubmissionStore
+	// verifyProofs makes the client verify the proofs of retrieved shares and blobs.
+	verifyProofs bool
+	// cache serves immutable objects without a round trip to the node.
+	cache Cache
+	// blobStore serves retrieved blobs without a round trip to the node.
+	blobStore BlobStore
+	// transport connects the modules of the client to the nodes.
+	transport Transport
+	// header holds the headers sent along with every request.
+	header http.Header
+	// auth provides the tokens, taking precedence over the one passed to NewClient.
+	auth AuthProvider
+	// modules are the RPC namespaces of the modules to connect. Empty means all of them.
+	modules []string
+	// extensions are additional modules keyed by their RPC namespace.
+	extensions map[string]interface{}
+	// strict rejects responses with unknown fields or values of unexpected types.
+	strict bool
+	// strictVersion fails the construction if the API version of the node is unsupported.
+	strictVersion bool
+	// maxRequestSize and maxResponseSize limit the size of HTTP requests and responses.
+	// Zero means no limit.
+	maxRequestSize  int64
+	maxResponseSize int64
+	// compression negotiates compressed HTTP responses, while compressRequests
+	// compresses the bodies of HTTP requests.
+	compression      bool
+	compressRequests bool
+
+	// reconnectMinDelay and reconnectMaxDelay bound the exponential backoff
+	// used to re-establish a dropped WebSocket connection.
+	reconnectMinDelay time.Duration
+	reconnectMaxDelay time.Duration
+	// noReconnect disables reconnection of dropped WebSocket connections.
+	noReconnect bool
+	// pingInterval is the interval between pings sent on WebSocket connections, while
+	// connTimeout is the duration without any response after which a connection is torn down.
+	pingInterval time.Duration
+	connTimeout  time.Duration
+	// onGap is notified about heights missed by subscriptions while they were re-established.
+	onGap func(SubscriptionGap)
+	// onSubscriptionError is notified about subscriptions terminated by an error.
+	onSubscriptionError func(*SubscriptionError)
+	// events receives the events about the connectivity of the client.
+	events func(ConnectionEvent)
+
+	// endpoints are the addresses of additional nodes the client connects to.
+	endpoints []string
+	// hedgeDelay is the delay after which hedged calls are fired against a second endpoint.
+	// Zero disables hedging.
+	hedgeDelay   time.Duration
+	hedgeMethods []string
+	// crossCheckMethods are the methods whose results are compared between two endpoints.
+	crossCheckMethods []string
+
+	// timeouts holds the default timeouts of calls keyed by method or module, with "" being
+	// the default of all calls.
+	timeouts map[string]time.Duration
+
+	// retryPolicies holds the retry policies keyed by module, with "" being the default.
+	retryPolicies map[string]RetryPolicy
+	// retrySafe are the names of non read-only methods which are safe to retry.
+	retrySafe []string
+
+	// breakerThreshold is the number of consecutive connection failures opening the
+	// circuit breaker of an endpoint for breakerCoolDown. Zero disables the breaker.
+	breakerThreshold int
+	breakerCoolDown  time.Duration
+
+	// rateLimit caps the rate of all calls, while methodRateLimits caps the rate of
+	// individual methods keyed by their name.
+	rateLimit        *tokenBucket
+	methodRateLimits map[string]*tokenBucket
+
+	// maxInFlight bounds the number of calls in flight. Zero means no limit.
+	maxInFlight int
+	// rejectExcess fails calls exceeding maxInFlight instead of queuing them.
+	rejectExcess bool
+
+	// callHooks are notified about every request made to a node.
+	callHooks []CallHook
+	// prometheus collects metrics about calls and subscriptions.
+	prometheus *PrometheusMetrics
+	// tracerProvider provides the tracer recording spans for calls. Nil disables tracing.
+	tracerProvider trace.TracerProvider
+
+	// logger logs the lifecycle of connections, retries, slow calls and subscription errors.
+	logger *slog.Logger
+	// slowCallThreshold is the duration above which calls are logged as slow. Zero disables it.
+	slowCallThreshold time.Duration
+	// requestIDs attaches a request ID to every call.
+	requestIDs bool
+	// clock is the source of time of retries, re-created subscriptions, circuit breakers,
+	// hedging, rate limits, polling and keep-alive pings.
+	clock Clock
+}
+
+func newConfig(opts ...Option) *config {
+	cfg := &config{
+		transport: DefaultTransport,
+		header:    http.Header{},
+		logger:    slog.New(slog.NewTextHandler(io.Discard, nil)),
+		clock:     realClock{},
+	}
+	for _, opt := range opts {
+		opt(cfg)
+	}
+	if cfg.requestIDs {
+		cfg.logger = slog.New(requestIDHandler{cfg.logger.Handler()})
+	}
+	cfg.transport = cfg.wrapTransport(cfg.transport)
+	return cfg
+}
+
+// roundTripper returns the function wrapping the round trippers of the HTTP clients
+// requests are sent with, or nil if the requests are neither compressed, limited, validated,
+// measured nor correlated.
+func (cfg *config) roundTripper() func(http.RoundTripper) http.RoundTripper {
+	if !cfg.compression && !cfg.limited() && !cfg.strict && cfg.prometheus == nil && !cfg.requestIDs {
+		return nil
+	}
+	return func(next http.RoundTripper) http.RoundTripper {
+		if cfg.compression {
+			// limits and validation apply to the decompressed responses
+			next = &compressionRoundTripper{next: next, compressRequests: cfg.compressRequests}
+		}
+		if cfg.limited() {
+			next = &limitedRoundTripper{
+				next:            next,
+				maxRequestSize:  cfg.maxRequestSize,
+				maxResponseSize: cfg.maxResponseSize,
+			}
+		}
+		if cfg.strict {
+			next = &strictRoundTripper{next: next}
+		}
+		if cfg.prometheus != nil {
+			next = cfg.prometheus.roundTripper(next)
+		}
+		if cfg.requestIDs {
+			next = &requestIDRoundTripper{next: next}
+		}
+		return next
+	}
+}
+
+// limited reports whether the sizes of requests or responses are limited.
+func (cfg *config) limited() bool {
+	return cfg.maxRequestSize > 0 || cfg.maxResponseSize > 0
+}
+
+// checkEndpoint returns an error if the endpoint at addr is connected to over WebSocket
+// while options requiring HTTP are set.
+func (cfg *config) checkEndpoint(addr string) error {
+	if !cfg.strict {
+		return nil
+	}
+	ws := false
+	switch cfg.transport.(type) {
+	case WebSocketTransport:
+		ws = true
+	case schemeTransport:
+		ws = strings.HasPrefix(addr, "ws://") || strings.HasPrefix(addr, "wss://")
+	}
+	if ws {
+		return fmt.Errorf("strict decoding not supported over WebSocket, connect to %s over HTTP", addr)
+	}
+	return nil
+}
+
+// wrapTransport makes the transport send its requests through the round tripper
+// of the config, if it sends them over HTTP, and apply the size limits and keep-alive
+// pings to WebSocket connections.
+func (cfg *config) wrapTransport(transport Transport) Transport {
+	wrap := cfg.roundTripper()
+	ws := wsOptions{
+		maxRequestSize:  cfg.maxRequestSize,
+		maxResponseSize: cfg.maxResponseSize,
+		pingInterval:    cfg.pingInterval,
+		connTimeout:     cfg.connTimeout,
+		clock:           cfg.clock,
+	}
+	switch t := transport.(type) {
+	case schemeTransport:
+		t.wrap, t.ws = wrap, ws
+		return t
+	case HTTPTransport:
+		t.wrap = wrap
+		return t
+	case WebSocketTransport:
+		t.ws = ws
+		return t
+	default:
+		return transport
+	}
+}
+
+// rpcOptions translates the config into the options of the underlying JSON-RPC client.
+func (cfg *config) rpcOptions() []jsonrpc.Option {
+	var opts []jsonrpc.Option
+	if cfg.reconnectMinDelay > 0 && cfg.reconnectMaxDelay > 0 {
+		opts = append(opts, jsonrpc.WithReconnectBackoff(cfg.reconnectMinDelay, cfg.reconnectMaxDelay))
+	}
+	if cfg.noReconnect {
+		opts = append(opts, jsonrpc.WithNoReconnect())
+	}
+	if cfg.pingInterval > 0 && cfg.connTimeout > 0 {
+		opts = append(opts, jsonrpc.WithPingInterval(cfg.pingInterval), jsonrpc.WithTimeout(cfg.connTimeout))
+	}
+	return opts
+}
+
+// httpClient returns the HTTP client for requests to the endpoint at addr sent outside of the
+// transport, such as batches. It is the one of an HTTPTransport, if configured, or
+// http.DefaultClient otherwise, unless addr is a unix socket, whose client is unix.
+func (cfg *config) httpClient(addr string, unix *http.Client) *http.Client {
+	client := http.DefaultClient
+	if t, ok := cfg.transport.(HTTPTransport); ok && t.Client != nil {
+		client = t.Client
+	}
+	_, client = httpEndpoint(addr, client, unix)
+	return wrapClient(client, cfg.roundTripper())
+}
+
+// reconnectBackoff returns the backoff used to re-establish connections and subscriptions.
+func (cfg *config) reconnectBackoff() backoff {
+	if cfg.reconnectMinDelay > 0 && cfg.reconnectMaxDelay > 0 {
+		return backoff{minDelay: cfg.reconnectMinDelay, maxDelay: cfg.reconnectMaxDelay}
+	}
+	return backoff{minDelay: defaultBackoffMinDelay, maxDelay: defaultBackoffMaxDelay}
+}
+
+// interceptors returns the interceptors all calls of the client are routed through.
+func (cfg *config) interceptors(p *proxy) []interceptor {
+	var interceptors []interceptor
+	if cfg.requestIDs {
+		interceptors = append(interceptors, correlateRequests)
+	}
+	interceptors = append(interceptors, applyCallOptions(p))
+	if len(cfg.timeouts) > 0 {
+		interceptors = append(interceptors, defaultTimeouts(cfg.timeouts))
+	}
+	if cfg.tracerProvider != nil {
+		interceptors = append(interceptors, traceCalls(cfg.tracerProvider))
+	}
+	if len(cfg.crossCheckMethods) > 0 {
+		interceptors = append(interceptors, crossCheck(p, cfg.crossCheckMethods))
+	}
+	interceptors = append(interceptors, retry(cfg.retryPolicies, cfg.retrySafe, cfg.logger, cfg.clock))
+	if cfg.hedgeDelay > 0 {
+		interceptors = append(interceptors, hedge(p, cfg.hedgeDelay, cfg.hedgeMethods, cfg.clock))
+	}
+	if cfg.rateLimit != nil || len(cfg.methodRateLimits) > 0 {
+		interceptors = append(interceptors, rateLimit(cfg.rateLimit, cfg.methodRateLimits, cfg.clock))
+	}
+	if cfg.maxInFlight > 0 {
+		interceptors = append(interceptors, limitInFlight(make(chan struct{}, cfg.maxInFlight), cfg.rejectExcess))
+	}
+	if cfg.slowCallThreshold > 0 {
+		interceptors = append(interceptors, notify([]CallHook{logSlowCalls(cfg.logger, cfg.slowCallThreshold)}))
+	}
+	if len(cfg.callHooks) > 0 {
+		interceptors = append(interceptors, notify(cfg.callHooks))
+	}
+	if cfg.prometheus != nil {
+		interceptors = append(interceptors, labelRequests)
+	}
+	if cfg.strict {
+		interceptors = append(interceptors, expectResults(p))
+	}
+	return append(interceptors, mapErrors)
+}
+
+// dialedModules returns the subset of the modules to connect.
+func (cfg *config) dialedModules(modules map[string]interface{}) (map[string]interface{}, error) {
+	if len(cfg.modules) == 0 {
+		return modules, nil
+	}
+	dialed := make(map[string]interface{}, len(cfg.modules))
+	for _, name := range cfg.modules {
+		module, ok := modules[name]
+		if !ok {
+			return nil, fmt.Errorf("unknown module %s", name)
+		}
+		dialed[name] = module
+	}
+	return dialed, nil
+}
+
+// circuitBreaker returns a new circuit breaker for an endpoint or nil if it's disabled.
+func (cfg *config) circuitBreaker() *circuitBreaker {
+	if cfg.breakerThreshold <= 0 {
+		return nil
+	}
+	return newCircuitBreaker(cfg.breakerThreshold, cfg.breakerCoolDown, cfg.clock)
+}
+
+// submitHook combines all hooks interested in submissions into a single one.
+// Returns nil if there are none.
+func (cfg *config) submitHook() MetricsHook {
+	var hooks multiHook
+	if cfg.metrics != nil {
+		hooks = append(hooks, cfg.metrics)
+	}
+	if cfg.audit != nil {
+		hooks = append(hooks, auditHook{log: cfg.audit})
+	}
+	switch len(hooks) {
+	case 0:
+		return nil
+	case 1:
+		return hooks[0]
+	default:
+		return hooks
+	}
+}
+
+// WithMetrics is an option that registers a MetricsHook, which will be notified
+// about every blob submission made through the client. If the hook implements
+// RetrievalHook, e.g. SubmitSummary, it's notified about blob retrievals as well.
+func WithMetrics(hook MetricsHook) Option {
+	return func(cfg *config) {
+		cfg.metrics = hook
+	}
+}
+
+// WithAuditLog is an option that registers an AuditLogger, which will record
+// every blob submission and retrieval made through the client.
+func WithAuditLog(log AuditLogger) Option {
+	return func(cfg *config) {
+		cfg.audit = log
+	}
+}
+
+// WithDryRun is an option that prevents the client from broadcasting submissions.
+// Instead, the submission methods return a DryRunError describing the fee of the
+// transaction and the predicted share layout of the blobs.
+func WithDryRun() Option {
+	return func(cfg *config) {
+		cfg.dryRun = true
+	}
+}
+
+// WithDryRunSigner is an option that enables the dry-run mode of WithDryRun, signing the
+// prepared transactions with the signer, so the DryRunError carries the encoded transaction
+// as it would be broadcast.
+func WithDryRunSigner(signer DryRunSigner) Option {
+	return func(cfg *config) {
+		cfg.dryRun = true
+		cfg.dryRunSigner = &signer
+	}
+}
+
+// WithResubmission is an option that resubmits transactions of blob.Submit and
+// state.SubmitPayForBlob failing with a recoverable error according to the policy:
+// transactions signed with an outdated account sequence are resubmitted as they are,
+// as the node resynchronizes the sequence, while transactions rejected for their fee,
+// e.g. by a full mempool, or timed out are resubmitted with a raised gas price.
+// The caller only receives the result of the last attempt.
+func WithResubmission(policy ResubmitPolicy) Option {
+	return func(cfg *config) {
+		cfg.resubmitPolicy = &policy
+	}
+}
+
+// WithReceiptStore is an option that records the receipt of every blob included through
+// blob.Submit and state.SubmitPayForBlob in the store, keyed by the commitment of the blob.
+// Once a submission succeeds, the blobs are located in the block, so the submission only
+// returns once the node processed its header. Use NewFileSubmissionStore to persist them.
+// Passing the same store to WithIdempotentSubmission skips the blobs recorded.
+func WithReceiptStore(store SubmissionStore) Option {
+	return func(cfg *config) {
+		cfg.receipts = store
+	}
+}
+
+// WithIdempotentSubmission is an option that records the blobs included through blob.Submit
+// and state.SubmitPayForBlob in the store by their commitment, and skips the blobs recorded
+// before on later submissions, e.g. when a rollup resubmits a batch after a crash. If all
+// the blobs of a submission were included before, their original height and transaction
+// hash are returned without broadcasting anything, otherwise only the remaining blobs are
+// submitted. Concurrent submissions of the same blobs aren't deduplicated. Use
+// NewFileSubmissionStore for the records to survive restarts.
+func WithIdempotentSubmission(store SubmissionStore) Option {
+	return func(cfg *config) {
+		cfg.submissions = store
+	}
+}
+
+// WithProofVerification is an option that makes the client verify the data returned by
+// blob.GetAll, share.GetSharesByNamespace, share.GetRange and share.GetEDS against the data
+// availability header of the block, failing with ErrVerificationFailed instead of returning
+// unproven data. blob.Included verifies the proof locally against the data root of the
+// header instead of relying on the answer of the node, and blob.GetCommitmentProof verifies
+// the returned proof. The headers are retrieved from the node as well, so they should be
+// cross-checked or validated separately. Requires the header and share modules.
+func WithProofVerification() Option {
+	return func(cfg *config) {
+		cfg.verifyProofs = true
+	}
+}
+
+// WithCache is an option that serves immutable objects, such as headers, blobs and
+// extended data squares, from the given Cache, avoiding repeated round trips to the node.
+// Use a FileCache to persist them or an LRUCache to keep the most recent ones in memory.
+func WithCache(cache Cache) Option {
+	return func(cfg *config) {
+		cfg.cache = cache
+	}
+}
+
+// WithBlobStore is an option that serves blobs read before from the given BlobStore,
+// so repeated reads of the same rollup data skip the node. Blobs retrieved by Get and
+// GetAll are added to the store. With WithProofVerification, only verified blobs are.
+func WithBlobStore(store BlobStore) Option {
+	return func(cfg *config) {
+		cfg.blobStore = store
+	}
+}
+
+// WithReconnectBackoff is an option that configures the backoff used to re-establish
+// a dropped WebSocket connection. The delay between attempts grows exponentially
+// from minDelay up to maxDelay and is randomly jittered by up to minDelay.
+// Calls made while the connection is down fail until it is re-established.
+func WithReconnectBackoff(minDelay, maxDelay time.Duration) Option {
+	return func(cfg *config) {
+		cfg.reconnectMinDelay = minDelay
+		cfg.reconnectMaxDelay = max(minDelay, maxDelay)
+	}
+}
+
+// WithoutReconnect is an option that disables reconnection of a dropped
+// WebSocket connection, so every call fails once the connection is lost
+// and subscriptions are closed instead of being re-created.
+func WithoutReconnect() Option {
+	return func(cfg *config) {
+		cfg.noReconnect = true
+	}
+}
+
+// WithSubscriptionGapHandler is an option that registers a callback notified about
+// the heights a subscription missed while it was re-created after the connection dropped,
+// so the caller can backfill them.
+func WithSubscriptionGapHandler(onGap func(SubscriptionGap)) Option {
+	return func(cfg *config) {
+		cfg.onGap = onGap
+	}
+}
+
+// WithSubscriptionErrorHandler is an option that registers a callback notified about
+// subscriptions terminated by an error, such as ErrSubscriptionPanicked for panics while
+// dispatching their items, right before their channels are closed.
+func WithSubscriptionErrorHandler(onError func(*SubscriptionError)) Option {
+	return func(cfg *config) {
+		cfg.onSubscriptionError = onError
+	}
+}
+
+// WithConnectionEvents is an option that registers a handler receiving the events about the
+// connectivity of the client, e.g. to alert on connectivity issues: Connected and Disconnected
+// for every endpoint, as well as Reconnecting and Resubscribed for subscriptions re-created
+// after the connection dropped. The handler is called synchronously and must not block.
+func WithConnectionEvents(handler func(ConnectionEvent)) Option {
+	return func(cfg *config) {
+		cfg.events = handler
+	}
+}
+
+// WithEndpoints is an option that connects the client to additional nodes
+// next to the one it is constructed with. The same token is used for all of them.
+func WithEndpoints(addrs ...string) Option {
+	return func(cfg *config) {
+		cfg.endpoints = append(cfg.endpoints, addrs...)
+	}
+}
+
+// WithHedging is an option that enables hedged requests for read-only methods, e.g.
+// "share.GetEDS". If the first endpoint hasn't responded within the delay, the call is
+// fired against a second endpoint as well and the first successful response is returned.
+// Requires additional endpoints to be configured with WithEndpoints. If no methods are
+// provided, header, EDS and blob retrieval methods are hedged.
+func WithHedging(delay time.Duration, methods ...string) Option {
+	return func(cfg *config) {
+		cfg.hedgeDelay = delay
+		cfg.hedgeMethods = methods
+		if len(methods) == 0 {
+			cfg.hedgeMethods = defaultHedgedMethods
+		}
+	}
+}
+
+// WithCrossCheck is an option that performs calls of the given methods, e.g.
+// "header.GetByHeight", against two endpoints and compares their results, failing
+// with a *MismatchError if they differ. Requires additional endpoints to be configured
+// with WithEndpoints. If no methods are provided, header and proof retrieval methods
+// are cross-checked.
+func WithCrossCheck(methods ...string) Option {
+	return func(cfg *config) {
+		cfg.crossCheckMethods = methods
+		if len(methods) == 0 {
+			cfg.crossCheckMethods = defaultCrossCheckedMethods
+		}
+	}
+}
+
+// WithDefaultTimeout is an option that bounds the duration of calls, including retries,
+// of the given modules or methods, e.g. "header" or "share.GetEDS", by the timeout unless
+// the call sets its own one with WithCallTimeout. The timeout of a method takes precedence
+// over the one of its module. If no modules or methods are provided, the timeout applies
+// to all calls without a more specific one. Subscriptions aren't bounded.
+func WithDefaultTimeout(timeout time.Duration, modulesOrMethods ...string) Option {
+	return func(cfg *config) {
+		if cfg.timeouts == nil {
+			cfg.timeouts = make(map[string]time.Duration)
+		}
+		if len(modulesOrMethods) == 0 {
+			cfg.timeouts[""] = timeout
+		}
+		for _, name := range modulesOrMethods {
+			cfg.timeouts[name] = timeout
+		}
+	}
+}
+
+// WithRetry is an option that retries calls failing with a transient error, such as
+// a timeout, a dropped connection or an HTTP 429/503 response, according to the policy.
+// If modules are provided, e.g. "header", the policy only applies to them, overriding
+// the default one. Only read-only methods are retried, unless marked with WithRetrySafe.
+func WithRetry(policy RetryPolicy, modules ...string) Option {
+	return func(cfg *config) {
+		if cfg.retryPolicies == nil {
+			cfg.retryPolicies = make(map[string]RetryPolicy)
+		}
+		if len(modules) == 0 {
+			cfg.retryPolicies[""] = policy
+		}
+		for _, module := range modules {
+			cfg.retryPolicies[module] = policy
+		}
+	}
+}
+
+// WithRetrySafe is an option that marks methods which modify state, e.g. "state.Transfer",
+// as safe to be retried by WithRetry. Retrying them may apply the call more than once.
+func WithRetrySafe(methods ...string) Option {
+	return func(cfg *config) {
+		cfg.retrySafe = append(cfg.retrySafe, methods...)
+	}
+}
+
+// WithClock is an option that replaces the clock timing the retries, the re-creation of
+// subscriptions, the cool-down of circuit breakers, hedged calls, rate limits, polls and
+// the keep-alive pings set with WithKeepAlive, e.g. with a fake clock in tests.
+func WithClock(clock Clock) Option {
+	return func(cfg *config) {
+		cfg.clock = clock
+	}
+}
+
+// WithCircuitBreaker is an option that stops calls to an endpoint for the cool-down
+// period once threshold consecutive calls to it failed due to the connection. Afterwards,
+// a single call probes the endpoint, resuming calls to it if it succeeds. Calls are routed
+// to other endpoints configured with WithEndpoints meanwhile, failing with ErrCircuitOpen
+// if there are none.
+func WithCircuitBreaker(threshold int, coolDown time.Duration) Option {
+	return func(cfg *config) {
+		cfg.breakerThreshold = threshold
+		cfg.breakerCoolDown = coolDown
+	}
+}
+
+// WithRateLimit is an option that caps the rate of calls made by the client to perSecond,
+// allowing bursts of up to burst calls. Calls exceeding the rate are delayed until they are
+// allowed or their context is done. Retried and hedged calls count as separate calls.
+// A rate of zero or below removes the limit.
+func WithRateLimit(perSecond float64, burst int) Option {
+	return func(cfg *config) {
+		cfg.rateLimit = nil
+		if perSecond > 0 {
+			cfg.rateLimit = newTokenBucket(perSecond, burst)
+		}
+	}
+}
+
+// WithMethodRateLimit is an option that caps the rate of calls of a single method,
+// e.g. "share.GetEDS", in addition to the limit set with WithRateLimit.
+// A rate of zero or below removes the limit of the method.
+func WithMethodRateLimit(method string, perSecond float64, burst int) Option {
+	return func(cfg *config) {
+		if perSecond <= 0 {
+			delete(cfg.methodRateLimits, method)
+			return
+		}
+		if cfg.methodRateLimits == nil {
+			cfg.methodRateLimits = make(map[string]*tokenBucket)
+		}
+		cfg.methodRateLimits[method] = newTokenBucket(perSecond, burst)
+	}
+}
+
+// WithMaxInFlight is an option that bounds the number of calls in flight.
+// Excess calls are queued until a slot frees up or their context is done.
+func WithMaxInFlight(limit int) Option {
+	return func(cfg *config) {
+		cfg.maxInFlight = limit
+	}
+}
+
+// WithRejectExcessCalls is an option that makes calls exceeding the limit set with
+// WithMaxInFlight fail immediately with ErrTooManyInFlight instead of being queued.
+func WithRejectExcessCalls() Option {
+	return func(cfg *config) {
+		cfg.rejectExcess = true
+	}
+}
+
+// WithTransport is an option that replaces the transport connecting the client to
+// the nodes, e.g. with HTTPTransport or a TransportFunc serving requests in memory.
+// By default, HTTP or WebSocket is used based on the scheme of the address.
+func WithTransport(transport Transport) Option {
+	return func(cfg *config) {
+		cfg.transport = transport
+	}
+}
+
+// WithHeader is an option that sets a header sent along with every request,
+// e.g. the API key required by a gateway in front of the node.
+func WithHeader(key, value string) Option {
+	return func(cfg *config) {
+		cfg.header.Set(key, value)
+	}
+}
+
+// WithUserAgent is an option that sets the User-Agent header sent along with every request.
+func WithUserAgent(userAgent string) Option {
+	return WithHeader("User-Agent", userAgent)
+}
+
+// WithCallHook is an option that registers a hook notified about the method, params,
+// results, duration and error of every request made to a node, e.g. to dump the traffic
+// for debugging or feed it into custom telemetry.
+func WithCallHook(hook CallHook) Option {
+	return func(cfg *config) {
+		cfg.callHooks = append(cfg.callHooks, hook)
+	}
+}
+
+// WithPrometheus is an option that records per-method call and error counts, latencies
+// and payload sizes as well as the number of re-created subscriptions in the given metrics.
+// Payload sizes are counted for requests sent over HTTP, but not over WebSocket connections.
+func WithPrometheus(metrics *PrometheusMetrics) Option {
+	return func(cfg *config) {
+		cfg.prometheus = metrics
+		cfg.callHooks = append(cfg.callHooks, metrics.observeCall)
+	}
+}
+
+// WithTracerProvider is an option that records an OpenTelemetry span for every call,
+// carrying the method, the height parameters and the size of the result, as well as for
+// every item delivered by subscriptions. Spans are parented by the span of the caller's context.
+func WithTracerProvider(tp trace.TracerProvider) Option {
+	return func(cfg *config) {
+		cfg.tracerProvider = tp
+	}
+}
+
+// WithLogger is an option that sets the logger recording the lifecycle of connections,
+// retries, slow calls and subscription errors. By default, nothing is logged.
+func WithLogger(logger *slog.Logger) Option {
+	return func(cfg *config) {
+		cfg.logger = logger
+	}
+}
+
+// WithSlowCallThreshold is an option that logs a warning for every call taking longer than threshold.
+func WithSlowCallThreshold(threshold time.Duration) Option {
+	return func(cfg *config) {
+		cfg.slowCallThreshold = threshold
+	}
+}
+
+// WithRequestIDs is an option that attaches a request ID to every call, taken from the context
+// if set with WithRequestID and generated otherwise. The ID is sent in the RequestIDHeader of
+// requests made over HTTP, added to the records of the logger, available to call hooks through
+// RequestID and carried by errors as RequestIDError. WebSocket connections send no per-request
+// headers, so the ID isn't visible to the node for calls made over them.
+func WithRequestIDs() Option {
+	return func(cfg *config) {
+		cfg.requestIDs = true
+	}
+}
+
+// WithStrictDecoding is an option that makes calls fail with ErrStrictDecoding if the results
+// returned by the node contain fields unknown to the client or values of unexpected types,
+// surfacing protocol drift between client and node versions. Only responses received over HTTP
+// are checked, so NewClient fails for WebSocket endpoints, and custom transports other than
+// HTTPTransport aren't covered. Subscriptions and batches aren't checked.
+func WithStrictDecoding() Option {
+	return func(cfg *config) {
+		cfg.strict = true
+	}
+}
+
+// WithMaxRequestSize is an option that fails HTTP requests larger than size bytes with
+// ErrRequestTooLarge before they are sent, e.g. submissions the node would reject anyway
+// because they exceed its own limit. Over WebSocket connections, messages larger than size
+// bytes close the connection with the status CloseMessageTooBig, failing the pending calls,
+// after which the connection is re-established. Custom transports other than HTTPTransport
+// and WebSocketTransport aren't covered.
+func WithMaxRequestSize(size int64) Option {
+	return func(cfg *config) {
+		cfg.maxRequestSize = size
+	}
+}
+
+// WithMaxResponseSize is an option that fails HTTP responses larger than size bytes,
+// such as extended data squares of large blocks, with ErrResponseTooLarge.
+// By default, responses aren't limited. Over WebSocket connections, it limits the size of
+// the messages received, closing the connection as WithMaxRequestSize does. Custom
+// transports other than HTTPTransport and WebSocketTransport aren't covered.
+func WithMaxResponseSize(size int64) Option {
+	return func(cfg *config) {
+		cfg.maxResponseSize = size
+	}
+}
+
+// WithCompression is an option that asks nodes to compress their HTTP responses with gzip
+// or deflate, e.g. for remote RPC providers, as namespaced shares compress well. If
+// compressRequests is set, the bodies of HTTP requests are compressed with gzip as well,
+// which requires the node or a proxy in front of it to accept compressed requests.
+// Size limits apply to the decompressed payloads. Requests sent over WebSocket connections
+// or by custom transports other than HTTPTransport aren't compressed.
+func WithCompression(compressRequests bool) Option {
+	return func(cfg *config) {
+		cfg.compression = true
+		cfg.compressRequests = compressRequests
+	}
+}
+
+// WithKeepAlive is an option that configures the pings sent on WebSocket connections every
+// interval. A connection on which nothing, not even a pong, arrived for timeout is considered
+// dead, torn down and re-established, with its subscriptions being re-created. This prevents
+// subscriptions from hanging silently once idle connections are dropped by NATs or routers.
+// The timeout is raised to at least twice the interval. Defaults to 5s and 30s respectively.
+// Once set, the pings are timed by the clock of the client, see WithClock.
+func WithKeepAlive(interval, timeout time.Duration) Option {
+	return func(cfg *config) {
+		cfg.pingInterval = interval
+		cfg.connTimeout = max(timeout, 2*interval+1)
+	}
+}
+
+// WithStrictVersionCheck is an option that makes NewClient fail with an IncompatibleVersionError
+// if the API version of the node is outside the range supported by the client, instead of
+// logging a warning. The check requires a token granting admin permissions and is skipped otherwise.
+func WithStrictVersionCheck() Option {
+	return func(cfg *config) {
+		cfg.strictVersion = true
+	}
+}
+
+// WithAuthProvider is an option that authenticates the client with the tokens provided
+// by the AuthProvider instead of the token passed to NewClient. Once the node rejects the
+// current token, the connections are re-established with a refreshed one and the call is
+// retried once. Whether the client is read-only is decided based on the initial token.
+// Rejections are only detected for requests sent over HTTP. WebSocket connections check the
+// token once while connecting, so they keep working with an expired token until they drop,
+// after which re-connecting fails; use an http:// address to rotate tokens.
+func WithAuthProvider(provider AuthProvider) Option {
+	return func(cfg *config) {
+		cfg.auth = provider
+	}
+}
+
+// WithModules is an option that connects only the modules with the given RPC namespaces,
+// e.g. "blob" and "header". Methods of other modules fail with ErrModuleNotEnabled.
+func WithModules(names ...string) Option {
+	return func(cfg *config) {
+		cfg.modules = append(cfg.modules, names...)
+	}
+}
+
+// WithModule is an option that registers an additional module under the given RPC namespace,
+// e.g. one provided by a fork or plugin of celestia-node. The module must be a pointer to a
+// struct of function fields taking a context.Context first and returning an error last, like
+// the API structs of this package, optionally tagged with the permission they require. The
+// fields are filled on construction and calls made through them are handled like those of
+// the built-in modules.
+func WithModule(namespace string, module interface{}) Option {
+	return func(cfg *config) {
+		if cfg.extensions == nil {
+			cfg.extensions = make(map[string]interface{})
+		}
+		cfg.extensions[namespace] = module
+	}
+}
@@ -0,0 +1,112 @@
+package client
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"reflect"
+	"strings"
+
+	"github.com/filecoin-project/go-jsonrpc/auth"
+)
+
+const (
+	permWrite auth.Permission = "write"
+	permAdmin auth.Permission = "admin"
+)
+
+// ErrReadOnlyClient is returned by methods requiring write permissions when
+// the token the client was constructed with does not grant them.
+var ErrReadOnlyClient = errors.New("client: token lacks write permissions, client is read-only")
+
+// ReadOnly reports whether write methods of the client are unavailable
+// because the token does not grant write permissions.
+func (c *Client) ReadOnly() bool {
+	return c.readOnly
+}
+
+// methodPerm returns the permission required by the method, e.g. "blob.Submit",
+// or an empty one if the method doesn't exist.
+func (c *Client) methodPerm(method string) auth.Permission {
+	moduleName, methodName, ok := strings.Cut(method, ".")
+	if !ok {
+		return ""
+	}
+	module, ok := c.modules()[moduleName]
+	if !ok {
+		return ""
+	}
+	field, ok := reflect.TypeOf(module).Elem().FieldByName(methodName)
+	if !ok {
+		return ""
+	}
+	return auth.Permission(field.Tag.Get("perm"))
+}
+
+// permissionsFromToken extracts the permissions granted by a celestia-node JWT.
+// The signature of the token is not verified, as this is up to the node.
+func permissionsFromToken(token string) ([]auth.Permission, error) {
+	parts := strings.Split(token, ".")
+	if len(parts) != 3 {
+		return nil, errors.New("token is not a JWT")
+	}
+	payload, err := base64.RawURLEncoding.DecodeString(parts[1])
+	if err != nil {
+		return nil, fmt.Errorf("decoding token payload: %w", err)
+	}
+
+	var claims struct {
+		Allow []auth.Permission
+	}
+	if err := json.Unmarshal(payload, &claims); err != nil {
+		return nil, fmt.Errorf("unmarshalling token payload: %w", err)
+	}
+	return claims.Allow, nil
+}
+
+// canWrite reports whether the token grants write permissions. Tokens which
+// can't be inspected are assumed to grant them, leaving the decision to the node.
+func canWrite(token string) bool {
+	if token == "" {
+		return true
+	}
+	perms, err := permissionsFromToken(token)
+	if err != nil {
+		return true
+	}
+	for _, perm := range perms {
+		if perm == permWrite || perm == permAdmin {
+			return true
+		}
+	}
+	return false
+}
+
+// disableMethods replaces every method of the module requiring the given
+// permission with one returning err.
+func disableMethods(module interface{}, perm auth.Permission, err error) {
+	v := reflect.ValueOf(module).Elem()
+	t := v.Type()
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		if field.Type.Kind() != reflect.Func || auth.Permission(field.Tag.Get("perm")) != perm {
+			continue
+		}
+		fnType := field.Type
+		v.Field(i).Set(reflect.MakeFunc(fnType, func([]reflect.Value) []reflect.Value {
+			return errorResults(fnType, err)
+		}))
+	}
+}
+
+// errorResults builds the return values of a function of type fn
+// consisting of zero values and err as the last value.
+func errorResults(fn reflect.Type, err error) []reflect.Value {
+	out := make([]reflect.Value, fn.NumOut())
+	for i := range out {
+		out[i] = reflect.Zero(fn.Out(i))
+	}
+	out[len(out)-1] = reflect.ValueOf(&err).Elem()
+	return out
+}
@@ -0,0 +1,58 @@
+package client
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/filecoin-project/go-jsonrpc/auth"
+	"github.com/stretchr/testify/require"
+
+	"github.com/celestiaorg/celestia-openrpc/types/blob"
+	"github.com/celestiaorg/celestia-openrpc/types/share"
+)
+
+func TestCanWrite(t *testing.T) {
+	secret := []byte("secret")
+	readToken, err := MintToken(secret, []auth.Permission{"public", "read"}, 0)
+	require.NoError(t, err)
+	writeToken, err := MintToken(secret, []auth.Permission{"public", "read", "write"}, 0)
+	require.NoError(t, err)
+	adminToken, err := MintToken(secret, []auth.Permission{"admin"}, time.Hour)
+	require.NoError(t, err)
+
+	require.False(t, canWrite(readToken))
+	require.True(t, canWrite(writeToken))
+	require.True(t, canWrite(adminToken))
+	// tokens which can't be inspected are left to the node
+	require.True(t, canWrite(""))
+	require.True(t, canWrite("not-a-jwt"))
+	require.True(t, canWrite("a.!!!.c"))
+}
+
+func TestDisableMethods(t *testing.T) {
+	api := &blob.API{
+		Submit: func(context.Context, []*blob.Blob, *blob.SubmitOptions) (uint64, error) {
+			return 1, nil
+		},
+		GetAll: func(context.Context, uint64, []share.Namespace) ([]*blob.Blob, error) {
+			return nil, nil
+		},
+	}
+	disableMethods(api, permWrite, ErrReadOnlyClient)
+
+	_, err := api.Submit(context.Background(), nil, nil)
+	require.ErrorIs(t, err, ErrReadOnlyClient)
+	_, err = api.GetAll(context.Background(), 1, nil)
+	require.NoError(t, err)
+}
+
+func TestMethodPerm(t *testing.T) {
+	c := &Client{}
+	require.Equal(t, permWrite, c.methodPerm("blob.Submit"))
+	require.Equal(t, auth.Permission("read"), c.methodPerm("header.GetByHeight"))
+	require.Equal(t, permAdmin, c.methodPerm("node.Info"))
+	require.Empty(t, c.methodPerm("blob.Unknown"))
+	require.Empty(t, c.methodPerm("unknown.Method"))
+	require.Empty(t, c.methodPerm("blob"))
+}
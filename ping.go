@@ -0,0 +1,55 @@
+package client
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/celestiaorg/celestia-openrpc/types/node"
+)
+
+// ErrNodeNotReady is returned by Ping if the node is reachable but its RPC isn't ready yet.
+var ErrNodeNotReady = errors.New("node is not ready")
+
+// PingResult describes the node as observed by Ping.
+type PingResult struct {
+	// Latency is the round trip time of the readiness check.
+	Latency time.Duration
+	// Type and APIVersion describe the node. They are only known if the token grants
+	// admin permissions, as required by node.Info.
+	Type       node.Type
+	APIVersion string
+	// Height is the height the node is synced to, while NetworkHeight is the height
+	// of the network head as known to the node.
+	Height        uint64
+	NetworkHeight uint64
+	// Synced reports whether the node caught up with the network head.
+	Synced bool
+}
+
+// Ping verifies the connection to the node, the validity of the token and the
+// responsiveness of the node in one call, e.g. for readiness probes.
+func (c *Client) Ping(ctx context.Context) (*PingResult, error) {
+	start := time.Now()
+	ready, err := c.Node.Ready(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("checking readiness: %w", err)
+	}
+	res := &PingResult{Latency: time.Since(start)}
+	if !ready {
+		return res, ErrNodeNotReady
+	}
+
+	state, err := c.Header.SyncState(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("getting sync state: %w", err)
+	}
+	res.Height, res.NetworkHeight, res.Synced = state.Height, state.ToHeight, state.Finished()
+
+	// node information is only available to admins, so failing to get it isn't fatal
+	if info, err := c.Node.Info(ctx); err == nil {
+		res.Type, res.APIVersion = info.Type, info.APIVersion
+	}
+	return res, nil
+}
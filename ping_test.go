@@ -0,0 +1,62 @@
+package client
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/celestiaorg/celestia-openrpc/types/node"
+	"github.com/celestiaorg/go-header/sync"
+)
+
+// pingClient returns a client whose node reports the readiness and the sync state,
+// and whose node information is only available if admin is set.
+func pingClient(ready bool, state sync.State, admin bool) *Client {
+	c := &Client{}
+	c.Node.Ready = func(context.Context) (bool, error) {
+		return ready, nil
+	}
+	c.Node.Info = func(context.Context) (node.Info, error) {
+		if !admin {
+			return node.Info{}, errors.New("missing permission")
+		}
+		return node.Info{Type: node.Type(1), APIVersion: "v0.13.0"}, nil
+	}
+	c.Header.SyncState = func(context.Context) (sync.State, error) {
+		return state, nil
+	}
+	return c
+}
+
+func TestPing(t *testing.T) {
+	res, err := pingClient(true, sync.State{Height: 10, ToHeight: 10}, true).Ping(context.Background())
+	require.NoError(t, err)
+	require.True(t, res.Synced)
+	require.EqualValues(t, 10, res.Height)
+	require.Equal(t, node.Type(1), res.Type)
+	require.Equal(t, "v0.13.0", res.APIVersion)
+
+	// node information is optional
+	res, err = pingClient(true, sync.State{Height: 5, ToHeight: 10}, false).Ping(context.Background())
+	require.NoError(t, err)
+	require.False(t, res.Synced)
+	require.EqualValues(t, 10, res.NetworkHeight)
+	require.Empty(t, res.APIVersion)
+}
+
+func TestPingNotReady(t *testing.T) {
+	res, err := pingClient(false, sync.State{}, true).Ping(context.Background())
+	require.ErrorIs(t, err, ErrNodeNotReady)
+	require.NotNil(t, res)
+}
+
+func TestPingUnreachable(t *testing.T) {
+	c := pingClient(true, sync.State{}, true)
+	c.Node.Ready = func(context.Context) (bool, error) {
+		return false, errConnection
+	}
+	_, err := c.Ping(context.Background())
+	require.ErrorIs(t, err, errConnection)
+}
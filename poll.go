@@ -0,0 +1,181 @@
+package client
+
+import (
+	"context"
+	"errors"
+	"math/rand"
+	"time"
+
+	"github.com/celestiaorg/celestia-openrpc/types/blob"
+	"github.com/celestiaorg/celestia-openrpc/types/share"
+)
+
+const (
+	defaultPollInterval    = 6 * time.Second
+	defaultMinPollInterval = 500 * time.Millisecond
+	defaultMaxPollInterval = 30 * time.Second
+	defaultPollJitter      = 0.2
+)
+
+// PollOption is the functional option that is applied to the namespace poller
+// to configure its parameters.
+type PollOption func(cfg *pollConfig)
+
+type pollConfig struct {
+	startHeight uint64
+	interval    time.Duration
+	minInterval time.Duration
+	maxInterval time.Duration
+	jitter      float64
+	clock       Clock
+}
+
+// WithPollStartHeight is an option that allows to specify the first height to be polled.
+// By default, polling starts right after the current head of the node.
+func WithPollStartHeight(height uint64) PollOption {
+	return func(cfg *pollConfig) {
+		cfg.startHeight = height
+	}
+}
+
+// WithPollInterval is an option that allows to specify the initial interval between polls
+// and the bounds the interval adapts within according to the observed block time.
+func WithPollInterval(initial, minInterval, maxInterval time.Duration) PollOption {
+	return func(cfg *pollConfig) {
+		cfg.interval = initial
+		cfg.minInterval = minInterval
+		cfg.maxInterval = maxInterval
+	}
+}
+
+// WithPollJitter is an option that allows to specify the fraction of the interval
+// the polls are randomly spread by, so multiple pollers don't hit the node at once.
+func WithPollJitter(jitter float64) PollOption {
+	return func(cfg *pollConfig) {
+		cfg.jitter = jitter
+	}
+}
+
+// PollBlobs follows the given namespace by polling the node for new heights.
+// It is a fallback for nodes without working subscriptions and exposes the same
+// channel interface as blob.API.Subscribe: a response is delivered for every new
+// height, even if it contains no blobs. The channel is closed once ctx is done.
+//
+// The node is only asked for blobs once its head advances past the last seen height,
+// and the polling interval adapts to the block time observed from the headers.
+// It is only backed off while the node can't be reached or fails to serve the blobs.
+func (c *Client) PollBlobs(
+	ctx context.Context,
+	namespace share.Namespace,
+	opts ...PollOption,
+) (<-chan *blob.SubscriptionResponse, error) {
+	if err := namespace.ValidateForBlob(); err != nil {
+		return nil, err
+	}
+
+	cfg := &pollConfig{
+		interval:    defaultPollInterval,
+		minInterval: defaultMinPollInterval,
+		maxInterval: defaultMaxPollInterval,
+		jitter:      defaultPollJitter,
+		clock:       c.clock,
+	}
+	if cfg.clock == nil {
+		cfg.clock = realClock{}
+	}
+	for _, opt := range opts {
+		opt(cfg)
+	}
+
+	next := cfg.startHeight
+	if next == 0 {
+		head, err := c.Header.LocalHead(ctx)
+		if err != nil {
+			return nil, err
+		}
+		next = head.Height() + 1
+	}
+
+	out := make(chan *blob.SubscriptionResponse)
+	go c.poll(ctx, namespace, next, cfg, out)
+	return out, nil
+}
+
+func (c *Client) poll(
+	ctx context.Context,
+	namespace share.Namespace,
+	next uint64,
+	cfg *pollConfig,
+	out chan<- *blob.SubscriptionResponse,
+) {
+	defer close(out)
+
+	var (
+		// blockInterval is the interval adapted to the observed block time, while
+		// interval is the one of the next poll, which is backed off on errors
+		blockInterval = cfg.interval
+		interval      = cfg.interval
+		lastHeight    uint64
+		lastTime      time.Time
+	)
+	for {
+		timer := cfg.clock.NewTimer(jittered(interval, cfg.jitter))
+		select {
+		case <-timer.C():
+		case <-ctx.Done():
+			timer.Stop()
+			return
+		}
+
+		head, err := c.Header.LocalHead(ctx)
+		if err != nil {
+			// the node is unreachable, so back off
+			interval = clamp(interval*2, cfg.minInterval, cfg.maxInterval)
+			continue
+		}
+		if head.Height() < next {
+			// the next block isn't there yet, keep polling at the block time
+			interval = blockInterval
+			continue
+		}
+
+		// adapt the interval to the block time observed between two heads
+		if lastHeight != 0 && head.Height() > lastHeight {
+			//nolint:gosec
+			blockTime := head.Time().Sub(lastTime) / time.Duration(head.Height()-lastHeight)
+			blockInterval = clamp(blockTime, cfg.minInterval, cfg.maxInterval)
+		}
+		lastHeight, lastTime = head.Height(), head.Time()
+
+		lastInterval := interval
+		interval = blockInterval
+		for ; next <= head.Height(); next++ {
+			blobs, err := c.Blob.GetAll(ctx, next, []share.Namespace{namespace})
+			if err != nil && !errors.Is(err, ErrBlobNotFound) {
+				// the node failed to serve the height, so back off and retry it on the next poll
+				interval = clamp(lastInterval*2, cfg.minInterval, cfg.maxInterval)
+				break
+			}
+
+			select {
+			case out <- &blob.SubscriptionResponse{Blobs: blobs, Height: next}:
+			case <-ctx.Done():
+				return
+			}
+		}
+	}
+}
+
+// jittered randomly spreads d by the given fraction in both directions.
+func jittered(d time.Duration, jitter float64) time.Duration {
+	if jitter <= 0 {
+		return d
+	}
+	//nolint:gosec
+	delta := (rand.Float64()*2 - 1) * jitter * float64(d)
+	return d + time.Duration(delta)
+}
+
+func clamp(d, minD, maxD time.Duration) time.Duration {
+	return min(max(d, minD), maxD)
+}
@@ -0,0 +1,156 @@
+package client
+
+import (
+	"context"
+	"errors"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/celestiaorg/celestia-openrpc/types/blob"
+	"github.com/celestiaorg/celestia-openrpc/types/core"
+	"github.com/celestiaorg/celestia-openrpc/types/header"
+	"github.com/celestiaorg/celestia-openrpc/types/share"
+)
+
+func testHeader(height uint64, at time.Time) *header.ExtendedHeader {
+	//nolint:gosec
+	return &header.ExtendedHeader{
+		RawHeader: header.RawHeader{Height: int64(height), Time: at},
+		Commit:    &core.Commit{Height: int64(height)},
+	}
+}
+
+// drain discards the items of the channel until it is closed.
+func drain[T any](ch <-chan T) {
+	for {
+		if _, ok := <-ch; !ok {
+			return
+		}
+	}
+}
+
+func TestPollBlobs(t *testing.T) {
+	b := testBlob(t, "hello")
+	namespace := share.Namespace(b.Namespace().Bytes())
+
+	var head atomic.Uint64
+	head.Store(10)
+	c := &Client{}
+	c.Header.LocalHead = func(context.Context) (*header.ExtendedHeader, error) {
+		return testHeader(head.Load(), time.Time{}), nil
+	}
+	c.Blob.GetAll = func(_ context.Context, height uint64, _ []share.Namespace) ([]*blob.Blob, error) {
+		if height == 12 {
+			return []*blob.Blob{b}, nil
+		}
+		return nil, blob.ErrBlobNotFound
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	sub, err := c.PollBlobs(ctx, namespace,
+		WithPollInterval(5*time.Millisecond, 5*time.Millisecond, 50*time.Millisecond),
+		WithPollJitter(0),
+	)
+	require.NoError(t, err)
+
+	head.Store(12)
+	resp := <-sub
+	require.EqualValues(t, 11, resp.Height)
+	require.Empty(t, resp.Blobs)
+	resp = <-sub
+	require.EqualValues(t, 12, resp.Height)
+	require.Len(t, resp.Blobs, 1)
+
+	cancel()
+	drain(sub)
+}
+
+func TestPollBlobsKeepsIntervalBetweenBlocks(t *testing.T) {
+	b := testBlob(t, "hello")
+	var polls atomic.Int64
+	c := &Client{}
+	c.Header.LocalHead = func(context.Context) (*header.ExtendedHeader, error) {
+		polls.Add(1)
+		return testHeader(10, time.Time{}), nil
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	sub, err := c.PollBlobs(ctx, share.Namespace(b.Namespace().Bytes()),
+		WithPollStartHeight(11),
+		WithPollInterval(5*time.Millisecond, 5*time.Millisecond, time.Second),
+		WithPollJitter(0),
+	)
+	require.NoError(t, err)
+
+	time.Sleep(300 * time.Millisecond)
+	cancel()
+	drain(sub)
+	// backing off while waiting for the next block would have allowed only a handful of polls
+	require.Greater(t, polls.Load(), int64(15))
+}
+
+func TestPollBlobsBacksOffOnErrors(t *testing.T) {
+	b := testBlob(t, "hello")
+	var polls atomic.Int64
+	c := &Client{}
+	c.Header.LocalHead = func(context.Context) (*header.ExtendedHeader, error) {
+		polls.Add(1)
+		return nil, errors.New("connection refused")
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	sub, err := c.PollBlobs(ctx, share.Namespace(b.Namespace().Bytes()),
+		WithPollStartHeight(11),
+		WithPollInterval(5*time.Millisecond, 5*time.Millisecond, time.Second),
+		WithPollJitter(0),
+	)
+	require.NoError(t, err)
+
+	time.Sleep(300 * time.Millisecond)
+	cancel()
+	drain(sub)
+	// 10ms, 20ms, 40ms, 80ms, 160ms
+	require.Less(t, polls.Load(), int64(8))
+}
+
+func TestPollBlobsBacksOffOnBlobErrors(t *testing.T) {
+	b := testBlob(t, "hello")
+	clock := &fakeClock{}
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	var polls int
+	c := &Client{clock: clock}
+	c.Header.LocalHead = func(context.Context) (*header.ExtendedHeader, error) {
+		return testHeader(20, time.Time{}), nil
+	}
+	c.Blob.GetAll = func(_ context.Context, height uint64, _ []share.Namespace) ([]*blob.Blob, error) {
+		// the height failing to be served is retried
+		require.EqualValues(t, 11, height)
+		if polls++; polls == 4 {
+			cancel()
+		}
+		return nil, errors.New("connection refused")
+	}
+
+	sub, err := c.PollBlobs(ctx, share.Namespace(b.Namespace().Bytes()),
+		WithPollStartHeight(11),
+		WithPollInterval(time.Hour, time.Hour, 4*time.Hour),
+		WithPollJitter(0),
+	)
+	require.NoError(t, err)
+	drain(sub)
+	require.Equal(t, []time.Duration{time.Hour, 2 * time.Hour, 4 * time.Hour, 4 * time.Hour}, clock.delays[:4])
+}
+
+func TestJittered(t *testing.T) {
+	require.Equal(t, time.Second, jittered(time.Second, 0))
+	for i := 0; i < 100; i++ {
+		d := jittered(time.Second, 0.2)
+		require.GreaterOrEqual(t, d, 800*time.Millisecond)
+		require.LessOrEqual(t, d, 1200*time.Millisecond)
+	}
+}
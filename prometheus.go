@@ -0,0 +1,153 @@
+package client
+
+import (
+	"context"
+	"io"
+	"net/http"
+
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+// PrometheusMetrics collects metrics about the calls made through the client.
+// It implements prometheus.Collector and has to be registered by the caller.
+type PrometheusMetrics struct {
+	calls           *prometheus.CounterVec
+	errors          *prometheus.CounterVec
+	latency         *prometheus.HistogramVec
+	requestSize     *prometheus.HistogramVec
+	responseSize    *prometheus.HistogramVec
+	resubscriptions *prometheus.CounterVec
+}
+
+// NewPrometheusMetrics creates the collectors with names prefixed by namespace, e.g. "rollkit".
+func NewPrometheusMetrics(namespace string) *PrometheusMetrics {
+	sizeBuckets := prometheus.ExponentialBuckets(64, 4, 10)
+	return &PrometheusMetrics{
+		calls: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: namespace,
+			Subsystem: "celestia_rpc",
+			Name:      "calls_total",
+			Help:      "Number of calls made to the node.",
+		}, []string{"method"}),
+		errors: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: namespace,
+			Subsystem: "celestia_rpc",
+			Name:      "errors_total",
+			Help:      "Number of calls to the node which failed.",
+		}, []string{"method"}),
+		latency: prometheus.NewHistogramVec(prometheus.HistogramOpts{
+			Namespace: namespace,
+			Subsystem: "celestia_rpc",
+			Name:      "call_duration_seconds",
+			Help:      "Duration of calls made to the node.",
+			Buckets:   prometheus.DefBuckets,
+		}, []string{"method"}),
+		requestSize: prometheus.NewHistogramVec(prometheus.HistogramOpts{
+			Namespace: namespace,
+			Subsystem: "celestia_rpc",
+			Name:      "request_size_bytes",
+			Help:      "Size of the HTTP request bodies of calls made to the node.",
+			Buckets:   sizeBuckets,
+		}, []string{"method"}),
+		responseSize: prometheus.NewHistogramVec(prometheus.HistogramOpts{
+			Namespace: namespace,
+			Subsystem: "celestia_rpc",
+			Name:      "response_size_bytes",
+			Help:      "Size of the HTTP response bodies of calls made to the node.",
+			Buckets:   sizeBuckets,
+		}, []string{"method"}),
+		resubscriptions: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: namespace,
+			Subsystem: "celestia_rpc",
+			Name:      "resubscriptions_total",
+			Help:      "Number of subscriptions re-created after the connection dropped.",
+		}, []string{"method"}),
+	}
+}
+
+func (m *PrometheusMetrics) collectors() []prometheus.Collector {
+	return []prometheus.Collector{m.calls, m.errors, m.latency, m.requestSize, m.responseSize, m.resubscriptions}
+}
+
+// Describe implements prometheus.Collector.
+func (m *PrometheusMetrics) Describe(ch chan<- *prometheus.Desc) {
+	for _, c := range m.collectors() {
+		c.Describe(ch)
+	}
+}
+
+// Collect implements prometheus.Collector.
+func (m *PrometheusMetrics) Collect(ch chan<- prometheus.Metric) {
+	for _, c := range m.collectors() {
+		c.Collect(ch)
+	}
+}
+
+// observeCall is a CallHook recording the outcome of a call.
+func (m *PrometheusMetrics) observeCall(_ context.Context, info CallInfo) {
+	m.calls.WithLabelValues(info.Method).Inc()
+	m.latency.WithLabelValues(info.Method).Observe(info.Duration.Seconds())
+	if info.Err != nil {
+		m.errors.WithLabelValues(info.Method).Inc()
+	}
+}
+
+// methodKey is the context key passing the method of a call to its HTTP round trips.
+type methodKey struct{}
+
+// labelRequests is an interceptor passing the method of the call to the HTTP round trips
+// made for it, so their sizes can be attributed to the method.
+func labelRequests(ctx context.Context, c *call, next invoker) ([]interface{}, error) {
+	return next(context.WithValue(ctx, methodKey{}, c.name()), c)
+}
+
+// roundTripper returns a round tripper recording the sizes of the request and response
+// bodies of calls, as labelled by labelRequests.
+func (m *PrometheusMetrics) roundTripper(next http.RoundTripper) http.RoundTripper {
+	return &sizeRoundTripper{metrics: m, next: next}
+}
+
+type sizeRoundTripper struct {
+	metrics *PrometheusMetrics
+	next    http.RoundTripper
+}
+
+func (rt *sizeRoundTripper) RoundTrip(req *http.Request) (*http.Response, error) {
+	method, ok := req.Context().Value(methodKey{}).(string)
+	if !ok {
+		return rt.next.RoundTrip(req)
+	}
+	if req.ContentLength >= 0 {
+		rt.metrics.requestSize.WithLabelValues(method).Observe(float64(req.ContentLength))
+	}
+
+	resp, err := rt.next.RoundTrip(req)
+	if err != nil {
+		return nil, err
+	}
+	resp.Body = &countingBody{ReadCloser: resp.Body, observer: rt.metrics.responseSize.WithLabelValues(method)}
+	return resp, nil
+}
+
+// countingBody counts the bytes read from the body and records them once it's closed.
+type countingBody struct {
+	io.ReadCloser
+	observer prometheus.Observer
+	read     int64
+}
+
+func (b *countingBody) Read(p []byte) (int, error) {
+	n, err := b.ReadCloser.Read(p)
+	b.read += int64(n)
+	return n, err
+}
+
+func (b *countingBody) Close() error {
+	b.observer.Observe(float64(b.read))
+	return b.ReadCloser.Close()
+}
+
+// observeResubscription records a subscription being re-created.
+func (m *PrometheusMetrics) observeResubscription(method string) {
+	m.resubscriptions.WithLabelValues(method).Inc()
+}
@@ -0,0 +1,79 @@
+package client
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/stretchr/testify/require"
+)
+
+// observations records the values observed by a prometheus.Observer.
+type observations []float64
+
+func (o *observations) Observe(v float64) {
+	*o = append(*o, v)
+}
+
+func TestPrometheusMetrics(t *testing.T) {
+	srv := testNode(t, func(_ *http.Request, method string, _ json.RawMessage) (interface{}, error) {
+		if method != "node.Ready" {
+			return nil, errors.New("method not found")
+		}
+		return true, nil
+	})
+
+	metrics := NewPrometheusMetrics("test")
+	c, err := NewClient(context.Background(), srv.URL, "", WithPrometheus(metrics))
+	require.NoError(t, err)
+	defer c.Close()
+
+	_, err = c.Node.Ready(context.Background())
+	require.NoError(t, err)
+	_, err = c.Node.LogLevelSet(context.Background(), "rpc", "debug")
+	require.Error(t, err)
+
+	require.InDelta(t, 1, testutil.ToFloat64(metrics.calls.WithLabelValues("node.Ready")), 0)
+	require.InDelta(t, 0, testutil.ToFloat64(metrics.errors.WithLabelValues("node.Ready")), 0)
+	require.InDelta(t, 1, testutil.ToFloat64(metrics.errors.WithLabelValues("node.LogLevelSet")), 0)
+	// the sizes of the bodies are counted at the transport for every request
+	require.Equal(t, 3, testutil.CollectAndCount(metrics.requestSize))
+	require.Equal(t, 3, testutil.CollectAndCount(metrics.responseSize))
+}
+
+func TestCountingBody(t *testing.T) {
+	var sizes observations
+	body := &countingBody{ReadCloser: io.NopCloser(strings.NewReader(`{"result":true}`)), observer: &sizes}
+	_, err := io.ReadAll(body)
+	require.NoError(t, err)
+	require.Empty(t, sizes)
+
+	require.NoError(t, body.Close())
+	require.Equal(t, observations{15}, sizes)
+}
+
+func TestSizeRoundTripperSkipsUnlabelled(t *testing.T) {
+	metrics := NewPrometheusMetrics("test")
+	rt := metrics.roundTripper(roundTripperFunc(func(*http.Request) (*http.Response, error) {
+		return &http.Response{StatusCode: http.StatusOK, Body: io.NopCloser(strings.NewReader("{}"))}, nil
+	}))
+
+	req, err := http.NewRequest(http.MethodPost, "http://localhost", strings.NewReader("{}"))
+	require.NoError(t, err)
+	resp, err := rt.RoundTrip(req)
+	require.NoError(t, err)
+	require.NoError(t, resp.Body.Close())
+	require.Zero(t, testutil.CollectAndCount(metrics.requestSize))
+}
+
+// roundTripperFunc is an http.RoundTripper calling the function.
+type roundTripperFunc func(*http.Request) (*http.Response, error)
+
+func (f roundTripperFunc) RoundTrip(req *http.Request) (*http.Response, error) {
+	return f(req)
+}
@@ -0,0 +1,276 @@
+package client
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"reflect"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/filecoin-project/go-jsonrpc"
+
+	clientbuilder "github.com/celestiaorg/celestia-openrpc/builder"
+)
+
+var (
+	contextType = reflect.TypeOf((*context.Context)(nil)).Elem()
+	errorType   = reflect.TypeOf((*error)(nil)).Elem()
+)
+
+// call describes a single invocation of a module method.
+type call struct {
+	module string
+	method string
+	perm   string
+	// args holds the arguments of the call, excluding the context.
+	args []interface{}
+	// subscription reports whether the method returns a subscription channel,
+	// which lives as long as the context of the call.
+	subscription bool
+}
+
+// name returns the fully-qualified name of the method, e.g. "header.GetByHeight".
+func (c *call) name() string {
+	return c.module + "." + c.method
+}
+
+// invoker performs the call and returns its results, excluding the error.
+type invoker func(ctx context.Context, c *call) ([]interface{}, error)
+
+// interceptor intercepts calls on their way to the node. It is responsible
+// for passing the call on to next.
+type interceptor func(ctx context.Context, c *call, next invoker) ([]interface{}, error)
+
+// endpoint is a single node the client is connected to.
+type endpoint struct {
+	addr      string
+	transport Transport
+	opts      []jsonrpc.Option
+	// types holds the types of the modules keyed by their RPC namespace.
+	types   map[string]reflect.Type
+	stats   endpointStats
+	breaker *circuitBreaker
+	// httpClient sends the requests made outside of the transport, such as batches.
+	// If nil, http.DefaultClient or the client of the unix socket is used.
+	httpClient *http.Client
+	// unix is the HTTP client of the unix socket the endpoint is served on, if any, shared by
+	// its connections. Its idle connections are closed along with the endpoint.
+	unix *http.Client
+	// events receives the Connected and Disconnected events of the endpoint, if set.
+	events func(ConnectionEvent)
+	// down is set once a call failed because of the connection, until one succeeds.
+	down atomic.Bool
+
+	mu sync.RWMutex
+	// modules holds the raw modules bound to the endpoint keyed by their RPC namespace.
+	modules map[string]interface{}
+	// header holds the headers sent along with every request, such as the auth token.
+	header http.Header
+	closer clientbuilder.MultiClientCloser
+}
+
+// dialEndpoint connects the given modules to the node at addr using the transport.
+func dialEndpoint(
+	ctx context.Context,
+	addr string,
+	modules map[string]interface{},
+	header http.Header,
+	transport Transport,
+	opts []jsonrpc.Option,
+) (*endpoint, error) {
+	ep := &endpoint{addr: addr, transport: transport, opts: opts, types: make(map[string]reflect.Type, len(modules))}
+	for name, module := range modules {
+		ep.types[name] = reflect.TypeOf(module).Elem()
+	}
+	if path, ok := unixSocketPath(addr); ok {
+		ep.unix = newUnixHTTPClient(path)
+	}
+	return ep, ep.connect(ctx, header)
+}
+
+// connect (re-)connects the modules of the endpoint sending the given headers along
+// with every request, closing the previous connections once the new ones are established.
+func (ep *endpoint) connect(ctx context.Context, header http.Header) error {
+	var closer clientbuilder.MultiClientCloser
+	modules := make(map[string]interface{}, len(ep.types))
+	for name, typ := range ep.types {
+		raw := reflect.New(typ).Interface()
+		c, err := ep.transport.Dial(withUnixClient(ctx, ep.unix), ep.addr, name, raw, header, ep.opts...)
+		if err != nil {
+			closer.CloseAll()
+			return err
+		}
+		closer.Register(c)
+		modules[name] = raw
+	}
+
+	ep.mu.Lock()
+	prev := ep.closer
+	ep.modules, ep.header, ep.closer = modules, header, closer
+	ep.mu.Unlock()
+	prev.CloseAll()
+	return nil
+}
+
+// module returns the raw module of the given RPC namespace.
+func (ep *endpoint) module(name string) (interface{}, bool) {
+	ep.mu.RLock()
+	defer ep.mu.RUnlock()
+	module, ok := ep.modules[name]
+	return module, ok
+}
+
+// requestHeader returns the headers sent along with every request.
+func (ep *endpoint) requestHeader() http.Header {
+	ep.mu.RLock()
+	defer ep.mu.RUnlock()
+	return ep.header
+}
+
+// close closes the connections to the endpoint.
+func (ep *endpoint) close() {
+	ep.mu.Lock()
+	defer ep.mu.Unlock()
+	ep.closer.CloseAll()
+	ep.closer = clientbuilder.MultiClientCloser{}
+	if ep.unix != nil {
+		ep.unix.CloseIdleConnections()
+	}
+}
+
+// endpointKey is the context key pinning a call to an endpoint.
+type endpointKey struct{}
+
+// withEndpoint pins all calls made with the returned context to the endpoint with the given index.
+func withEndpoint(ctx context.Context, idx int) context.Context {
+	return context.WithValue(ctx, endpointKey{}, idx)
+}
+
+// pinnedEndpoint returns the index of the endpoint the context is pinned to, if any.
+func pinnedEndpoint(ctx context.Context) (int, bool) {
+	idx, ok := ctx.Value(endpointKey{}).(int)
+	return idx, ok
+}
+
+// proxy dispatches the calls made on the modules of the client through
+// the interceptors to one of the endpoints.
+type proxy struct {
+	endpoints []*endpoint
+	invoke    invoker
+}
+
+func newProxy(endpoints []*endpoint) *proxy {
+	p := &proxy{endpoints: endpoints}
+	p.invoke = p.dispatch
+	return p
+}
+
+// use routes all calls through the interceptors. The first interceptor is the outermost one.
+func (p *proxy) use(interceptors ...interceptor) {
+	for i := len(interceptors) - 1; i >= 0; i-- {
+		next, intercept := p.invoke, interceptors[i]
+		p.invoke = func(ctx context.Context, c *call) ([]interface{}, error) {
+			return intercept(ctx, c, next)
+		}
+	}
+}
+
+// dispatch performs the call against the pinned or the most preferred endpoint.
+func (p *proxy) dispatch(ctx context.Context, c *call) ([]interface{}, error) {
+	idx, ok := pinnedEndpoint(ctx)
+	if !ok {
+		idx = p.rank()[0]
+	}
+	if idx < 0 || idx >= len(p.endpoints) {
+		return nil, fmt.Errorf("endpoint %d does not exist", idx)
+	}
+
+	ep := p.endpoints[idx]
+	module, ok := ep.module(c.module)
+	if !ok {
+		return nil, fmt.Errorf("%s: %w", c.name(), ErrModuleNotEnabled)
+	}
+	fn := reflect.ValueOf(module).Elem().FieldByName(c.method)
+	if !fn.IsValid() || fn.IsNil() {
+		return nil, fmt.Errorf("method %s is not available", c.name())
+	}
+
+	in := make([]reflect.Value, 0, len(c.args)+1)
+	in = append(in, reflect.ValueOf(ctx))
+	for i, arg := range c.args {
+		in = append(in, valueOf(arg, fn.Type().In(i+1)))
+	}
+	ticket, ok := ep.breaker.allow()
+	if !ok {
+		return nil, fmt.Errorf("%s: %w", ep.addr, ErrCircuitOpen)
+	}
+	start := time.Now()
+	out := fn.Call(in)
+
+	results := make([]interface{}, len(out)-1)
+	for i := range results {
+		results[i] = out[i].Interface()
+	}
+	err, _ := out[len(out)-1].Interface().(error)
+	ep.stats.observe(time.Since(start), err)
+	ep.breaker.record(ticket, err)
+	ep.trackConnection(err)
+	return results, err
+}
+
+// bind fills the methods of the module with ones dispatching through the proxy.
+func (p *proxy) bind(name string, module interface{}) {
+	v := reflect.ValueOf(module).Elem()
+	t := v.Type()
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		fnType := field.Type
+		if fnType.Kind() != reflect.Func || fnType.NumIn() == 0 || fnType.In(0) != contextType ||
+			fnType.NumOut() == 0 || fnType.Out(fnType.NumOut()-1) != errorType {
+			continue
+		}
+
+		method, perm := field.Name, field.Tag.Get("perm")
+		v.Field(i).Set(reflect.MakeFunc(fnType, func(in []reflect.Value) []reflect.Value {
+			ctx, _ := in[0].Interface().(context.Context)
+			if ctx == nil {
+				ctx = context.Background()
+			}
+			c := &call{
+				module:       name,
+				method:       method,
+				perm:         perm,
+				args:         make([]interface{}, len(in)-1),
+				subscription: fnType.Out(0).Kind() == reflect.Chan,
+			}
+			for i, arg := range in[1:] {
+				c.args[i] = arg.Interface()
+			}
+
+			results, err := p.invoke(ctx, c)
+			if err != nil || len(results) != fnType.NumOut()-1 {
+				if err == nil {
+					err = fmt.Errorf("%s: unexpected number of results", c.name())
+				}
+				return errorResults(fnType, err)
+			}
+
+			out := make([]reflect.Value, fnType.NumOut())
+			for i, res := range results {
+				out[i] = valueOf(res, fnType.Out(i))
+			}
+			out[len(out)-1] = reflect.Zero(errorType)
+			return out
+		}))
+	}
+}
+
+// valueOf converts v into a reflect.Value of type t, substituting nil with the zero value.
+func valueOf(v interface{}, t reflect.Type) reflect.Value {
+	if v == nil {
+		return reflect.Zero(t)
+	}
+	return reflect.ValueOf(v)
+}
@@ -0,0 +1,153 @@
+package client
+
+import (
+	"context"
+	"errors"
+	"sync"
+
+	"github.com/celestiaorg/celestia-openrpc/types/blob"
+	"github.com/celestiaorg/celestia-openrpc/types/state"
+)
+
+// ErrQueueClosed is returned by SubmitQueue.Submit once the queue is closed.
+var ErrQueueClosed = errors.New("client: submit queue is closed")
+
+// SubmitQueue serializes the PayForBlobs submissions of every signer. The node signs every
+// transaction with the next sequence of the account it tracks, so transactions of the same
+// signer submitted concurrently race for the same sequence and fail with "account sequence
+// mismatch". The queue hands the submissions of a signer to the node one at a time, in the
+// order they were queued, while submissions of different signers proceed concurrently.
+// Queueing doesn't wait for the submissions ahead, so the transaction in flight doesn't
+// block the callers preparing the next ones.
+type SubmitQueue struct {
+	submit func(context.Context, []*blob.Blob, *state.TxConfig) (*state.TxResponse, error)
+
+	mu      sync.Mutex
+	signers map[string]*signerQueue
+	closed  bool
+	wg      sync.WaitGroup
+}
+
+// signerQueue holds the submissions of a signer waiting for their turn.
+type signerQueue struct {
+	pending []*Submission
+	running bool
+	// next is the local sequence of the next submission queued
+	next uint64
+}
+
+// Submission is a submission queued in a SubmitQueue.
+type Submission struct {
+	// Signer identifies the account signing the transaction: its address, or its key
+	// name if no address is set. Empty for the default account of the node.
+	Signer string
+	// Sequence is the position of the submission among those of its signer, starting at
+	// zero. Submissions of a signer reach the node in the order of their sequence.
+	Sequence uint64
+
+	ctx    context.Context
+	blobs  []*blob.Blob
+	config *state.TxConfig
+	done   chan struct{}
+	resp   *state.TxResponse
+	err    error
+}
+
+// Done returns a channel closed once the submission completes.
+func (s *Submission) Done() <-chan struct{} {
+	return s.done
+}
+
+// Wait waits for the submission to complete and returns the response of the node.
+func (s *Submission) Wait(ctx context.Context) (*state.TxResponse, error) {
+	select {
+	case <-s.done:
+		return s.resp, s.err
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	}
+}
+
+// NewSubmitQueue returns a queue submitting with the client.
+func (c *Client) NewSubmitQueue() *SubmitQueue {
+	return &SubmitQueue{
+		submit: func(ctx context.Context, blobs []*blob.Blob, config *state.TxConfig) (*state.TxResponse, error) {
+			return c.State.SubmitPayForBlob(ctx, blobs, config)
+		},
+		signers: make(map[string]*signerQueue),
+	}
+}
+
+// Submit queues the submission of the blobs in a PayForBlobs transaction configured with
+// config, and returns without waiting for it. The submission is abandoned if ctx is done
+// before its turn comes.
+func (q *SubmitQueue) Submit(ctx context.Context, blobs []*blob.Blob, config *state.TxConfig) (*Submission, error) {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	if q.closed {
+		return nil, ErrQueueClosed
+	}
+
+	signer := signerOf(config)
+	sq, ok := q.signers[signer]
+	if !ok {
+		sq = &signerQueue{}
+		q.signers[signer] = sq
+	}
+	s := &Submission{
+		Signer:   signer,
+		Sequence: sq.next,
+		ctx:      ctx,
+		blobs:    blobs,
+		config:   config,
+		done:     make(chan struct{}),
+	}
+	sq.next++
+	sq.pending = append(sq.pending, s)
+	if !sq.running {
+		sq.running = true
+		q.wg.Add(1)
+		go q.run(sq)
+	}
+	return s, nil
+}
+
+// Close stops accepting submissions and waits for the queued ones to complete.
+func (q *SubmitQueue) Close() {
+	q.mu.Lock()
+	q.closed = true
+	q.mu.Unlock()
+	q.wg.Wait()
+}
+
+// run submits the pending submissions of the signer one after the other, until none are left.
+func (q *SubmitQueue) run(sq *signerQueue) {
+	defer q.wg.Done()
+	for {
+		q.mu.Lock()
+		if len(sq.pending) == 0 {
+			sq.running = false
+			q.mu.Unlock()
+			return
+		}
+		s := sq.pending[0]
+		sq.pending = sq.pending[1:]
+		q.mu.Unlock()
+
+		if s.err = s.ctx.Err(); s.err == nil {
+			s.resp, s.err = q.submit(s.ctx, s.blobs, s.config)
+		}
+		close(s.done)
+	}
+}
+
+// signerOf returns the identity of the account signing transactions configured with config.
+func signerOf(config *state.TxConfig) string {
+	if config == nil {
+		return ""
+	}
+	if config.SignerAddress() != "" {
+		return config.SignerAddress()
+	}
+	return config.KeyName()
+}
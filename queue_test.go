@@ -0,0 +1,73 @@
+package client
+
+import (
+	"context"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/celestiaorg/celestia-openrpc/types/blob"
+	"github.com/celestiaorg/celestia-openrpc/types/state"
+)
+
+func TestSubmitQueue(t *testing.T) {
+	var (
+		mu         sync.Mutex
+		inFlight   = map[string]int{}
+		concurrent bool
+		order      = map[string][]string{}
+		release    = make(chan struct{})
+		started    = make(chan string, 16)
+	)
+	c := &Client{}
+	c.State.SubmitPayForBlob = func(_ context.Context, blobs []*blob.Blob, config *state.TxConfig) (*state.TxResponse, error) {
+		signer := signerOf(config)
+		mu.Lock()
+		inFlight[signer]++
+		concurrent = concurrent || inFlight[signer] > 1
+		order[signer] = append(order[signer], string(blobs[0].Data))
+		mu.Unlock()
+
+		started <- signer
+		<-release
+
+		mu.Lock()
+		inFlight[signer]--
+		mu.Unlock()
+		return &state.TxResponse{Height: 1}, nil
+	}
+
+	q := c.NewSubmitQueue()
+	ctx := context.Background()
+	alice, bob := state.NewTxConfig(state.WithKeyName("alice")), state.NewTxConfig(state.WithKeyName("bob"))
+	var submissions []*Submission
+	for _, sub := range []struct {
+		data   string
+		config *state.TxConfig
+	}{{"a0", alice}, {"a1", alice}, {"b0", bob}, {"a2", alice}} {
+		// queueing doesn't wait for the submissions ahead
+		s, err := q.Submit(ctx, []*blob.Blob{testBlob(t, sub.data)}, sub.config)
+		require.NoError(t, err)
+		submissions = append(submissions, s)
+	}
+	require.Equal(t, []uint64{0, 1, 0, 2}, []uint64{
+		submissions[0].Sequence, submissions[1].Sequence, submissions[2].Sequence, submissions[3].Sequence,
+	})
+
+	// both signers submit at once
+	require.ElementsMatch(t, []string{"alice", "bob"}, []string{<-started, <-started})
+	close(release)
+	for _, s := range submissions {
+		resp, err := s.Wait(ctx)
+		require.NoError(t, err)
+		require.EqualValues(t, 1, resp.Height)
+	}
+	q.Close()
+	require.False(t, concurrent)
+	require.Equal(t, []string{"a0", "a1", "a2"}, order["alice"])
+	require.Equal(t, []string{"b0"}, order["bob"])
+
+	_, err := q.Submit(ctx, []*blob.Blob{testBlob(t, "late")}, alice)
+	require.ErrorIs(t, err, ErrQueueClosed)
+}
@@ -0,0 +1,74 @@
+package client
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+// tokenBucket limits the rate of events, allowing bursts of up to burst events.
+// The rate must be positive.
+type tokenBucket struct {
+	rate  float64
+	burst float64
+
+	mu     sync.Mutex
+	tokens float64
+	// last is the time tokens were last refilled at, while started is set once they were.
+	// Buckets are created along with the options, before the clock is known, so they
+	// start to refill with the first event.
+	last    time.Time
+	started bool
+}
+
+func newTokenBucket(perSecond float64, burst int) *tokenBucket {
+	b := float64(max(burst, 1))
+	return &tokenBucket{rate: perSecond, burst: b, tokens: b}
+}
+
+// wait blocks until an event is allowed to happen according to the clock or ctx is done.
+func (b *tokenBucket) wait(ctx context.Context, clock Clock) error {
+	b.mu.Lock()
+	now := clock.Now()
+	if b.started {
+		b.tokens = min(b.burst, b.tokens+now.Sub(b.last).Seconds()*b.rate)
+	}
+	b.last, b.started = now, true
+	// reserve the token upfront, so concurrent callers queue up behind each other
+	b.tokens--
+	delay := time.Duration(-b.tokens / b.rate * float64(time.Second))
+	b.mu.Unlock()
+
+	if delay <= 0 {
+		return nil
+	}
+	timer := clock.NewTimer(delay)
+	defer timer.Stop()
+	select {
+	case <-timer.C():
+		return nil
+	case <-ctx.Done():
+		b.mu.Lock()
+		b.tokens++
+		b.mu.Unlock()
+		return ctx.Err()
+	}
+}
+
+// rateLimit returns an interceptor delaying calls to keep their rate within the limits
+// of the global bucket and the bucket of the method, if any. Either may be nil.
+func rateLimit(global *tokenBucket, methods map[string]*tokenBucket, clock Clock) interceptor {
+	return func(ctx context.Context, c *call, next invoker) ([]interface{}, error) {
+		if bucket, ok := methods[c.name()]; ok {
+			if err := bucket.wait(ctx, clock); err != nil {
+				return nil, err
+			}
+		}
+		if global != nil {
+			if err := global.wait(ctx, clock); err != nil {
+				return nil, err
+			}
+		}
+		return next(ctx, c)
+	}
+}
@@ -0,0 +1,70 @@
+package client
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestTokenBucket(t *testing.T) {
+	b := newTokenBucket(100, 2)
+	ctx := context.Background()
+
+	start := time.Now()
+	require.NoError(t, b.wait(ctx, realClock{}))
+	require.NoError(t, b.wait(ctx, realClock{}))
+	require.Less(t, time.Since(start), 5*time.Millisecond)
+
+	// the burst is used up, so the next events are spaced by 10ms
+	require.NoError(t, b.wait(ctx, realClock{}))
+	require.NoError(t, b.wait(ctx, realClock{}))
+	require.GreaterOrEqual(t, time.Since(start), 15*time.Millisecond)
+}
+
+func TestTokenBucketCancelled(t *testing.T) {
+	b := newTokenBucket(1, 1)
+	require.NoError(t, b.wait(context.Background(), realClock{}))
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	require.ErrorIs(t, b.wait(ctx, realClock{}), context.DeadlineExceeded)
+	// the reserved token is returned, so the bucket isn't indebted further
+	b.mu.Lock()
+	require.Greater(t, b.tokens, -0.5)
+	b.mu.Unlock()
+}
+
+func TestRateLimitOptions(t *testing.T) {
+	cfg := newConfig(WithRateLimit(10, 5), WithMethodRateLimit("share.GetEDS", 1, 1))
+	require.NotNil(t, cfg.rateLimit)
+	require.Contains(t, cfg.methodRateLimits, "share.GetEDS")
+
+	// non-positive rates remove the limits instead of disabling the wait
+	cfg = newConfig(
+		WithRateLimit(10, 5), WithRateLimit(0, 5),
+		WithMethodRateLimit("share.GetEDS", 1, 1), WithMethodRateLimit("share.GetEDS", -1, 1),
+	)
+	require.Nil(t, cfg.rateLimit)
+	require.NotContains(t, cfg.methodRateLimits, "share.GetEDS")
+}
+
+func TestRateLimitInterceptor(t *testing.T) {
+	methods := map[string]*tokenBucket{"share.GetEDS": newTokenBucket(1, 1)}
+	intercept := rateLimit(nil, methods, realClock{})
+	next, calls := failingInvoker(0, nil)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+	eds := &call{module: "share", method: "GetEDS", perm: "read"}
+	_, err := intercept(ctx, eds, next)
+	require.NoError(t, err)
+	_, err = intercept(ctx, eds, next)
+	require.ErrorIs(t, err, context.DeadlineExceeded)
+
+	// other methods aren't limited
+	_, err = intercept(context.Background(), &call{module: "header", method: "NetworkHead", perm: "read"}, next)
+	require.NoError(t, err)
+	require.Equal(t, 2, *calls)
+}
@@ -0,0 +1,71 @@
+package client
+
+import (
+	"context"
+	"log/slog"
+
+	"github.com/celestiaorg/celestia-openrpc/types/blob"
+	"github.com/celestiaorg/celestia-openrpc/types/state"
+)
+
+// recordReceipts wraps the submission methods of the client to record the receipts of the
+// included blobs in the store, along with their share range and the fee paid for them.
+func recordReceipts(c *Client, store SubmissionStore, log *slog.Logger) {
+	submit := c.Blob.Submit
+	c.Blob.Submit = func(ctx context.Context, blobs []*blob.Blob, opts *blob.SubmitOptions) (uint64, error) {
+		height, err := submit(ctx, blobs, opts)
+		if err != nil {
+			return height, err
+		}
+		receipt := SubmitReceipt{Height: height}
+		if opts != nil {
+			receipt.Fee = fee(opts.GasPrice(), opts.GasLimit())
+		}
+		c.putReceipts(ctx, store, log, blobs, receipt)
+		return height, nil
+	}
+
+	submitPayForBlob := c.State.SubmitPayForBlob
+	c.State.SubmitPayForBlob = func(
+		ctx context.Context,
+		blobs []*blob.Blob,
+		config *state.TxConfig,
+	) (*state.TxResponse, error) {
+		resp, err := submitPayForBlob(ctx, blobs, config)
+		if err != nil || resp == nil || resp.Code != 0 {
+			return resp, err
+		}
+		//nolint:gosec
+		receipt := SubmitReceipt{Height: uint64(resp.Height), TxHash: resp.TxHash}
+		if config != nil {
+			//nolint:gosec
+			receipt.Fee = fee(config.GasPrice(), uint64(resp.GasWanted))
+		}
+		c.putReceipts(ctx, store, log, blobs, receipt)
+		return resp, nil
+	}
+}
+
+// putReceipts locates the blobs included at the height of the receipt and records them.
+// As the blobs were included already, failing to locate or record them is only logged.
+func (c *Client) putReceipts(
+	ctx context.Context,
+	store SubmissionStore,
+	log *slog.Logger,
+	blobs []*blob.Blob,
+	receipt SubmitReceipt,
+) {
+	located, err := c.locateBlobs(ctx, receipt.Height, blobs)
+	if err != nil {
+		log.WarnContext(ctx, "locating submitted blobs failed", "height", receipt.Height, "err", err)
+	}
+	for i, b := range blobs {
+		r := receipt
+		if i < len(located) {
+			r.Start, r.End = located[i].Start, located[i].End
+		}
+		if err := store.Put(b.Commitment, r); err != nil {
+			log.WarnContext(ctx, "recording submitted blob failed", "height", receipt.Height, "err", err)
+		}
+	}
+}
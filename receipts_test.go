@@ -0,0 +1,92 @@
+package client
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/celestiaorg/celestia-openrpc/mocks"
+	"github.com/celestiaorg/celestia-openrpc/types/blob"
+	"github.com/celestiaorg/celestia-openrpc/types/header"
+	"github.com/celestiaorg/celestia-openrpc/types/share"
+	"github.com/celestiaorg/celestia-openrpc/types/state"
+)
+
+// locatingClient returns a client locating every blob at the second share of the second
+// row of an original square of 4x4 shares.
+func locatingClient(t *testing.T) *Client {
+	t.Helper()
+	c := &Client{}
+	c.Header.WaitForHeight = func(_ context.Context, height uint64) (*header.ExtendedHeader, error) {
+		eh := mocks.NewHeader(height)
+		eh.DAH = &header.DataAvailabilityHeader{RowRoots: make([][]byte, 8), ColumnRoots: make([][]byte, 8)}
+		return eh, nil
+	}
+	c.Blob.Get = func(_ context.Context, _ uint64, _ share.Namespace, commitment blob.Commitment) (*blob.Blob, error) {
+		b := testBlob(t, "a")
+		if !b.Commitment.Equal(commitment) {
+			return nil, blob.ErrBlobNotFound
+		}
+		return withIndex(t, b, 9), nil
+	}
+	return c
+}
+
+func TestRecordReceipts(t *testing.T) {
+	calls := 0
+	client := locatingClient(t)
+	client.State.SubmitPayForBlob = func(context.Context, []*blob.Blob, *state.TxConfig) (*state.TxResponse, error) {
+		calls++
+		return &state.TxResponse{Height: 7, TxHash: "ABCD", GasWanted: 100_000}, nil
+	}
+	store := NewMemorySubmissionStore(10)
+	recordReceipts(client, store, discardLogger)
+
+	a, b := testBlob(t, "a"), testBlob(t, "b")
+	config := state.NewTxConfig(state.WithGasPrice(0.2))
+	_, err := client.State.SubmitPayForBlob(context.Background(), []*blob.Blob{a, b}, config)
+	require.NoError(t, err)
+
+	receipt, ok := store.Get(a.Commitment)
+	require.True(t, ok)
+	require.Equal(t, SubmitReceipt{Height: 7, TxHash: "ABCD", Start: 5, End: 6, Fee: 20_000}, receipt)
+	// blobs which couldn't be located are recorded without their share range
+	receipt, ok = store.Get(b.Commitment)
+	require.True(t, ok)
+	require.Equal(t, SubmitReceipt{Height: 7, TxHash: "ABCD", Fee: 20_000}, receipt)
+
+	// without WithIdempotentSubmission, the blobs are submitted again
+	_, err = client.State.SubmitPayForBlob(context.Background(), []*blob.Blob{a, b}, config)
+	require.NoError(t, err)
+	require.Equal(t, 2, calls)
+}
+
+func TestReceiptsSkipSubmitted(t *testing.T) {
+	store, err := NewFileSubmissionStore(t.TempDir())
+	require.NoError(t, err)
+
+	calls := 0
+	client := locatingClient(t)
+	client.State.SubmitPayForBlob = func(context.Context, []*blob.Blob, *state.TxConfig) (*state.TxResponse, error) {
+		calls++
+		return &state.TxResponse{Height: 7, TxHash: "ABCD"}, nil
+	}
+	// as wired by WithReceiptStore and WithIdempotentSubmission sharing the store
+	recordReceipts(client, store, discardLogger)
+	submitOnce(client, store, discardLogger)
+
+	blobs := []*blob.Blob{testBlob(t, "a")}
+	for i := 0; i < 2; i++ {
+		resp, err := client.State.SubmitPayForBlob(context.Background(), blobs, nil)
+		require.NoError(t, err)
+		require.EqualValues(t, 7, resp.Height)
+		require.Equal(t, "ABCD", resp.TxHash)
+	}
+	require.Equal(t, 1, calls)
+
+	// the receipt recorded with the share range is kept
+	receipt, ok := store.Get(blobs[0].Commitment)
+	require.True(t, ok)
+	require.Equal(t, SubmitReceipt{Height: 7, TxHash: "ABCD", Start: 5, End: 6}, receipt)
+}
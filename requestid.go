@@ -0,0 +1,103 @@
+package client
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"fmt"
+	"log/slog"
+	"net/http"
+)
+
+// RequestIDHeader is the header carrying the request ID of calls made over HTTP
+// by clients constructed with WithRequestIDs.
+const RequestIDHeader = "X-Request-ID"
+
+// requestIDKey is the context key of the request ID.
+type requestIDKey struct{}
+
+// WithRequestID returns a context making calls of clients constructed with WithRequestIDs
+// use the given request ID instead of a generated one, e.g. to correlate them with an
+// incoming request of the caller.
+func WithRequestID(ctx context.Context, id string) context.Context {
+	return context.WithValue(ctx, requestIDKey{}, id)
+}
+
+// RequestID returns the request ID carried by the context, if any. Within call hooks,
+// it's the ID of the call the hook is notified about.
+func RequestID(ctx context.Context) (string, bool) {
+	id, ok := ctx.Value(requestIDKey{}).(string)
+	return id, ok && id != ""
+}
+
+// newRequestID generates a random request ID.
+func newRequestID() string {
+	var id [8]byte
+	_, _ = rand.Read(id[:])
+	return hex.EncodeToString(id[:])
+}
+
+// RequestIDError is returned by failed calls of clients constructed with WithRequestIDs.
+type RequestIDError struct {
+	RequestID string
+	Err       error
+}
+
+func (e *RequestIDError) Error() string {
+	return fmt.Sprintf("%v (request %s)", e.Err, e.RequestID)
+}
+
+func (e *RequestIDError) Unwrap() error {
+	return e.Err
+}
+
+// correlateRequests is an interceptor attaching the request ID carried by the context,
+// or a generated one, to the call and its error. Retries and hedges share the ID.
+func correlateRequests(ctx context.Context, c *call, next invoker) ([]interface{}, error) {
+	id, ok := RequestID(ctx)
+	if !ok {
+		id = newRequestID()
+		ctx = WithRequestID(ctx, id)
+	}
+	out, err := next(ctx, c)
+	if err != nil {
+		err = &RequestIDError{RequestID: id, Err: err}
+	}
+	return out, err
+}
+
+// requestIDRoundTripper sets the request ID carried by the context of HTTP requests as their header.
+type requestIDRoundTripper struct {
+	next http.RoundTripper
+}
+
+func (rt *requestIDRoundTripper) RoundTrip(req *http.Request) (*http.Response, error) {
+	id, ok := RequestID(req.Context())
+	if !ok {
+		return rt.next.RoundTrip(req)
+	}
+	req = req.Clone(req.Context())
+	req.Header.Set(RequestIDHeader, id)
+	return rt.next.RoundTrip(req)
+}
+
+// requestIDHandler adds the request ID carried by the context to the records it handles.
+type requestIDHandler struct {
+	slog.Handler
+}
+
+func (h requestIDHandler) Handle(ctx context.Context, r slog.Record) error {
+	if id, ok := RequestID(ctx); ok {
+		r = r.Clone()
+		r.AddAttrs(slog.String("request_id", id))
+	}
+	return h.Handler.Handle(ctx, r)
+}
+
+func (h requestIDHandler) WithAttrs(attrs []slog.Attr) slog.Handler {
+	return requestIDHandler{h.Handler.WithAttrs(attrs)}
+}
+
+func (h requestIDHandler) WithGroup(name string) slog.Handler {
+	return requestIDHandler{h.Handler.WithGroup(name)}
+}
@@ -0,0 +1,98 @@
+package client
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"log/slog"
+	"net/http"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestRequestIDs(t *testing.T) {
+	var (
+		mu     sync.Mutex
+		sent   = make(map[string]string)
+		hooked = make(map[string]string)
+	)
+	srv := testNode(t, func(r *http.Request, method string, _ json.RawMessage) (interface{}, error) {
+		mu.Lock()
+		sent[method] = r.Header.Get(RequestIDHeader)
+		mu.Unlock()
+		if method != "node.Ready" {
+			return nil, errors.New("header: not found")
+		}
+		return true, nil
+	})
+
+	logs := &syncBuffer{}
+	c, err := NewClient(context.Background(), srv.URL, "",
+		WithRequestIDs(),
+		WithLogger(slog.New(slog.NewTextHandler(logs, nil))),
+		WithSlowCallThreshold(time.Nanosecond),
+		WithCallHook(func(ctx context.Context, info CallInfo) {
+			id, _ := RequestID(ctx)
+			require.Equal(t, id, info.RequestID)
+			mu.Lock()
+			hooked[info.Method] = info.RequestID
+			mu.Unlock()
+		}),
+	)
+	require.NoError(t, err)
+	defer c.Close()
+	ids := func(method string) (string, string) {
+		mu.Lock()
+		defer mu.Unlock()
+		return sent[method], hooked[method]
+	}
+
+	// the ID of the caller is propagated
+	_, err = c.Node.Ready(WithRequestID(context.Background(), "caller-id"))
+	require.NoError(t, err)
+	sentID, hookedID := ids("node.Ready")
+	require.Equal(t, "caller-id", sentID)
+	require.Equal(t, "caller-id", hookedID)
+	require.Contains(t, logs.String(), "request_id=caller-id")
+
+	// otherwise one is generated
+	_, err = c.Header.GetByHeight(context.Background(), 1)
+	require.ErrorIs(t, err, ErrHeaderNotFound)
+	var idErr *RequestIDError
+	require.ErrorAs(t, err, &idErr)
+	require.NotEmpty(t, idErr.RequestID)
+	sentID, hookedID = ids("header.GetByHeight")
+	require.Equal(t, idErr.RequestID, sentID)
+	require.Equal(t, idErr.RequestID, hookedID)
+	require.Contains(t, err.Error(), idErr.RequestID)
+}
+
+func TestRequestIDsDisabled(t *testing.T) {
+	srv := testNode(t, func(r *http.Request, _ string, _ json.RawMessage) (interface{}, error) {
+		require.Empty(t, r.Header.Get(RequestIDHeader))
+		return nil, errors.New("header: not found")
+	})
+	c, err := NewClient(context.Background(), srv.URL, "")
+	require.NoError(t, err)
+	defer c.Close()
+
+	_, err = c.Header.GetByHeight(context.Background(), 1)
+	var idErr *RequestIDError
+	require.False(t, errors.As(err, &idErr))
+}
+
+func TestRequestIDFromContext(t *testing.T) {
+	_, ok := RequestID(context.Background())
+	require.False(t, ok)
+	_, ok = RequestID(WithRequestID(context.Background(), ""))
+	require.False(t, ok)
+	id, ok := RequestID(WithRequestID(context.Background(), "id"))
+	require.True(t, ok)
+	require.Equal(t, "id", id)
+
+	require.Len(t, newRequestID(), 16)
+	require.NotEqual(t, newRequestID(), newRequestID())
+}
@@ -0,0 +1,201 @@
+package client
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"strings"
+	"time"
+
+	"github.com/celestiaorg/celestia-openrpc/types/appconsts"
+	"github.com/celestiaorg/celestia-openrpc/types/blob"
+	"github.com/celestiaorg/celestia-openrpc/types/state"
+)
+
+// ResubmitPolicy configures how submissions failing with a recoverable error are resubmitted.
+type ResubmitPolicy struct {
+	// MaxAttempts is the maximum number of submissions, including the first one.
+	// Values below 2 disable resubmissions.
+	MaxAttempts int
+	// MinDelay and MaxDelay bound the exponential backoff between attempts.
+	MinDelay time.Duration
+	MaxDelay time.Duration
+	// GasPriceMultiplier scales the gas price of the resubmission of a transaction rejected
+	// because of its fee, such as one evicted from a full mempool or timed out. Submissions
+	// relying on the minimum gas price of the node start from appconsts.DefaultMinGasPrice.
+	// Values of 1 or below keep the gas price.
+	GasPriceMultiplier float64
+}
+
+// DefaultResubmitPolicy returns a policy making up to three attempts and raising the gas
+// price by half on every resubmission caused by the fee, suitable for WithResubmission.
+func DefaultResubmitPolicy() ResubmitPolicy {
+	return ResubmitPolicy{
+		MaxAttempts:        3,
+		MinDelay:           defaultBackoffMinDelay,
+		MaxDelay:           defaultBackoffMaxDelay,
+		GasPriceMultiplier: 1.5,
+	}
+}
+
+func (p ResubmitPolicy) backoff() backoff {
+	return backoff{minDelay: p.MinDelay, maxDelay: max(p.MinDelay, p.MaxDelay)}
+}
+
+// bumpGasPrice returns the gas price to resubmit with after a failure caused by the fee.
+func (p ResubmitPolicy) bumpGasPrice(gasPrice float64) float64 {
+	if p.GasPriceMultiplier <= 1 {
+		return gasPrice
+	}
+	if gasPrice < 0 {
+		gasPrice = appconsts.DefaultMinGasPrice
+	}
+	return gasPrice * p.GasPriceMultiplier
+}
+
+// submitFailure is the kind of a recoverable submission failure.
+type submitFailure int
+
+const (
+	failureNone submitFailure = iota
+	// failureSequence is a transaction signed with an outdated account sequence. The node
+	// resynchronizes the sequence of the account on such failures, so resubmitting is enough.
+	failureSequence
+	// failureFee is a transaction which wasn't included in time for its fee.
+	failureFee
+)
+
+// Error codes of the cosmos-sdk reported in the responses of rejected transactions.
+const (
+	codeInsufficientFee = 13
+	codeMempoolIsFull   = 20
+	codeTxTimeoutHeight = 30
+	codeWrongSequence   = 32
+)
+
+// classifySubmitError returns the kind of the failure of a submission failing with err.
+func classifySubmitError(err error) submitFailure {
+	if err == nil || errors.Is(err, context.Canceled) || errors.Is(err, context.DeadlineExceeded) {
+		return failureNone
+	}
+	msg := strings.ToLower(err.Error())
+	switch {
+	case strings.Contains(msg, "account sequence mismatch"), strings.Contains(msg, "incorrect account sequence"):
+		return failureSequence
+	case strings.Contains(msg, "mempool is full"), strings.Contains(msg, "insufficient fee"),
+		strings.Contains(msg, "tx timed out"), strings.Contains(msg, "timed out waiting for tx"):
+		return failureFee
+	default:
+		return failureNone
+	}
+}
+
+// classifySubmitCode returns the kind of the failure of a transaction rejected with the code.
+func classifySubmitCode(code uint32) submitFailure {
+	switch code {
+	case codeWrongSequence:
+		return failureSequence
+	case codeInsufficientFee, codeMempoolIsFull, codeTxTimeoutHeight:
+		return failureFee
+	default:
+		return failureNone
+	}
+}
+
+// resubmit wraps the submission methods of the client to resubmit transactions failing
+// with a recoverable error according to the policy, returning the result of the last attempt.
+func resubmit(c *Client, policy ResubmitPolicy, log *slog.Logger, clock Clock) {
+	// wait waits before the given resubmission, reporting whether to go on
+	wait := func(ctx context.Context, method string, attempt int, err error) bool {
+		delay := policy.backoff().next(attempt)
+		log.WarnContext(ctx, "resubmitting transaction", "method", method, "attempt", attempt+1, "delay", delay, "err", err)
+		select {
+		case <-clock.After(delay):
+			return true
+		case <-ctx.Done():
+			return false
+		}
+	}
+
+	submit := c.Blob.Submit
+	c.Blob.Submit = func(ctx context.Context, blobs []*blob.Blob, opts *blob.SubmitOptions) (uint64, error) {
+		for attempt := 0; ; attempt++ {
+			height, err := submit(ctx, blobs, opts)
+			failure := classifySubmitError(err)
+			if failure == failureNone || attempt+1 >= policy.MaxAttempts || !wait(ctx, "blob.Submit", attempt, err) {
+				return height, err
+			}
+			if failure == failureFee {
+				opts = withSubmitGasPrice(opts, policy.bumpGasPrice(submitGasPrice(opts)))
+			}
+		}
+	}
+
+	submitPayForBlob := c.State.SubmitPayForBlob
+	c.State.SubmitPayForBlob = func(
+		ctx context.Context,
+		blobs []*blob.Blob,
+		config *state.TxConfig,
+	) (*state.TxResponse, error) {
+		for attempt := 0; ; attempt++ {
+			resp, err := submitPayForBlob(ctx, blobs, config)
+			failure := classifySubmitError(err)
+			var reason error = err
+			if err == nil && resp != nil && resp.Code != 0 {
+				failure = classifySubmitCode(resp.Code)
+				reason = errors.New(resp.RawLog)
+			}
+			if failure == failureNone || attempt+1 >= policy.MaxAttempts ||
+				!wait(ctx, "state.SubmitPayForBlob", attempt, reason) {
+				return resp, err
+			}
+			if failure == failureFee {
+				config = withTxGasPrice(config, policy.bumpGasPrice(txGasPrice(config)))
+			}
+		}
+	}
+}
+
+// submitGasPrice returns the gas price of the options, negative if the node's minimum is used.
+func submitGasPrice(opts *blob.SubmitOptions) float64 {
+	if opts == nil {
+		return blob.DefaultGasPrice
+	}
+	return opts.GasPrice()
+}
+
+// withSubmitGasPrice returns a copy of the options with the gas price.
+func withSubmitGasPrice(opts *blob.SubmitOptions, gasPrice float64) *blob.SubmitOptions {
+	if opts == nil {
+		return blob.NewSubmitOptions(blob.WithGasPrice(gasPrice))
+	}
+	return blob.NewSubmitOptions(
+		blob.WithGas(opts.GasLimit()),
+		blob.WithKeyName(opts.KeyName()),
+		blob.WithSignerAddress(opts.SignerAddress()),
+		blob.WithFeeGranterAddress(opts.FeeGranterAddress()),
+		blob.WithGasPrice(gasPrice),
+	)
+}
+
+// txGasPrice returns the gas price of the config, negative if the node's minimum is used.
+func txGasPrice(config *state.TxConfig) float64 {
+	if config == nil {
+		return state.DefaultGasPrice
+	}
+	return config.GasPrice()
+}
+
+// withTxGasPrice returns a copy of the config with the gas price.
+func withTxGasPrice(config *state.TxConfig, gasPrice float64) *state.TxConfig {
+	if config == nil {
+		return state.NewTxConfig(state.WithGasPrice(gasPrice))
+	}
+	return state.NewTxConfig(
+		state.WithGas(config.GasLimit()),
+		state.WithKeyName(config.KeyName()),
+		state.WithSignerAddress(config.SignerAddress()),
+		state.WithFeeGranterAddress(config.FeeGranterAddress()),
+		state.WithGasPrice(gasPrice),
+	)
+}
@@ -0,0 +1,74 @@
+package client
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/celestiaorg/celestia-openrpc/types/appconsts"
+	"github.com/celestiaorg/celestia-openrpc/types/blob"
+	"github.com/celestiaorg/celestia-openrpc/types/state"
+)
+
+func TestResubmitBlobs(t *testing.T) {
+	failures := []error{
+		errors.New("broadcast tx: account sequence mismatch, expected 5, got 4: incorrect account sequence"),
+		errors.New("broadcast tx: mempool is full"),
+	}
+	var gasPrices []float64
+	c := &Client{}
+	c.Blob.Submit = func(_ context.Context, _ []*blob.Blob, opts *blob.SubmitOptions) (uint64, error) {
+		gasPrices = append(gasPrices, opts.GasPrice())
+		if len(gasPrices) <= len(failures) {
+			return 0, failures[len(gasPrices)-1]
+		}
+		return 7, nil
+	}
+	resubmit(c, DefaultResubmitPolicy(), discardLogger, &fakeClock{})
+
+	opts := blob.NewSubmitOptions(blob.WithGas(100_000), blob.WithKeyName("rollup"))
+	height, err := c.Blob.Submit(context.Background(), []*blob.Blob{testBlob(t, "hello")}, opts)
+	require.NoError(t, err)
+	require.EqualValues(t, 7, height)
+	// the gas price is only raised after the failure caused by the fee
+	require.Len(t, gasPrices, 3)
+	require.Equal(t, []float64{blob.DefaultGasPrice, blob.DefaultGasPrice}, gasPrices[:2])
+	require.InDelta(t, appconsts.DefaultMinGasPrice*1.5, gasPrices[2], 1e-9)
+
+	// unrecoverable failures are returned right away
+	gasPrices = nil
+	failures = []error{errors.New("insufficient funds"), nil}
+	_, err = c.Blob.Submit(context.Background(), []*blob.Blob{testBlob(t, "hello")}, opts)
+	require.EqualError(t, err, "insufficient funds")
+	require.Len(t, gasPrices, 1)
+
+	// as is the last failure once the attempts are exhausted
+	gasPrices = nil
+	failures = []error{errors.New("tx timed out"), errors.New("tx timed out"), errors.New("tx timed out")}
+	_, err = c.Blob.Submit(context.Background(), []*blob.Blob{testBlob(t, "hello")}, blob.NewSubmitOptions(blob.WithGasPrice(1)))
+	require.EqualError(t, err, "tx timed out")
+	require.Equal(t, []float64{1, 1.5, 2.25}, gasPrices)
+}
+
+func TestResubmitPayForBlob(t *testing.T) {
+	var configs []*state.TxConfig
+	c := &Client{}
+	c.State.SubmitPayForBlob = func(_ context.Context, _ []*blob.Blob, config *state.TxConfig) (*state.TxResponse, error) {
+		configs = append(configs, config)
+		if len(configs) == 1 {
+			return &state.TxResponse{Code: codeMempoolIsFull, RawLog: "mempool is full"}, nil
+		}
+		return &state.TxResponse{Height: 7}, nil
+	}
+	resubmit(c, DefaultResubmitPolicy(), discardLogger, &fakeClock{})
+
+	config := state.NewTxConfig(state.WithSignerAddress(testSignerAddress), state.WithGasPrice(0.2))
+	resp, err := c.State.SubmitPayForBlob(context.Background(), []*blob.Blob{testBlob(t, "hello")}, config)
+	require.NoError(t, err)
+	require.EqualValues(t, 7, resp.Height)
+	require.Len(t, configs, 2)
+	require.InDelta(t, 0.3, configs[1].GasPrice(), 1e-9)
+	require.Equal(t, testSignerAddress, configs[1].SignerAddress())
+}
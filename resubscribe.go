@@ -0,0 +1,199 @@
+package client
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"runtime/debug"
+
+	gofraud "github.com/celestiaorg/go-fraud"
+
+	"github.com/celestiaorg/celestia-openrpc/types/blob"
+	"github.com/celestiaorg/celestia-openrpc/types/fraud"
+	"github.com/celestiaorg/celestia-openrpc/types/header"
+	"github.com/celestiaorg/celestia-openrpc/types/share"
+)
+
+// SubscriptionGap describes the heights a subscription missed while it was
+// re-established, so the caller can backfill them.
+type SubscriptionGap struct {
+	// Method is the name of the subscription method, e.g. "header.Subscribe".
+	Method string
+	// FromHeight and ToHeight are the first and the last missed height.
+	FromHeight uint64
+	ToHeight   uint64
+}
+
+// ErrSubscriptionPanicked is the error of subscriptions terminated by a panic while
+// dispatching their items, e.g. due to a malformed notification.
+var ErrSubscriptionPanicked = errors.New("subscription panicked")
+
+// SubscriptionError describes why a subscription was terminated, closing its channel.
+type SubscriptionError struct {
+	// Method is the name of the subscription method, e.g. "header.Subscribe".
+	Method string
+	Err    error
+}
+
+func (e *SubscriptionError) Error() string {
+	return fmt.Sprintf("%s: %v", e.Method, e.Err)
+}
+
+func (e *SubscriptionError) Unwrap() error {
+	return e.Err
+}
+
+// resubscriber keeps the consumer channels of subscriptions alive by
+// re-creating the subscriptions once the connection drops.
+type resubscriber struct {
+	backoff backoff
+	onGap   func(SubscriptionGap)
+	// onError is notified about subscriptions terminated by an error, if set.
+	onError func(*SubscriptionError)
+	// onResubscribe is notified about every subscription re-created.
+	onResubscribe func(method string)
+	// events receives the Reconnecting and Resubscribed events, if set.
+	events func(ConnectionEvent)
+	log    *slog.Logger
+	// closing is closed once the client is closed and no more attempts should be made.
+	closing <-chan struct{}
+	clock   Clock
+}
+
+// resubscribe wraps a subscription to be re-created whenever its channel is closed before ctx is done.
+// If height is provided, gaps between the last delivered and the first re-delivered item are reported.
+func resubscribe[T any](
+	ctx context.Context,
+	r *resubscriber,
+	method string,
+	subscribe func(context.Context) (<-chan T, error),
+	height func(T) uint64,
+) (<-chan T, error) {
+	// the subscription is cancelled once it's terminated by a panic, so the node stops sending items
+	ctx, cancel := context.WithCancel(ctx)
+	sub, err := subscribe(ctx)
+	if err != nil {
+		cancel()
+		return nil, err
+	}
+
+	out := make(chan T)
+	go func() {
+		defer close(out)
+		defer cancel()
+		defer r.recover(ctx, method)
+
+		var last uint64
+		for {
+			for item := range sub {
+				if height != nil {
+					h := height(item)
+					if last != 0 && h > last+1 && r.onGap != nil {
+						r.onGap(SubscriptionGap{Method: method, FromHeight: last + 1, ToHeight: h - 1})
+					}
+					last = max(last, h)
+				}
+
+				select {
+				case out <- item:
+				case <-ctx.Done():
+					return
+				case <-r.closing:
+					return
+				}
+			}
+
+			select {
+			case <-ctx.Done():
+				// the subscription was closed because the caller is done with it
+				return
+			case <-r.closing:
+				// the subscription was closed because the client is
+				return
+			default:
+			}
+			// the subscription was closed, most likely due to a dropped connection
+			r.log.WarnContext(ctx, "subscription closed, re-creating it", "method", method)
+			if sub = awaitSubscription(ctx, r, method, subscribe); sub == nil {
+				return
+			}
+			if r.onResubscribe != nil {
+				r.onResubscribe(method)
+			}
+			if r.events != nil {
+				r.events(ConnectionEvent{Type: Resubscribed, Method: method})
+			}
+		}
+	}()
+	return out, nil
+}
+
+// recover recovers a panic of the goroutine dispatching the items of a subscription,
+// reporting it as a SubscriptionError instead of crashing the process. Must be deferred.
+func (r *resubscriber) recover(ctx context.Context, method string) {
+	v := recover()
+	if v == nil {
+		return
+	}
+	r.log.ErrorContext(ctx, "subscription panicked", "method", method, "panic", v, "stack", string(debug.Stack()))
+	if r.onError != nil {
+		r.onError(&SubscriptionError{Method: method, Err: fmt.Errorf("%w: %v", ErrSubscriptionPanicked, v)})
+	}
+}
+
+// awaitSubscription re-creates the subscription with backoff until it succeeds,
+// ctx is done or the client is closed, in which case nil is returned.
+func awaitSubscription[T any](
+	ctx context.Context,
+	r *resubscriber,
+	method string,
+	subscribe func(context.Context) (<-chan T, error),
+) <-chan T {
+	var err error
+	for attempt := 0; ; attempt++ {
+		select {
+		case <-r.clock.After(r.backoff.next(attempt)):
+		case <-ctx.Done():
+			return nil
+		case <-r.closing:
+			return nil
+		}
+
+		if r.events != nil {
+			r.events(ConnectionEvent{Type: Reconnecting, Method: method, Attempt: attempt + 1, Err: err})
+		}
+		var sub <-chan T
+		if sub, err = subscribe(ctx); err == nil {
+			return sub
+		}
+		r.log.WarnContext(ctx, "re-creating subscription failed", "method", method, "attempt", attempt+1, "err", err)
+	}
+}
+
+// resubscribeAll wraps all subscription methods of the client to be re-created
+// after the connection drops.
+func resubscribeAll(c *Client, r *resubscriber) {
+	headerSub := c.Header.Subscribe
+	c.Header.Subscribe = func(ctx context.Context) (<-chan *header.ExtendedHeader, error) {
+		return resubscribe(ctx, r, "header.Subscribe", headerSub, (*header.ExtendedHeader).Height)
+	}
+
+	blobSub := c.Blob.Subscribe
+	c.Blob.Subscribe = func(ctx context.Context, namespace share.Namespace) (<-chan *blob.SubscriptionResponse, error) {
+		subscribe := func(ctx context.Context) (<-chan *blob.SubscriptionResponse, error) {
+			return blobSub(ctx, namespace)
+		}
+		return resubscribe(ctx, r, "blob.Subscribe", subscribe, func(resp *blob.SubscriptionResponse) uint64 {
+			return resp.Height
+		})
+	}
+
+	fraudSub := c.Fraud.Subscribe
+	c.Fraud.Subscribe = func(ctx context.Context, proofType gofraud.ProofType) (<-chan *fraud.Proof, error) {
+		subscribe := func(ctx context.Context) (<-chan *fraud.Proof, error) {
+			return fraudSub(ctx, proofType)
+		}
+		return resubscribe(ctx, r, "fraud.Subscribe", subscribe, nil)
+	}
+}
@@ -0,0 +1,132 @@
+package client
+
+import (
+	"bytes"
+	"context"
+	"log/slog"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func testResubscriber(buf *bytes.Buffer) *resubscriber {
+	return &resubscriber{
+		backoff: backoff{minDelay: time.Millisecond, maxDelay: time.Millisecond},
+		log:     slog.New(slog.NewTextHandler(buf, nil)),
+		closing: make(chan struct{}),
+		clock:   realClock{},
+	}
+}
+
+// subscriptions returns a subscribe function serving the given channels one after another.
+func subscriptions(subs ...chan uint64) (func(context.Context) (<-chan uint64, error), *int) {
+	var calls int
+	return func(context.Context) (<-chan uint64, error) {
+		sub := subs[calls]
+		calls++
+		return sub, nil
+	}, &calls
+}
+
+func TestResubscribe(t *testing.T) {
+	var buf bytes.Buffer
+	r := testResubscriber(&buf)
+	var gaps []SubscriptionGap
+	r.onGap = func(gap SubscriptionGap) { gaps = append(gaps, gap) }
+	var resubscriptions int
+	r.onResubscribe = func(string) { resubscriptions++ }
+
+	first, second := make(chan uint64, 2), make(chan uint64, 1)
+	first <- 1
+	first <- 2
+	close(first)
+	second <- 5
+	subscribe, calls := subscriptions(first, second)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	out, err := resubscribe(ctx, r, "header.Subscribe", subscribe, func(h uint64) uint64 { return h })
+	require.NoError(t, err)
+
+	require.EqualValues(t, 1, <-out)
+	require.EqualValues(t, 2, <-out)
+	require.EqualValues(t, 5, <-out)
+	cancel()
+	close(second)
+	drain(out)
+
+	require.Equal(t, 2, *calls)
+	require.Equal(t, 1, resubscriptions)
+	require.Equal(t, []SubscriptionGap{{Method: "header.Subscribe", FromHeight: 3, ToHeight: 4}}, gaps)
+	require.Contains(t, buf.String(), "re-creating")
+}
+
+func TestResubscribeStopsOnCancel(t *testing.T) {
+	var buf bytes.Buffer
+	r := testResubscriber(&buf)
+	sub := make(chan uint64)
+	subscribe, calls := subscriptions(sub)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	out, err := resubscribe(ctx, r, "header.Subscribe", subscribe, nil)
+	require.NoError(t, err)
+
+	// the subscription is closed as expected once the caller cancels it
+	cancel()
+	close(sub)
+	drain(out)
+
+	require.Equal(t, 1, *calls)
+	require.Empty(t, buf.String())
+}
+
+func TestResubscribeStopsOnClose(t *testing.T) {
+	var buf bytes.Buffer
+	r := testResubscriber(&buf)
+	r.backoff = backoff{minDelay: time.Hour, maxDelay: time.Hour}
+	closing := make(chan struct{})
+	r.closing = closing
+	sub := make(chan uint64)
+	subscribe, calls := subscriptions(sub)
+
+	out, err := resubscribe(context.Background(), r, "header.Subscribe", subscribe, nil)
+	require.NoError(t, err)
+
+	close(sub)
+	close(closing)
+	drain(out)
+	require.Equal(t, 1, *calls)
+}
+
+func TestResubscribeRecoversPanic(t *testing.T) {
+	var buf bytes.Buffer
+	r := testResubscriber(&buf)
+	var subErr *SubscriptionError
+	r.onError = func(err *SubscriptionError) { subErr = err }
+
+	sub := make(chan uint64, 2)
+	sub <- 1
+	sub <- 0
+	var subCtx context.Context
+	subscribe := func(ctx context.Context) (<-chan uint64, error) {
+		subCtx = ctx
+		return sub, nil
+	}
+
+	// a malformed item makes the height extraction panic
+	out, err := resubscribe(context.Background(), r, "header.Subscribe", subscribe, func(h uint64) uint64 {
+		if h == 0 {
+			panic("malformed header")
+		}
+		return h
+	})
+	require.NoError(t, err)
+	require.EqualValues(t, 1, <-out)
+	drain(out)
+
+	require.ErrorIs(t, subErr, ErrSubscriptionPanicked)
+	require.Equal(t, "header.Subscribe", subErr.Method)
+	require.Contains(t, subErr.Error(), "malformed header")
+	require.ErrorIs(t, subCtx.Err(), context.Canceled)
+	require.Contains(t, buf.String(), "subscription panicked")
+}
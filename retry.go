@@ -0,0 +1,70 @@
+package client
+
+import (
+	"context"
+	"log/slog"
+	"time"
+)
+
+// RetryPolicy configures how calls failing with a transient error are retried.
+type RetryPolicy struct {
+	// MaxAttempts is the maximum number of attempts, including the first one.
+	// Values below 2 disable retries.
+	MaxAttempts int
+	// MinDelay and MaxDelay bound the exponential backoff between attempts.
+	MinDelay time.Duration
+	MaxDelay time.Duration
+}
+
+// DefaultRetryPolicy returns a policy making up to three attempts, suitable for WithRetry.
+func DefaultRetryPolicy() RetryPolicy {
+	return RetryPolicy{
+		MaxAttempts: 3,
+		MinDelay:    defaultBackoffMinDelay,
+		MaxDelay:    defaultBackoffMaxDelay,
+	}
+}
+
+func (p RetryPolicy) backoff() backoff {
+	return backoff{minDelay: p.MinDelay, maxDelay: max(p.MinDelay, p.MaxDelay)}
+}
+
+// retry returns an interceptor retrying calls which failed with a transient error.
+// Only read-only calls and the methods explicitly marked as safe are retried, as
+// repeating any other call, e.g. "blob.Submit", may apply it twice.
+// The policy of the call options takes precedence over the one of the module of the call,
+// which takes precedence over the default one, keyed by "".
+func retry(policies map[string]RetryPolicy, safe []string, log *slog.Logger, clock Clock) interceptor {
+	retrySafe := make(map[string]bool, len(safe))
+	for _, method := range safe {
+		retrySafe[method] = true
+	}
+
+	return func(ctx context.Context, c *call, next invoker) ([]interface{}, error) {
+		policy, ok := policies[c.module]
+		if !ok {
+			policy = policies[""]
+		}
+		if co := callOptionsFrom(ctx); co.retry != nil {
+			policy = *co.retry
+		}
+		if policy.MaxAttempts < 2 || (c.perm != "read" && !retrySafe[c.name()]) {
+			return next(ctx, c)
+		}
+
+		for attempt := 0; ; attempt++ {
+			out, err := next(ctx, c)
+			if err == nil || !isTransient(err, c.perm == "read") || attempt+1 >= policy.MaxAttempts || ctx.Err() != nil {
+				return out, err
+			}
+
+			delay := policy.backoff().next(attempt)
+			log.WarnContext(ctx, "retrying call", "method", c.name(), "attempt", attempt+1, "delay", delay, "err", err)
+			select {
+			case <-clock.After(delay):
+			case <-ctx.Done():
+				return nil, err
+			}
+		}
+	}
+}
@@ -0,0 +1,127 @@
+package client
+
+import (
+	"context"
+	"errors"
+	"io"
+	"log/slog"
+	"net/url"
+	"syscall"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+var discardLogger = slog.New(slog.NewTextHandler(io.Discard, nil))
+
+// failingInvoker returns an invoker failing the first failures calls with err and
+// counting all calls.
+func failingInvoker(failures int, err error) (invoker, *int) {
+	var calls int
+	return func(context.Context, *call) ([]interface{}, error) {
+		calls++
+		if calls <= failures {
+			return nil, err
+		}
+		return []interface{}{"ok"}, nil
+	}, &calls
+}
+
+var fastRetry = RetryPolicy{MaxAttempts: 3, MinDelay: time.Millisecond, MaxDelay: time.Millisecond}
+
+func TestRetry(t *testing.T) {
+	intercept := retry(map[string]RetryPolicy{"": fastRetry}, nil, discardLogger, realClock{})
+	next, calls := failingInvoker(2, syscall.ECONNRESET)
+
+	out, err := intercept(context.Background(), &call{module: "header", method: "GetByHeight", perm: "read"}, next)
+	require.NoError(t, err)
+	require.Equal(t, []interface{}{"ok"}, out)
+	require.Equal(t, 3, *calls)
+}
+
+func TestRetryGivesUp(t *testing.T) {
+	intercept := retry(map[string]RetryPolicy{"": fastRetry}, nil, discardLogger, realClock{})
+	next, calls := failingInvoker(5, io.ErrUnexpectedEOF)
+
+	_, err := intercept(context.Background(), &call{module: "header", method: "GetByHeight", perm: "read"}, next)
+	require.ErrorIs(t, err, io.ErrUnexpectedEOF)
+	require.Equal(t, 3, *calls)
+}
+
+func TestRetrySkipsPermanentErrors(t *testing.T) {
+	intercept := retry(map[string]RetryPolicy{"": fastRetry}, nil, discardLogger, realClock{})
+	next, calls := failingInvoker(5, errors.New("header: not found"))
+
+	_, err := intercept(context.Background(), &call{module: "header", method: "GetByHeight", perm: "read"}, next)
+	require.Error(t, err)
+	require.Equal(t, 1, *calls)
+}
+
+func TestRetryIdempotency(t *testing.T) {
+	intercept := retry(map[string]RetryPolicy{"": fastRetry}, []string{"state.Transfer"}, discardLogger, realClock{})
+
+	next, calls := failingInvoker(1, syscall.ECONNREFUSED)
+	_, err := intercept(context.Background(), &call{module: "blob", method: "Submit", perm: "write"}, next)
+	require.Error(t, err)
+	require.Equal(t, 1, *calls)
+
+	next, calls = failingInvoker(1, syscall.ECONNREFUSED)
+	_, err = intercept(context.Background(), &call{module: "state", method: "Transfer", perm: "write"}, next)
+	require.NoError(t, err)
+	require.Equal(t, 2, *calls)
+}
+
+func TestRetryDroppedResponse(t *testing.T) {
+	intercept := retry(map[string]RetryPolicy{"": fastRetry}, []string{"blob.Submit"}, discardLogger, realClock{})
+
+	// the node may have applied a write call whose response was cut short
+	next, calls := failingInvoker(1, &url.Error{Op: "Post", URL: "http://localhost:26658", Err: io.EOF})
+	_, err := intercept(context.Background(), &call{module: "blob", method: "Submit", perm: "write"}, next)
+	require.ErrorIs(t, err, io.EOF)
+	require.Equal(t, 1, *calls)
+
+	next, calls = failingInvoker(1, io.EOF)
+	_, err = intercept(context.Background(), &call{module: "header", method: "GetByHeight", perm: "read"}, next)
+	require.NoError(t, err)
+	require.Equal(t, 2, *calls)
+}
+
+func TestRetryPolicyPrecedence(t *testing.T) {
+	policies := map[string]RetryPolicy{"": fastRetry, "share": {MaxAttempts: 1}}
+	intercept := retry(policies, nil, discardLogger, realClock{})
+
+	next, calls := failingInvoker(1, syscall.ECONNRESET)
+	_, err := intercept(context.Background(), &call{module: "share", method: "GetEDS", perm: "read"}, next)
+	require.Error(t, err)
+	require.Equal(t, 1, *calls)
+
+	// the policy of the call options overrides the one of the module
+	ctx := WithCallOptions(context.Background(), WithCallRetry(fastRetry))
+	next, calls = failingInvoker(1, syscall.ECONNRESET)
+	_, err = intercept(ctx, &call{module: "share", method: "GetEDS", perm: "read"}, next)
+	require.NoError(t, err)
+	require.Equal(t, 2, *calls)
+}
+
+func TestIsTransient(t *testing.T) {
+	for _, err := range []error{
+		context.DeadlineExceeded,
+		syscall.ECONNRESET,
+		io.EOF,
+		errors.New(wsConnectionClosed),
+		errors.New("request failed, http status 503 Service Unavailable"),
+	} {
+		require.True(t, isTransient(err, true), err)
+	}
+	require.False(t, isTransient(io.EOF, false))
+	require.True(t, isTransient(syscall.ECONNREFUSED, false))
+	for _, err := range []error{
+		nil,
+		context.Canceled,
+		errors.New("blob: not found"),
+		errors.New("request failed, http status 400 Bad Request"),
+	} {
+		require.False(t, isTransient(err, true), err)
+	}
+}
@@ -0,0 +1,124 @@
+package client
+
+import (
+	"context"
+	"errors"
+	"sort"
+	"sync"
+	"time"
+)
+
+const (
+	// routingDecay is the weight of the latest observation in the moving averages.
+	routingDecay = 0.2
+	// failurePenalty is the latency an endpoint failing every call is penalized with.
+	failurePenalty = 10 * time.Second
+)
+
+// EndpointStats describes the observed performance of an endpoint the client routes calls to.
+type EndpointStats struct {
+	Addr string
+	// Calls and Failures count the calls made against the endpoint and those which failed
+	// due to the connection to it.
+	Calls    uint64
+	Failures uint64
+	// Latency is the moving average of the time the endpoint took to respond.
+	Latency time.Duration
+	// FailureRate is the moving average of the share of failed calls, between 0 and 1.
+	FailureRate float64
+	// Score is the value calls are routed by. The endpoint with the lowest score is preferred.
+	Score float64
+	// CircuitOpen reports whether the circuit breaker of the endpoint stopped calls to it.
+	CircuitOpen bool
+}
+
+// endpointStats accumulates the observations made for an endpoint.
+type endpointStats struct {
+	mu          sync.Mutex
+	calls       uint64
+	failures    uint64
+	latency     float64
+	failureRate float64
+}
+
+// observe records the outcome of a call which took the given time.
+func (s *endpointStats) observe(took time.Duration, err error) {
+	// calls aborted by the caller say nothing about the endpoint
+	if errors.Is(err, context.Canceled) {
+		return
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	failed := 0.0
+	if isConnectionError(err) {
+		s.failures++
+		failed = 1
+	} else {
+		s.latency = ewma(s.latency, float64(took), s.calls-s.failures == 0)
+	}
+	s.failureRate = ewma(s.failureRate, failed, s.calls == 0)
+	s.calls++
+}
+
+// score returns the score of the endpoint, lower being better. Endpoints
+// without observations score zero so they are tried first.
+func (s *endpointStats) score() float64 {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.latency + s.failureRate*float64(failurePenalty)
+}
+
+// snapshot returns the exported representation of the stats.
+func (s *endpointStats) snapshot(addr string) EndpointStats {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return EndpointStats{
+		Addr:        addr,
+		Calls:       s.calls,
+		Failures:    s.failures,
+		Latency:     time.Duration(s.latency),
+		FailureRate: s.failureRate,
+		Score:       s.latency + s.failureRate*float64(failurePenalty),
+	}
+}
+
+// ewma folds the value into the moving average, taking it as is for the first observation.
+func ewma(avg, v float64, first bool) float64 {
+	if first {
+		return v
+	}
+	return avg + routingDecay*(v-avg)
+}
+
+// rank returns the indices of the endpoints in the order of preference.
+// Endpoints whose circuit breaker rejects calls come last.
+func (p *proxy) rank() []int {
+	scores := make([]float64, len(p.endpoints))
+	available := make([]bool, len(p.endpoints))
+	idxs := make([]int, len(p.endpoints))
+	for i, ep := range p.endpoints {
+		idxs[i], scores[i], available[i] = i, ep.stats.score(), ep.breaker.available()
+	}
+	sort.SliceStable(idxs, func(i, j int) bool {
+		if available[idxs[i]] != available[idxs[j]] {
+			return available[idxs[i]]
+		}
+		return scores[idxs[i]] < scores[idxs[j]]
+	})
+	return idxs
+}
+
+// Endpoints returns the stats of the endpoints the client is connected to,
+// most preferred first.
+func (c *Client) Endpoints() []EndpointStats {
+	stats := make([]EndpointStats, 0, len(c.proxy.endpoints))
+	for _, idx := range c.proxy.rank() {
+		ep := c.proxy.endpoints[idx]
+		s := ep.stats.snapshot(ep.addr)
+		s.CircuitOpen = ep.breaker.isOpen()
+		stats = append(stats, s)
+	}
+	return stats
+}
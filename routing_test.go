@@ -0,0 +1,69 @@
+package client
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+// errConnection is an error caused by the connection to the node.
+var errConnection = errors.New(wsConnectionClosed)
+
+func TestEndpointStats(t *testing.T) {
+	var s endpointStats
+	s.observe(100*time.Millisecond, nil)
+	require.Equal(t, 100*time.Millisecond, s.snapshot("a").Latency)
+
+	s.observe(200*time.Millisecond, nil)
+	// the latest observation is weighted with routingDecay
+	require.Equal(t, 120*time.Millisecond, s.snapshot("a").Latency)
+
+	// cancelled calls are ignored
+	s.observe(time.Hour, context.Canceled)
+	require.EqualValues(t, 2, s.snapshot("a").Calls)
+
+	s.observe(time.Second, errConnection)
+	snap := s.snapshot("a")
+	require.EqualValues(t, 3, snap.Calls)
+	require.EqualValues(t, 1, snap.Failures)
+	require.InDelta(t, routingDecay, snap.FailureRate, 1e-9)
+	// failed calls don't affect the latency
+	require.Equal(t, 120*time.Millisecond, snap.Latency)
+	require.Equal(t, snap.Score, s.score())
+}
+
+func TestRank(t *testing.T) {
+	p := testProxy("slow", "fast", "failing")
+	p.endpoints[0].stats.observe(500*time.Millisecond, nil)
+	p.endpoints[1].stats.observe(50*time.Millisecond, nil)
+	p.endpoints[2].stats.observe(10*time.Millisecond, nil)
+	p.endpoints[2].stats.observe(10*time.Millisecond, errConnection)
+	require.Equal(t, []int{1, 0, 2}, p.rank())
+
+	// endpoints with an open circuit come last
+	p.endpoints[1].breaker = newCircuitBreaker(1, time.Hour, realClock{})
+	p.endpoints[1].breaker.record(0, errConnection)
+	require.Equal(t, []int{0, 2, 1}, p.rank())
+
+	c := &Client{proxy: p}
+	stats := c.Endpoints()
+	require.Len(t, stats, 3)
+	require.Equal(t, "slow", stats[0].Addr)
+	require.True(t, stats[2].CircuitOpen)
+}
+
+func TestRankPrefersUnobserved(t *testing.T) {
+	p := testProxy("observed", "new")
+	p.endpoints[0].stats.observe(time.Millisecond, nil)
+	require.Equal(t, []int{1, 0}, p.rank())
+}
+
+func TestEWMA(t *testing.T) {
+	require.InDelta(t, 10.0, ewma(0, 10, true), 1e-9)
+	require.InDelta(t, 12.0, ewma(10, 20, false), 1e-9)
+	require.InDelta(t, 8.0, ewma(10, 0, false), 1e-9)
+	require.False(t, isConnectionError(errors.New("blob: not found")))
+}
@@ -0,0 +1,156 @@
+// Package rpctest provides an in-process fake of the celestia-node JSON-RPC API, so the client
+// and code built on it can be tested without running a node.
+package rpctest
+
+import (
+	"context"
+	"net/http/httptest"
+	"strings"
+
+	"github.com/filecoin-project/go-jsonrpc"
+
+	"github.com/celestiaorg/celestia-openrpc/mocks"
+	"github.com/celestiaorg/celestia-openrpc/types/blob"
+	"github.com/celestiaorg/celestia-openrpc/types/da"
+	"github.com/celestiaorg/celestia-openrpc/types/das"
+	"github.com/celestiaorg/celestia-openrpc/types/fraud"
+	"github.com/celestiaorg/celestia-openrpc/types/header"
+	"github.com/celestiaorg/celestia-openrpc/types/node"
+	"github.com/celestiaorg/celestia-openrpc/types/p2p"
+	"github.com/celestiaorg/celestia-openrpc/types/share"
+	"github.com/celestiaorg/celestia-openrpc/types/state"
+)
+
+// APIVersion is the node API version reported by the Server.
+const APIVersion = "v0.13.0"
+
+// lightNode is the type of light nodes reported by node.Info.
+const lightNode node.Type = 1
+
+// Server serves the modules of celestia-node over HTTP and WebSocket. The blob and header
+// modules are backed by in-memory stores and the node module reports the server as ready.
+// Methods of the other modules fail with mocks.ErrNotMocked unless replaced with WithModule.
+// Tokens aren't checked, so any token, including an empty one, is accepted.
+type Server struct {
+	// URL is the HTTP address of the server, e.g. "http://127.0.0.1:38021".
+	URL string
+	// Blobs holds the blobs submitted to the server.
+	Blobs *mocks.BlobStore
+	// Headers holds the headers served by the server.
+	Headers *mocks.HeaderStore
+
+	srv *httptest.Server
+}
+
+type config struct {
+	blobs   *mocks.BlobStore
+	headers *mocks.HeaderStore
+	modules map[string]interface{}
+}
+
+// Option configures a Server.
+type Option func(*config)
+
+// WithBlobStore backs the blob module with the given store instead of an empty one.
+func WithBlobStore(store *mocks.BlobStore) Option {
+	return func(cfg *config) {
+		cfg.blobs = store
+	}
+}
+
+// WithHeaderStore backs the header module with the given store instead of an empty one.
+func WithHeaderStore(store *mocks.HeaderStore) Option {
+	return func(cfg *config) {
+		cfg.headers = store
+	}
+}
+
+// WithModule serves the module under the given RPC namespace, replacing the fake one.
+// The module is either a pointer to one of the API structs, e.g. one filled by mocks.Stub,
+// or a value whose methods implement the namespace, such as a blob.Module.
+func WithModule(namespace string, module interface{}) Option {
+	return func(cfg *config) {
+		cfg.modules[namespace] = module
+	}
+}
+
+// NewServer starts a Server. It must be closed once the test is done.
+func NewServer(opts ...Option) *Server {
+	cfg := &config{modules: make(map[string]interface{})}
+	for _, opt := range opts {
+		opt(cfg)
+	}
+	if cfg.blobs == nil {
+		cfg.blobs = mocks.NewBlobStore()
+	}
+	if cfg.headers == nil {
+		cfg.headers = mocks.NewHeaderStore()
+	}
+
+	modules := map[string]interface{}{
+		"fraud":  mocks.Stub(&fraud.API{}),
+		"blob":   cfg.blobs.API(),
+		"header": cfg.headers.API(),
+		"state":  mocks.Stub(&state.API{}),
+		"share":  mocks.Stub(&share.API{}),
+		"das":    mocks.Stub(&das.API{}),
+		"p2p":    mocks.Stub(&p2p.API{}),
+		"node": mocks.Stub(&node.API{
+			Info: func(context.Context) (node.Info, error) {
+				return node.Info{Type: lightNode, APIVersion: APIVersion}, nil
+			},
+			Ready: func(context.Context) (bool, error) {
+				return true, nil
+			},
+		}),
+		"da": mocks.Stub(&da.API{}),
+	}
+	for name, module := range cfg.modules {
+		modules[name] = module
+	}
+
+	rpc := jsonrpc.NewServer()
+	for name, module := range modules {
+		rpc.Register(name, handler(module))
+	}
+	srv := httptest.NewServer(rpc)
+	return &Server{URL: srv.URL, Blobs: cfg.blobs, Headers: cfg.headers, srv: srv}
+}
+
+// WebSocketURL returns the WebSocket address of the server, required for subscriptions.
+func (s *Server) WebSocketURL() string {
+	return "ws" + strings.TrimPrefix(s.URL, "http")
+}
+
+// Close shuts the server down, closing all connections.
+func (s *Server) Close() {
+	s.srv.CloseClientConnections()
+	s.srv.Close()
+}
+
+// handler returns the JSON-RPC handler of the module. API structs are wrapped,
+// as the server only serves methods, while other values are served as they are.
+func handler(module interface{}) interface{} {
+	switch m := module.(type) {
+	case *blob.API:
+		return blob.Wrap(m)
+	case *da.API:
+		return da.Wrap(m)
+	case *das.API:
+		return das.Wrap(m)
+	case *fraud.API:
+		return fraud.Wrap(m)
+	case *header.API:
+		return header.Wrap(m)
+	case *node.API:
+		return node.Wrap(m)
+	case *p2p.API:
+		return p2p.Wrap(m)
+	case *share.API:
+		return share.Wrap(m)
+	case *state.API:
+		return state.Wrap(m)
+	default:
+		return module
+	}
+}
@@ -0,0 +1,121 @@
+package rpctest_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	client "github.com/celestiaorg/celestia-openrpc"
+	"github.com/celestiaorg/celestia-openrpc/mocks"
+	"github.com/celestiaorg/celestia-openrpc/rpctest"
+	"github.com/celestiaorg/celestia-openrpc/types/blob"
+	"github.com/celestiaorg/celestia-openrpc/types/node"
+	"github.com/celestiaorg/celestia-openrpc/types/share"
+)
+
+func TestServer(t *testing.T) {
+	ctx := context.Background()
+	srv := rpctest.NewServer()
+	defer srv.Close()
+
+	c, err := client.NewClient(ctx, srv.URL, "")
+	require.NoError(t, err)
+	defer c.Close()
+
+	namespace, err := share.NewBlobNamespaceV0([]byte("rpctest"))
+	require.NoError(t, err)
+	b, err := blob.NewBlobV0(namespace, []byte("data"))
+	require.NoError(t, err)
+	height, err := c.Blob.Submit(ctx, []*blob.Blob{b}, blob.NewSubmitOptions())
+	require.NoError(t, err)
+	require.Equal(t, srv.Blobs.Height(), height)
+
+	got, err := c.Blob.Get(ctx, height, namespace, b.Commitment)
+	require.NoError(t, err)
+	require.Equal(t, b.Data, got.Data)
+	require.Equal(t, b.Commitment, got.Commitment)
+	_, err = c.Blob.Get(ctx, height+1, namespace, b.Commitment)
+	require.ErrorIs(t, err, client.ErrBlobNotFound)
+
+	srv.Headers.Append(mocks.NewHeader(1), mocks.NewHeader(2))
+	head, err := c.Header.LocalHead(ctx)
+	require.NoError(t, err)
+	require.EqualValues(t, 2, head.Height())
+	_, err = c.Header.GetByHeight(ctx, 3)
+	require.ErrorIs(t, err, client.ErrHeightFromFuture)
+
+	_, err = c.DAS.SamplingStats(ctx)
+	require.ErrorContains(t, err, mocks.ErrNotMocked.Error())
+}
+
+func TestServerSubscription(t *testing.T) {
+	srv := rpctest.NewServer()
+	defer srv.Close()
+
+	c, err := client.NewClient(context.Background(), srv.WebSocketURL(), "")
+	require.NoError(t, err)
+	defer c.Close()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	sub, err := c.Header.Subscribe(ctx)
+	require.NoError(t, err)
+
+	srv.Headers.Append(mocks.NewHeader(1))
+	select {
+	case h := <-sub:
+		require.EqualValues(t, 1, h.Height())
+	case <-time.After(time.Second):
+		t.Fatal("header wasn't delivered")
+	}
+}
+
+func TestServerBlobSubscription(t *testing.T) {
+	srv := rpctest.NewServer()
+	defer srv.Close()
+
+	c, err := client.NewClient(context.Background(), srv.WebSocketURL(), "")
+	require.NoError(t, err)
+	defer c.Close()
+
+	namespace, err := share.NewBlobNamespaceV0([]byte("subscribed"))
+	require.NoError(t, err)
+	other, err := share.NewBlobNamespaceV0([]byte("other"))
+	require.NoError(t, err)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	sub, err := c.Blob.Subscribe(ctx, namespace)
+	require.NoError(t, err)
+
+	b, err := blob.NewBlobV0(namespace, []byte("data"))
+	require.NoError(t, err)
+	otherBlob, err := blob.NewBlobV0(other, []byte("other"))
+	require.NoError(t, err)
+	height, err := c.Blob.Submit(ctx, []*blob.Blob{b, otherBlob}, blob.NewSubmitOptions())
+	require.NoError(t, err)
+
+	select {
+	case resp := <-sub:
+		require.Equal(t, height, resp.Height)
+		require.Len(t, resp.Blobs, 1)
+		require.Equal(t, b.Commitment, resp.Blobs[0].Commitment)
+	case <-time.After(time.Second):
+		t.Fatal("blobs weren't delivered")
+	}
+}
+
+func TestServerWithModule(t *testing.T) {
+	srv := rpctest.NewServer(rpctest.WithModule("node", mocks.Stub(&node.API{
+		Info: func(context.Context) (node.Info, error) {
+			return node.Info{APIVersion: "v0.1.0"}, nil
+		},
+	})))
+	defer srv.Close()
+
+	_, err := client.NewClient(context.Background(), srv.URL, "", client.WithStrictVersionCheck())
+	var incompatible *client.IncompatibleVersionError
+	require.ErrorAs(t, err, &incompatible)
+}
@@ -0,0 +1,181 @@
+package client
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+
+	"github.com/celestiaorg/rsmt2d"
+
+	"github.com/celestiaorg/celestia-openrpc/types/header"
+	"github.com/celestiaorg/celestia-openrpc/types/share"
+)
+
+// GetShareAtHeight returns the share at the given row and column of the extended data
+// square of the block at the height, fetching the header of the block first.
+func (c *Client) GetShareAtHeight(ctx context.Context, height uint64, row, col int) (*share.Share, error) {
+	eh, err := c.headerAt(ctx, height)
+	if err != nil {
+		return nil, err
+	}
+	return c.Share.GetShare(ctx, eh, row, col)
+}
+
+// GetEDSAtHeight returns the extended data square of the block at the height, fetching
+// the header of the block first.
+func (c *Client) GetEDSAtHeight(ctx context.Context, height uint64) (*rsmt2d.ExtendedDataSquare, error) {
+	eh, err := c.headerAt(ctx, height)
+	if err != nil {
+		return nil, err
+	}
+	return c.Share.GetEDS(ctx, eh)
+}
+
+// GetSharesByNamespaceAtHeight returns the shares of the namespace in the block at the
+// height, fetching the header of the block first. On clients constructed with
+// WithProofVerification, the shares are proven against the header.
+func (c *Client) GetSharesByNamespaceAtHeight(
+	ctx context.Context,
+	height uint64,
+	namespace share.Namespace,
+) (*share.NamespacedShares, error) {
+	eh, err := c.headerAt(ctx, height)
+	if err != nil {
+		return nil, err
+	}
+	return c.Share.GetSharesByNamespace(ctx, eh, namespace)
+}
+
+// StreamEDS retrieves the extended data square of the block at the height and calls fn
+// with every row of the square, in order, as it is decoded from the response of the node.
+// Unlike GetEDSAtHeight, neither the response nor the square are held in memory as a
+// whole, which suits squares of hundreds of megabytes. Streaming stops at the first error
+// returned by fn. The square is requested from the most preferred endpoint, bypassing
+// the options applied to regular calls, such as retries or caching.
+func (c *Client) StreamEDS(ctx context.Context, height uint64, fn func(row int, shares []share.Share) error) error {
+	eh, err := c.headerAt(ctx, height)
+	if err != nil {
+		return err
+	}
+	body, err := json.Marshal(batchRequest{Jsonrpc: "2.0", Method: "share.GetEDS", Params: []interface{}{eh}})
+	if err != nil {
+		return fmt.Errorf("marshaling request: %w", err)
+	}
+	ep := c.proxy.endpoints[c.proxy.rank()[0]]
+	resp, err := ep.post(ctx, body)
+	if err != nil {
+		return fmt.Errorf("requesting square at height %d: %w", height, err)
+	}
+	defer resp.Close()
+	return decodeEDSRows(resp, len(eh.DAH.RowRoots), fn)
+}
+
+// decodeEDSRows decodes the response of share.GetEDS, calling fn with every row of the
+// square of the given width as soon as its shares are decoded.
+func decodeEDSRows(r io.Reader, width int, fn func(row int, shares []share.Share) error) error {
+	dec := json.NewDecoder(r)
+	if err := expectDelim(dec, '{'); err != nil {
+		return err
+	}
+	for dec.More() {
+		key, err := dec.Token()
+		if err != nil {
+			return err
+		}
+		switch key {
+		case "result":
+			return decodeSquare(dec, width, fn)
+		case "error":
+			var obj errorObject
+			if err := dec.Decode(&obj); err != nil {
+				return err
+			}
+			return mapNodeError(obj.rpcError())
+		default:
+			var skipped json.RawMessage
+			if err := dec.Decode(&skipped); err != nil {
+				return err
+			}
+		}
+	}
+	return errors.New("response has no result")
+}
+
+// decodeSquare decodes the shares of the encoded rsmt2d.ExtendedDataSquare row by row.
+func decodeSquare(dec *json.Decoder, width int, fn func(row int, shares []share.Share) error) error {
+	if err := expectDelim(dec, '{'); err != nil {
+		return err
+	}
+	for dec.More() {
+		key, err := dec.Token()
+		if err != nil {
+			return err
+		}
+		if key != "data_square" {
+			var skipped json.RawMessage
+			if err := dec.Decode(&skipped); err != nil {
+				return err
+			}
+			continue
+		}
+		if err := expectDelim(dec, '['); err != nil {
+			return err
+		}
+		var rows int
+		shares := make([]share.Share, 0, width)
+		for dec.More() {
+			var shr share.Share
+			if err := dec.Decode(&shr); err != nil {
+				return fmt.Errorf("decoding share %d of row %d: %w", len(shares), rows, err)
+			}
+			if shares = append(shares, shr); len(shares) < width {
+				continue
+			}
+			if rows == width {
+				return fmt.Errorf("square has more than %d rows", width)
+			}
+			if err := fn(rows, shares); err != nil {
+				return err
+			}
+			rows++
+			shares = make([]share.Share, 0, width)
+		}
+		if rows != width || len(shares) != 0 {
+			return fmt.Errorf("square has %d full rows, expected %d", rows, width)
+		}
+		return nil
+	}
+	return errors.New("result has no data square")
+}
+
+// expectDelim consumes the next token of the decoder, failing if it isn't the delimiter.
+func expectDelim(dec *json.Decoder, delim json.Delim) error {
+	tok, err := dec.Token()
+	if err != nil {
+		return err
+	}
+	if tok != delim {
+		return fmt.Errorf("unexpected %v in response, expected %v", tok, delim)
+	}
+	return nil
+}
+
+// headerAt returns the header at the height, checking that it is the one requested and
+// that its data availability header is the one committed to by its data hash, so the
+// shares retrieved with it are those of the block.
+func (c *Client) headerAt(ctx context.Context, height uint64) (*header.ExtendedHeader, error) {
+	eh, err := c.Header.GetByHeight(ctx, height)
+	if err != nil {
+		return nil, fmt.Errorf("getting header at height %d: %w", height, err)
+	}
+	if eh == nil || eh.Height() != height {
+		return nil, fmt.Errorf("node returned a header for another height than %d", height)
+	}
+	if eh.DAH == nil || !bytes.Equal(eh.DAH.Hash(), eh.DataHash) {
+		return nil, fmt.Errorf("data availability header at height %d doesn't match its data hash", height)
+	}
+	return eh, nil
+}
@@ -0,0 +1,153 @@
+package client
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/celestiaorg/rsmt2d"
+	"github.com/stretchr/testify/require"
+
+	"github.com/celestiaorg/celestia-openrpc/mocks"
+	"github.com/celestiaorg/celestia-openrpc/types/appconsts"
+	"github.com/celestiaorg/celestia-openrpc/types/blob"
+	"github.com/celestiaorg/celestia-openrpc/types/core"
+	"github.com/celestiaorg/celestia-openrpc/types/header"
+	"github.com/celestiaorg/celestia-openrpc/types/share"
+)
+
+func TestGetSharesAtHeight(t *testing.T) {
+	eh := mocks.NewHeader(5)
+	eh.DAH = &header.DataAvailabilityHeader{RowRoots: make([][]byte, 2), ColumnRoots: make([][]byte, 2)}
+	eh.DataHash = eh.DAH.Hash()
+
+	c := &Client{}
+	c.Header.GetByHeight = func(_ context.Context, height uint64) (*header.ExtendedHeader, error) {
+		if height != 5 {
+			return mocks.NewHeader(height + 1), nil
+		}
+		return eh, nil
+	}
+	c.Share.GetShare = func(_ context.Context, got *header.ExtendedHeader, row, col int) (*share.Share, error) {
+		require.Same(t, eh, got)
+		shr := share.Share{byte(row), byte(col)}
+		return &shr, nil
+	}
+	c.Share.GetSharesByNamespace = func(
+		_ context.Context,
+		got *header.ExtendedHeader,
+		_ share.Namespace,
+	) (*share.NamespacedShares, error) {
+		require.Same(t, eh, got)
+		return &share.NamespacedShares{}, nil
+	}
+
+	shr, err := c.GetShareAtHeight(context.Background(), 5, 1, 2)
+	require.NoError(t, err)
+	require.Equal(t, share.Share{1, 2}, *shr)
+	_, err = c.GetSharesByNamespaceAtHeight(context.Background(), 5, share.Namespace{})
+	require.NoError(t, err)
+
+	// the header must be the one of the height
+	_, err = c.GetShareAtHeight(context.Background(), 6, 1, 2)
+	require.ErrorContains(t, err, "another height")
+
+	// the data availability header must be committed to by the header
+	eh.DataHash = []byte("other")
+	_, err = c.GetEDSAtHeight(context.Background(), 5)
+	require.ErrorContains(t, err, "doesn't match its data hash")
+}
+
+func TestStreamEDS(t *testing.T) {
+	data := make([][]byte, 4)
+	for i := range data {
+		data[i] = bytes.Repeat([]byte{byte(i)}, share.Size)
+	}
+	eds, err := rsmt2d.ComputeExtendedDataSquare(data, appconsts.DefaultCodec(), rsmt2d.NewDefaultTree)
+	require.NoError(t, err)
+	result, err := json.Marshal(eds)
+	require.NoError(t, err)
+
+	eh := mocks.NewHeader(2)
+	eh.DAH = &header.DataAvailabilityHeader{RowRoots: make([][]byte, 4), ColumnRoots: make([][]byte, 4)}
+	eh.DataHash = eh.DAH.Hash()
+	transport := TransportFunc(func(_ context.Context, _ string, _ http.Header, body []byte) (io.ReadCloser, error) {
+		var req batchRequest
+		require.NoError(t, json.Unmarshal(body, &req))
+		require.Equal(t, "share.GetEDS", req.Method)
+		resp := `{"jsonrpc":"2.0","id":0,"result":` + string(result) + `}`
+		return io.NopCloser(bytes.NewReader([]byte(resp))), nil
+	})
+	c := &Client{proxy: newProxy([]*endpoint{{addr: "memory", transport: transport}})}
+	c.Header.GetByHeight = func(context.Context, uint64) (*header.ExtendedHeader, error) {
+		return eh, nil
+	}
+
+	var rows int
+	require.NoError(t, c.StreamEDS(context.Background(), 2, func(row int, shares []share.Share) error {
+		require.Equal(t, rows, row)
+		require.Equal(t, eds.Row(uint(row)), shares)
+		rows++
+		return nil
+	}))
+	require.Equal(t, 4, rows)
+
+	// streaming stops at the first error of the callback
+	stop := errors.New("stop")
+	require.ErrorIs(t, c.StreamEDS(context.Background(), 2, func(int, []share.Share) error {
+		return stop
+	}), stop)
+
+	// squares not matching the header are rejected
+	eh.DAH = &header.DataAvailabilityHeader{RowRoots: make([][]byte, 8), ColumnRoots: make([][]byte, 8)}
+	eh.DataHash = eh.DAH.Hash()
+	require.ErrorContains(t, c.StreamEDS(context.Background(), 2, func(int, []share.Share) error {
+		return nil
+	}), "expected 8")
+}
+
+func TestDecodeEDSRowsError(t *testing.T) {
+	resp := `{"jsonrpc":"2.0","id":0,"error":{"code":1,"message":"blob: not found"}}`
+	err := decodeEDSRows(bytes.NewReader([]byte(resp)), 2, func(int, []share.Share) error { return nil })
+	require.ErrorIs(t, err, ErrBlobNotFound)
+}
+
+// testSquare returns the extended data square of a block holding a blob of four shares,
+// along with its root.
+func testSquare(t *testing.T) (*rsmt2d.ExtendedDataSquare, *share.Root) {
+	t.Helper()
+	shares, err := blob.BlobsToShares(testBlob(t, strings.Repeat("x", 1500)))
+	require.NoError(t, err)
+	require.Len(t, shares, 4)
+	eds, err := rsmt2d.ComputeExtendedDataSquare(shares, appconsts.DefaultCodec(), share.NewConstructor(2))
+	require.NoError(t, err)
+	root, err := core.NewDataAvailabilityHeader(eds)
+	require.NoError(t, err)
+	return eds, &root
+}
+
+func TestVerifyEDS(t *testing.T) {
+	eds, root := testSquare(t)
+	// squares decoded from JSON are created with the default tree constructor
+	decoded, err := rsmt2d.ImportExtendedDataSquare(eds.Flattened(), appconsts.DefaultCodec(), rsmt2d.NewDefaultTree)
+	require.NoError(t, err)
+
+	eh := mocks.NewHeader(1)
+	eh.DAH = root
+	c := &Client{}
+	c.Share.GetEDS = func(context.Context, *header.ExtendedHeader) (*rsmt2d.ExtendedDataSquare, error) {
+		return decoded, nil
+	}
+	verifyProofs(c)
+	_, err = c.Share.GetEDS(context.Background(), eh)
+	require.NoError(t, err)
+
+	eh.DAH = &share.Root{RowRoots: root.ColumnRoots, ColumnRoots: root.RowRoots}
+	_, err = c.Share.GetEDS(context.Background(), eh)
+	require.ErrorIs(t, err, ErrVerificationFailed)
+}
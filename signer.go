@@ -0,0 +1,41 @@
+package client
+
+import (
+	"context"
+
+	"github.com/celestiaorg/celestia-openrpc/types/blob"
+	"github.com/celestiaorg/celestia-openrpc/types/state"
+)
+
+// validateSigners wraps the submission methods of the client to reject share version 1
+// blobs whose signer isn't the submitting account before they reach the node, as the
+// transaction would otherwise be rejected by celestia-app after paying for its checks.
+func validateSigners(c *Client) {
+	submit := c.Blob.Submit
+	c.Blob.Submit = func(ctx context.Context, blobs []*blob.Blob, opts *blob.SubmitOptions) (uint64, error) {
+		var signer string
+		if opts != nil {
+			signer = opts.SignerAddress()
+		}
+		if err := blob.ValidateSigners(blobs, signer); err != nil {
+			return 0, err
+		}
+		return submit(ctx, blobs, opts)
+	}
+
+	submitPayForBlob := c.State.SubmitPayForBlob
+	c.State.SubmitPayForBlob = func(
+		ctx context.Context,
+		blobs []*blob.Blob,
+		config *state.TxConfig,
+	) (*state.TxResponse, error) {
+		var signer string
+		if config != nil {
+			signer = config.SignerAddress()
+		}
+		if err := blob.ValidateSigners(blobs, signer); err != nil {
+			return nil, err
+		}
+		return submitPayForBlob(ctx, blobs, config)
+	}
+}
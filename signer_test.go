@@ -0,0 +1,55 @@
+package client
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/celestiaorg/celestia-openrpc/types/blob"
+	"github.com/celestiaorg/celestia-openrpc/types/share"
+)
+
+// testSigner is the account encoded by testSignerAddress.
+var testSigner = []byte{1, 2, 3, 4, 5, 6, 7, 8, 9, 10, 11, 12, 13, 14, 15, 16, 17, 18, 19, 20}
+
+const (
+	testSignerAddress  = "celestia1qypqxpq9qcrsszg2pvxq6rs0zqg3yyc5wgawu3"
+	otherSignerAddress = "celestia142424242424242424242424242424242v52yp3"
+)
+
+func signedBlob(t *testing.T, data string) *blob.Blob {
+	t.Helper()
+	namespace, err := share.NewBlobNamespaceV0([]byte{1, 2, 3, 4})
+	require.NoError(t, err)
+	b, err := blob.NewBlobV1(namespace, []byte(data), testSigner)
+	require.NoError(t, err)
+	return b
+}
+
+func TestValidateSigners(t *testing.T) {
+	var submitted int
+	c := &Client{}
+	c.Blob.Submit = func(context.Context, []*blob.Blob, *blob.SubmitOptions) (uint64, error) {
+		submitted++
+		return 1, nil
+	}
+	validateSigners(c)
+	ctx := context.Background()
+	blobs := []*blob.Blob{testBlob(t, "unsigned"), signedBlob(t, "signed")}
+
+	_, err := c.Blob.Submit(ctx, blobs, blob.NewSubmitOptions(blob.WithSignerAddress(testSignerAddress)))
+	require.NoError(t, err)
+
+	_, err = c.Blob.Submit(ctx, blobs, blob.NewSubmitOptions(blob.WithSignerAddress(otherSignerAddress)))
+	require.ErrorIs(t, err, blob.ErrSignerMismatch)
+	_, err = c.Blob.Submit(ctx, blobs, nil)
+	require.ErrorIs(t, err, blob.ErrSignerMismatch)
+	_, err = c.Blob.Submit(ctx, blobs, blob.NewSubmitOptions(blob.WithSignerAddress(testSignerAddress[:len(testSignerAddress)-1]+"q")))
+	require.Error(t, err)
+
+	// unsigned blobs can be submitted by any account
+	_, err = c.Blob.Submit(ctx, blobs[:1], nil)
+	require.NoError(t, err)
+	require.Equal(t, 2, submitted)
+}
@@ -0,0 +1,191 @@
+package client
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+
+	"github.com/celestiaorg/celestia-openrpc/types/blob"
+	"github.com/celestiaorg/celestia-openrpc/types/share"
+)
+
+// DefaultReaderChunkSize is the number of shares blob readers fetch at once by default,
+// which amounts to about 30KiB of blob data.
+const DefaultReaderChunkSize = 64
+
+// ReaderOption is the functional option that is applied to the blob reader
+// to configure its parameters.
+type ReaderOption func(r *blobReader)
+
+// WithReaderChunkSize is an option that allows to specify the number of shares
+// fetched by every request of the blob reader.
+func WithReaderChunkSize(shares int) ReaderOption {
+	return func(r *blobReader) {
+		if shares > 0 {
+			r.chunkSize = shares
+		}
+	}
+}
+
+// OpenBlob returns a reader of the data of the blob, which fetches the shares of the blob
+// in chunks as they are read instead of retrieving the whole blob at once. The blob is
+// located with its commitment proof, so neither the node nor the client ever hold the
+// whole blob, which suits blobs of several megabytes. On clients constructed with
+// WithProofVerification, every chunk is proven before it is read.
+func (c *Client) OpenBlob(
+	ctx context.Context,
+	height uint64,
+	namespace share.Namespace,
+	commitment blob.Commitment,
+	opts ...ReaderOption,
+) (io.Reader, error) {
+	proof, err := c.Blob.GetCommitmentProof(ctx, height, namespace, commitment)
+	if err != nil {
+		return nil, err
+	}
+	eh, err := c.Header.GetByHeight(ctx, height)
+	if err != nil {
+		return nil, err
+	}
+	width, err := squareWidth(eh)
+	if err != nil {
+		return nil, err
+	}
+	start, end, err := proofShareRange(proof, width)
+	if err != nil {
+		return nil, fmt.Errorf("locating blob at height %d: %w", height, err)
+	}
+
+	r := &blobReader{
+		ctx:       ctx,
+		getRange:  c.Share.GetRange,
+		height:    height,
+		namespace: namespace,
+		start:     start,
+		next:      start,
+		end:       end,
+		chunkSize: DefaultReaderChunkSize,
+	}
+	for _, opt := range opts {
+		opt(r)
+	}
+	return r, nil
+}
+
+// proofShareRange returns the range of shares [start, end) of the original data square
+// of the given width whose subtree roots are proven by the commitment proof.
+func proofShareRange(proof *blob.CommitmentProof, width int) (start, end int, err error) {
+	if proof == nil || len(proof.SubtreeRootProofs) == 0 || proof.SubtreeRootProofs[0] == nil {
+		return 0, 0, errors.New("commitment proof has no shares")
+	}
+	// the blob starts at the first share proven in the first row of the proof
+	start = int(proof.RowProof.StartRow)*width + proof.SubtreeRootProofs[0].Start()
+	end = start
+	for _, p := range proof.SubtreeRootProofs {
+		if p == nil {
+			return 0, 0, errors.New("missing subtree root proof")
+		}
+		end += p.End() - p.Start()
+	}
+	return start, end, nil
+}
+
+// blobReader reads the data of a blob from the range of shares [start, end) of the square.
+type blobReader struct {
+	ctx       context.Context
+	getRange  func(context.Context, uint64, int, int) (*share.GetRangeResult, error)
+	height    uint64
+	namespace share.Namespace
+	chunkSize int
+
+	start, next, end int
+	// remaining is the number of bytes of data left in the shares past next,
+	// known once the first share is fetched.
+	remaining int
+	buf       []byte
+	err       error
+}
+
+// Read implements io.Reader.
+func (r *blobReader) Read(p []byte) (int, error) {
+	for len(r.buf) == 0 {
+		if r.err != nil {
+			return 0, r.err
+		}
+		if r.next == r.end {
+			return 0, io.EOF
+		}
+		if err := r.fetch(); err != nil {
+			r.err = err
+			return 0, err
+		}
+	}
+	n := copy(p, r.buf)
+	r.buf = r.buf[n:]
+	return n, nil
+}
+
+// fetch retrieves the next chunk of shares, appending their data to the buffer.
+func (r *blobReader) fetch() error {
+	end := min(r.next+r.chunkSize, r.end)
+	res, err := r.getRange(r.ctx, r.height, r.next, end)
+	if err != nil {
+		return fmt.Errorf("fetching shares [%d, %d) of blob at height %d: %w", r.next, end, r.height, err)
+	}
+	if res == nil || len(res.Shares) != end-r.next {
+		return fmt.Errorf("fetching shares [%d, %d) of blob at height %d: range is incomplete", r.next, end, r.height)
+	}
+
+	for i, shr := range res.Shares {
+		appShare, err := share.NewShare(shr)
+		if err != nil {
+			return err
+		}
+		if !bytes.Equal(share.GetNamespace(shr), r.namespace) {
+			return fmt.Errorf("share %d of blob at height %d is of another namespace", r.next+i, r.height)
+		}
+		isStart, err := appShare.IsSequenceStart()
+		if err != nil {
+			return err
+		}
+		if isStart != (r.next+i == r.start) {
+			return fmt.Errorf("share %d of blob at height %d doesn't belong to the blob", r.next+i, r.height)
+		}
+		if isStart {
+			if err := r.readSequenceLen(appShare); err != nil {
+				return err
+			}
+		}
+
+		data, err := appShare.RawData()
+		if err != nil {
+			return err
+		}
+		data = data[:min(len(data), r.remaining)]
+		r.remaining -= len(data)
+		r.buf = append(r.buf, data...)
+	}
+	r.next = end
+	return nil
+}
+
+// readSequenceLen reads the length of the blob from its first share, checking that
+// it matches the number of shares the blob was located at.
+func (r *blobReader) readSequenceLen(appShare *share.AppShare) error {
+	seqLen, err := appShare.SequenceLen()
+	if err != nil {
+		return err
+	}
+	signer, err := appShare.Signer()
+	if err != nil {
+		return err
+	}
+	//nolint:gosec
+	if share.SparseSharesNeeded(seqLen+uint32(len(signer))) != r.end-r.start {
+		return errors.New("length of the blob doesn't match its commitment proof")
+	}
+	r.remaining = int(seqLen)
+	return nil
+}
@@ -0,0 +1,80 @@
+package client
+
+import (
+	"context"
+	"io"
+	"strings"
+	"testing"
+
+	"github.com/celestiaorg/nmt"
+	"github.com/stretchr/testify/require"
+
+	"github.com/celestiaorg/celestia-openrpc/mocks"
+	"github.com/celestiaorg/celestia-openrpc/types/blob"
+	"github.com/celestiaorg/celestia-openrpc/types/header"
+	"github.com/celestiaorg/celestia-openrpc/types/proofs"
+	"github.com/celestiaorg/celestia-openrpc/types/share"
+)
+
+func TestOpenBlob(t *testing.T) {
+	b := testBlob(t, strings.Repeat("s", 2000))
+	blobShares, err := blob.BlobsToShares(b)
+	require.NoError(t, err)
+	require.Len(t, blobShares, 5)
+
+	// the blob takes up the last three shares of the second row and two of the third one
+	// of a square of width 4, the other shares are padding
+	padding, err := share.NamespacePaddingShare(b.Namespace())
+	require.NoError(t, err)
+	square := make([]share.Share, 16)
+	for i := range square {
+		square[i] = padding.ToBytes()
+	}
+	copy(square[5:], blobShares)
+	firstRow, err := rowTree(t, square[4:8]).ProveRange(1, 4)
+	require.NoError(t, err)
+	secondRow, err := rowTree(t, square[8:12]).ProveRange(0, 2)
+	require.NoError(t, err)
+	proof := &blob.CommitmentProof{
+		SubtreeRootProofs: []*nmt.Proof{&firstRow, &secondRow},
+		RowProof:          proofs.RowProof{StartRow: 1, EndRow: 2},
+	}
+
+	eh := mocks.NewHeader(3)
+	eh.DAH = &header.DataAvailabilityHeader{RowRoots: make([][]byte, 8), ColumnRoots: make([][]byte, 8)}
+	var ranges [][2]int
+	c := &Client{}
+	c.Blob.GetCommitmentProof = func(context.Context, uint64, share.Namespace, []byte) (*blob.CommitmentProof, error) {
+		return proof, nil
+	}
+	c.Header.GetByHeight = func(context.Context, uint64) (*header.ExtendedHeader, error) {
+		return eh, nil
+	}
+	c.Share.GetRange = func(_ context.Context, _ uint64, start, end int) (*share.GetRangeResult, error) {
+		ranges = append(ranges, [2]int{start, end})
+		return &share.GetRangeResult{Shares: square[start:end]}, nil
+	}
+
+	ctx := context.Background()
+	namespace := share.Namespace(b.Namespace().Bytes())
+	r, err := c.OpenBlob(ctx, 3, namespace, b.Commitment, WithReaderChunkSize(2))
+	require.NoError(t, err)
+	data, err := io.ReadAll(r)
+	require.NoError(t, err)
+	require.Equal(t, b.Data, data)
+	require.Equal(t, [][2]int{{5, 7}, {7, 9}, {9, 10}}, ranges)
+
+	// a proof which doesn't cover the whole blob is caught on the first read
+	proof.SubtreeRootProofs = proof.SubtreeRootProofs[:1]
+	r, err = c.OpenBlob(ctx, 3, namespace, b.Commitment)
+	require.NoError(t, err)
+	_, err = io.ReadAll(r)
+	require.Error(t, err)
+
+	// as are shares which don't belong to the blob
+	proof.SubtreeRootProofs = []*nmt.Proof{&secondRow}
+	r, err = c.OpenBlob(ctx, 3, namespace, b.Commitment)
+	require.NoError(t, err)
+	_, err = io.ReadAll(r)
+	require.Error(t, err)
+}
@@ -0,0 +1,154 @@
+package client
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"reflect"
+
+	"github.com/celestiaorg/celestia-openrpc/types/blob"
+	"github.com/celestiaorg/celestia-openrpc/types/core"
+	"github.com/celestiaorg/celestia-openrpc/types/header"
+)
+
+// ErrStrictDecoding is returned in strict mode for results containing fields unknown to the
+// client or values of unexpected types.
+var ErrStrictDecoding = errors.New("strict decoding failed")
+
+// expectedResult describes the result of a call as expected by the client.
+type expectedResult struct {
+	method string
+	typ    reflect.Type
+}
+
+// expectedResultKey is the context key passing the expected result of a call to its HTTP round trips.
+type expectedResultKey struct{}
+
+// expectResults returns an interceptor passing the expected type of the result of the call
+// to the HTTP round trips made for it, which are validated by strictRoundTripper.
+func expectResults(p *proxy) interceptor {
+	return func(ctx context.Context, c *call, next invoker) ([]interface{}, error) {
+		if typ, ok := resultType(p, c); ok {
+			ctx = context.WithValue(ctx, expectedResultKey{}, expectedResult{method: c.name(), typ: typ})
+		}
+		return next(ctx, c)
+	}
+}
+
+// resultType returns the type of the result of the called method, unless it's a subscription.
+func resultType(p *proxy, c *call) (reflect.Type, bool) {
+	if len(p.endpoints) == 0 {
+		return nil, false
+	}
+	module, ok := p.endpoints[0].types[c.module]
+	if !ok {
+		return nil, false
+	}
+	field, ok := module.FieldByName(c.method)
+	if !ok || field.Type.Kind() != reflect.Func || field.Type.NumOut() != 2 {
+		return nil, false
+	}
+	typ := field.Type.Out(0)
+	return typ, typ.Kind() != reflect.Chan
+}
+
+// strictRoundTripper rejects responses whose results contain fields unknown to the client
+// or values of unexpected types, surfacing protocol drift between client and node versions
+// instead of silently dropping the data. Only requests labelled by expectResults are checked.
+type strictRoundTripper struct {
+	next http.RoundTripper
+}
+
+func (rt *strictRoundTripper) RoundTrip(req *http.Request) (*http.Response, error) {
+	expected, ok := req.Context().Value(expectedResultKey{}).(expectedResult)
+	if !ok {
+		return rt.next.RoundTrip(req)
+	}
+
+	resp, err := rt.next.RoundTrip(req)
+	if err != nil {
+		return nil, err
+	}
+	// the error objects of failed calls are sent with status 500
+	if (resp.StatusCode < 200 || resp.StatusCode > 299) && resp.StatusCode != http.StatusInternalServerError {
+		resp.Body.Close()
+		return nil, fmt.Errorf("request failed, http status %s", resp.Status)
+	}
+	raw, err := io.ReadAll(resp.Body)
+	resp.Body.Close()
+	if err != nil {
+		return nil, err
+	}
+
+	if err := validateResponse(raw, expected.typ); err != nil {
+		return nil, fmt.Errorf("%w: %s result: %w", ErrStrictDecoding, expected.method, err)
+	}
+	resp.Body = io.NopCloser(bytes.NewReader(raw))
+	return resp, nil
+}
+
+// validateResponse strictly decodes the result of the response into the expected type.
+func validateResponse(resp []byte, typ reflect.Type) error {
+	var msg struct {
+		Result json.RawMessage `json:"result"`
+	}
+	if err := json.Unmarshal(resp, &msg); err != nil || len(msg.Result) == 0 {
+		// malformed responses and errors are handled by the JSON-RPC client
+		return nil
+	}
+
+	dec := json.NewDecoder(bytes.NewReader(msg.Result))
+	dec.DisallowUnknownFields()
+	return dec.Decode(reflect.New(strictType(typ)).Interface())
+}
+
+// strictShapes maps types decoding themselves, which strict decoding doesn't reach into,
+// to structs mirroring their encoding. The raw header and the validator set of extended
+// headers are amino encoded and only checked for presence.
+var strictShapes = map[reflect.Type]reflect.Type{
+	reflect.TypeOf(blob.Blob{}): reflect.TypeOf(struct {
+		Namespace    []byte `json:"namespace"`
+		Data         []byte `json:"data"`
+		ShareVersion uint32 `json:"share_version"`
+		Commitment   []byte `json:"commitment"`
+		Signer       []byte `json:"signer"`
+		Index        int    `json:"index"`
+	}{}),
+	reflect.TypeOf(header.ExtendedHeader{}): reflect.TypeOf(struct {
+		RawHeader    json.RawMessage              `json:"header"`
+		Commit       *core.Commit                 `json:"commit"`
+		ValidatorSet json.RawMessage              `json:"validator_set"`
+		DAH          *core.DataAvailabilityHeader `json:"dah"`
+	}{}),
+}
+
+// strictType returns the type to strictly decode values of type t into, replacing the
+// types in strictShapes with their shapes, including as elements of pointers, slices and maps.
+// Types without any replaced element are returned as is.
+func strictType(t reflect.Type) reflect.Type {
+	if shape, ok := strictShapes[t]; ok {
+		return shape
+	}
+	kind := t.Kind()
+	if kind != reflect.Pointer && kind != reflect.Slice && kind != reflect.Array && kind != reflect.Map {
+		return t
+	}
+	elem := strictType(t.Elem())
+	if elem == t.Elem() {
+		return t
+	}
+	switch kind {
+	case reflect.Pointer:
+		return reflect.PointerTo(elem)
+	case reflect.Slice:
+		return reflect.SliceOf(elem)
+	case reflect.Array:
+		return reflect.ArrayOf(t.Len(), elem)
+	default:
+		return reflect.MapOf(t.Key(), elem)
+	}
+}
@@ -0,0 +1,127 @@
+package client
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"net/url"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/celestiaorg/celestia-openrpc/types/blob"
+	"github.com/celestiaorg/celestia-openrpc/types/header"
+	"github.com/celestiaorg/celestia-openrpc/types/share"
+)
+
+func TestStrictDecoding(t *testing.T) {
+	b := testBlob(t, "data")
+	raw, err := json.Marshal(b)
+	require.NoError(t, err)
+	var drifted map[string]interface{}
+	require.NoError(t, json.Unmarshal(raw, &drifted))
+	drifted["signer"] = "celestia1"
+
+	srv := testNode(t, func(_ *http.Request, method string, params json.RawMessage) (interface{}, error) {
+		switch method {
+		case "node.Info":
+			return map[string]interface{}{"type": 1, "api_version": "v0.13.0", "build": "dev"}, nil
+		case "node.Ready":
+			return true, nil
+		case "blob.Get":
+			var args []interface{}
+			if err := json.Unmarshal(params, &args); err != nil {
+				return nil, err
+			}
+			if args[0] == float64(1) {
+				return json.RawMessage(raw), nil
+			}
+			return drifted, nil
+		default:
+			return nil, errors.New("method not found")
+		}
+	})
+
+	rt := &countingRoundTripper{}
+	c, err := NewClient(context.Background(), srv.URL, "",
+		WithStrictDecoding(), WithTransport(HTTPTransport{Client: &http.Client{Transport: rt}}))
+	require.NoError(t, err)
+	defer c.Close()
+
+	_, err = c.Node.Ready(context.Background())
+	require.NoError(t, err)
+	_, err = c.Node.Info(context.Background())
+	require.ErrorIs(t, err, ErrStrictDecoding)
+	require.ErrorContains(t, err, "node.Info")
+
+	// the fields of blobs are checked despite them decoding themselves
+	got, err := c.Blob.Get(context.Background(), 1, share.Namespace(b.Namespace().Bytes()), b.Commitment)
+	require.NoError(t, err)
+	require.Equal(t, b.Data, got.Data)
+	_, err = c.Blob.Get(context.Background(), 2, share.Namespace(b.Namespace().Bytes()), b.Commitment)
+	require.ErrorIs(t, err, ErrStrictDecoding)
+
+	// the client of the transport is used
+	require.Positive(t, rt.requests)
+}
+
+func TestStrictDecodingRejectsWebSocket(t *testing.T) {
+	_, err := NewClient(context.Background(), "ws://localhost:26658", "", WithStrictDecoding())
+	require.ErrorContains(t, err, "strict decoding not supported over WebSocket")
+	_, err = NewClient(context.Background(), "http://localhost:26658", "",
+		WithStrictDecoding(), WithTransport(WebSocketTransport{}))
+	require.ErrorContains(t, err, "strict decoding not supported over WebSocket")
+}
+
+func TestStrictRoundTripperStatus(t *testing.T) {
+	for status, ok := range map[int]bool{
+		http.StatusOK:                  true,
+		http.StatusInternalServerError: true,
+		http.StatusBadRequest:          false,
+		http.StatusUnauthorized:        false,
+		http.StatusServiceUnavailable:  false,
+	} {
+		rt := &strictRoundTripper{next: roundTripperFunc(func(*http.Request) (*http.Response, error) {
+			return &http.Response{
+				StatusCode: status,
+				Status:     http.StatusText(status),
+				Body:       io.NopCloser(strings.NewReader(`{"jsonrpc":"2.0","id":1,"result":true}`)),
+			}, nil
+		})}
+
+		ctx := context.WithValue(context.Background(), expectedResultKey{},
+			expectedResult{method: "node.Ready", typ: reflect.TypeOf(true)})
+		req, err := http.NewRequestWithContext(ctx, http.MethodPost, "http://localhost", nil)
+		require.NoError(t, err)
+		resp, err := rt.RoundTrip(req)
+		if ok {
+			require.NoError(t, err, status)
+			require.NoError(t, resp.Body.Close())
+		} else {
+			require.ErrorContains(t, err, "http status", status)
+		}
+	}
+}
+
+func TestStrictType(t *testing.T) {
+	blobType := reflect.TypeOf(blob.Blob{})
+	require.Equal(t, strictShapes[blobType], strictType(blobType))
+	require.Equal(t, reflect.SliceOf(reflect.PointerTo(strictShapes[blobType])),
+		strictType(reflect.TypeOf([]*blob.Blob{})))
+	headerType := reflect.TypeOf(header.ExtendedHeader{})
+	require.Equal(t, reflect.PointerTo(strictShapes[headerType]), strictType(reflect.PointerTo(headerType)))
+
+	// types without shapes are kept, including named slices
+	commitment := reflect.TypeOf(blob.Commitment{})
+	require.Equal(t, commitment, strictType(commitment))
+}
+
+func TestStrictDecodingNotRetried(t *testing.T) {
+	err := &url.Error{Op: "Post", URL: "http://localhost:26658", Err: ErrStrictDecoding}
+	require.False(t, isTransient(err, true))
+	require.False(t, isConnectionError(err))
+}
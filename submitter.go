@@ -0,0 +1,188 @@
+package client
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/celestiaorg/celestia-openrpc/types/appconsts"
+	"github.com/celestiaorg/celestia-openrpc/types/blob"
+	"github.com/celestiaorg/celestia-openrpc/types/share"
+)
+
+// ErrBlobTooLarge is reported for blobs which don't fit into a single transaction
+// or square on their own, so they can't be submitted at all.
+var ErrBlobTooLarge = errors.New("client: blob exceeds the submission limits")
+
+// SubmitterOption is the functional option that is applied to the blob submitter
+// to configure its parameters.
+type SubmitterOption func(s *BlobSubmitter)
+
+// WithMaxTxSize is an option that allows to specify the maximum size in bytes of the
+// transactions, if the network doesn't use appconsts.DefaultMaxTxSize.
+func WithMaxTxSize(size int) SubmitterOption {
+	return func(s *BlobSubmitter) {
+		s.maxTxSize = size
+	}
+}
+
+// WithMaxSquareSize is an option that allows to specify the maximum width of the square,
+// if the network doesn't use appconsts.DefaultGovMaxSquareSize.
+func WithMaxSquareSize(size int) SubmitterOption {
+	return func(s *BlobSubmitter) {
+		s.maxSquareSize = size
+	}
+}
+
+// WithLimits is an option that applies the transaction and square size limits,
+// e.g. as returned by Client.Limits.
+func WithLimits(limits blob.Limits) SubmitterOption {
+	return func(s *BlobSubmitter) {
+		s.maxTxSize = limits.MaxTxSize
+		s.maxSquareSize = limits.MaxSquareSize
+	}
+}
+
+// WithDeduplication is an option that leaves byte-identical blobs of the same namespace
+// out of the submission but the first one, whose result is reported for all of them.
+// Indexes in the errors refer to the deduplicated blobs.
+func WithDeduplication() SubmitterOption {
+	return func(s *BlobSubmitter) {
+		s.deduplicate = true
+	}
+}
+
+// BlobSubmitter submits lists of blobs of any length, splitting them over as many
+// PayForBlobs transactions as needed for every transaction to be accepted by the network.
+type BlobSubmitter struct {
+	submit        func(context.Context, []*blob.Blob, *blob.SubmitOptions) (uint64, error)
+	maxTxSize     int
+	maxSquareSize int
+	deduplicate   bool
+}
+
+// BlobResult is the outcome of the submission of a single blob.
+type BlobResult struct {
+	Blob *blob.Blob
+	// Index is the index of the blob among the submitted blobs, which only differs from
+	// its own with WithDeduplication.
+	Index int
+	// Height is the height the blob was included at. Zero if the submission failed.
+	Height uint64
+	// Err is the error the submission of the transaction holding the blob failed with.
+	Err error
+}
+
+// NewBlobSubmitter returns a submitter submitting the blobs with the client.
+func (c *Client) NewBlobSubmitter(opts ...SubmitterOption) *BlobSubmitter {
+	s := &BlobSubmitter{
+		submit: func(ctx context.Context, blobs []*blob.Blob, opts *blob.SubmitOptions) (uint64, error) {
+			return c.Blob.Submit(ctx, blobs, opts)
+		},
+		maxTxSize:     appconsts.DefaultMaxTxSize,
+		maxSquareSize: appconsts.DefaultGovMaxSquareSize,
+	}
+	for _, opt := range opts {
+		opt(s)
+	}
+	return s
+}
+
+// Submit submits the blobs in order, packing as many of them into every transaction
+// as the limits allow. The transactions are submitted one after the other with the
+// options, so a gas limit set in the options applies to every one of them. A result
+// is returned for every blob, and the returned error joins the errors of all the
+// failed transactions.
+func (s *BlobSubmitter) Submit(ctx context.Context, blobs []*blob.Blob, opts *blob.SubmitOptions) ([]BlobResult, error) {
+	if !s.deduplicate {
+		return s.submitBlobs(ctx, blobs, opts)
+	}
+	unique, indices := blob.Deduplicate(blobs)
+	submitted, err := s.submitBlobs(ctx, unique, opts)
+	results := make([]BlobResult, len(blobs))
+	for i, idx := range indices {
+		results[i] = submitted[idx]
+		results[i].Blob = blobs[i]
+	}
+	return results, err
+}
+
+func (s *BlobSubmitter) submitBlobs(
+	ctx context.Context,
+	blobs []*blob.Blob,
+	opts *blob.SubmitOptions,
+) ([]BlobResult, error) {
+	results := make([]BlobResult, len(blobs))
+	for i, b := range blobs {
+		results[i].Blob, results[i].Index = b, i
+	}
+
+	chunks := s.chunks(blobs, results)
+	var errs []error
+	for _, res := range results {
+		if res.Err != nil {
+			errs = append(errs, res.Err)
+		}
+	}
+	for _, chunk := range chunks {
+		batch := make([]*blob.Blob, len(chunk))
+		for i, idx := range chunk {
+			batch[i] = blobs[idx]
+		}
+		height, err := s.submit(ctx, batch, opts)
+		if err != nil {
+			err = fmt.Errorf("submitting blobs %d to %d: %w", chunk[0], chunk[len(chunk)-1], err)
+			errs = append(errs, err)
+		}
+		for _, idx := range chunk {
+			results[idx].Height, results[idx].Err = height, err
+		}
+	}
+	return results, errors.Join(errs...)
+}
+
+// chunks splits the indexes of the blobs into groups each fitting into a transaction.
+// Blobs too large to fit into any are reported in the results and left out.
+func (s *BlobSubmitter) chunks(blobs []*blob.Blob, results []BlobResult) [][]int {
+	maxShares := s.maxSquareSize * s.maxSquareSize
+	var (
+		chunks          [][]int
+		current         []int
+		txSize, nShares int
+	)
+	for i, b := range blobs {
+		if b == nil {
+			results[i].Err = fmt.Errorf("blob %d is nil", i)
+			continue
+		}
+		size, shares := blobTxSize(b), blobShares(b)
+		if size > s.maxTxSize || shares > maxShares {
+			results[i].Err = fmt.Errorf("%w: blob %d of %d bytes", ErrBlobTooLarge, i, len(b.Data))
+			continue
+		}
+		if len(current) > 0 && (txSize+size > s.maxTxSize || nShares+shares > maxShares) {
+			chunks = append(chunks, current)
+			current, txSize, nShares = nil, 0, 0
+		}
+		current = append(current, i)
+		txSize += size
+		nShares += shares
+	}
+	if len(current) > 0 {
+		chunks = append(chunks, current)
+	}
+	return chunks
+}
+
+// blobTxSize returns the number of bytes the blob adds to a PayForBlobs transaction.
+func blobTxSize(b *blob.Blob) int {
+	return len(b.Data) + len(b.Signer()) + appconsts.BytesPerBlobInfo
+}
+
+// blobShares returns the number of shares the blob may take up in the square, including
+// the padding aligning it to the width of its subtrees.
+func blobShares(b *blob.Blob) int {
+	//nolint:gosec
+	shares := share.SparseSharesNeeded(uint32(sequenceLen(b)))
+	return shares + share.SubTreeWidth(shares, appconsts.DefaultSubtreeRootThreshold) - 1
+}
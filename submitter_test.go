@@ -0,0 +1,114 @@
+package client
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/celestiaorg/celestia-openrpc/types/blob"
+	"github.com/celestiaorg/celestia-openrpc/types/share"
+)
+
+func TestBlobSubmitterChunks(t *testing.T) {
+	var submitted [][]*blob.Blob
+	c := &Client{}
+	c.Blob.Submit = func(_ context.Context, blobs []*blob.Blob, _ *blob.SubmitOptions) (uint64, error) {
+		submitted = append(submitted, blobs)
+		return uint64(len(submitted)), nil
+	}
+	s := c.NewBlobSubmitter(WithMaxTxSize(2500))
+
+	blobs := []*blob.Blob{
+		testBlob(t, strings.Repeat("a", 1000)),
+		testBlob(t, strings.Repeat("b", 1000)),
+		testBlob(t, strings.Repeat("c", 1000)),
+		testBlob(t, strings.Repeat("d", 3000)),
+		testBlob(t, "e"),
+	}
+	results, err := s.Submit(context.Background(), blobs, nil)
+	require.ErrorIs(t, err, ErrBlobTooLarge)
+	require.Len(t, results, len(blobs))
+
+	// the blobs are packed in order, leaving out the one too large for any transaction
+	require.Equal(t, [][]*blob.Blob{blobs[:2], {blobs[2], blobs[4]}}, submitted)
+	for i, height := range []uint64{1, 1, 2, 0, 2} {
+		require.Equal(t, blobs[i], results[i].Blob)
+		require.Equal(t, height, results[i].Height)
+	}
+	require.ErrorIs(t, results[3].Err, ErrBlobTooLarge)
+	require.NoError(t, results[4].Err)
+}
+
+func TestBlobSubmitterSquareSize(t *testing.T) {
+	var submitted int
+	c := &Client{}
+	c.Blob.Submit = func(context.Context, []*blob.Blob, *blob.SubmitOptions) (uint64, error) {
+		submitted++
+		return 1, nil
+	}
+	// a square of 2x2 fits the 3 shares of a single blob
+	s := c.NewBlobSubmitter(WithMaxSquareSize(2))
+	b := testBlob(t, strings.Repeat("x", 1000))
+	results, err := s.Submit(context.Background(), []*blob.Blob{b, b}, nil)
+	require.NoError(t, err)
+	require.Len(t, results, 2)
+	require.Equal(t, 2, submitted)
+}
+
+func TestBlobSubmitterFailures(t *testing.T) {
+	failure := errors.New("insufficient funds")
+	var calls int
+	c := &Client{}
+	c.Blob.Submit = func(context.Context, []*blob.Blob, *blob.SubmitOptions) (uint64, error) {
+		calls++
+		if calls == 1 {
+			return 0, failure
+		}
+		return 5, nil
+	}
+	s := c.NewBlobSubmitter(WithMaxTxSize(1500))
+
+	blobs := []*blob.Blob{testBlob(t, strings.Repeat("a", 1000)), testBlob(t, strings.Repeat("b", 1000))}
+	results, err := s.Submit(context.Background(), blobs, nil)
+	require.ErrorIs(t, err, failure)
+	require.ErrorIs(t, results[0].Err, failure)
+	require.Zero(t, results[0].Height)
+	// the failure of a transaction doesn't stop the following ones
+	require.NoError(t, results[1].Err)
+	require.EqualValues(t, 5, results[1].Height)
+}
+
+func TestBlobSubmitterDeduplication(t *testing.T) {
+	var submitted []*blob.Blob
+	c := &Client{}
+	c.Blob.Submit = func(_ context.Context, blobs []*blob.Blob, _ *blob.SubmitOptions) (uint64, error) {
+		submitted = append(submitted, blobs...)
+		return 5, nil
+	}
+	s := c.NewBlobSubmitter(WithDeduplication())
+
+	a, b := testBlob(t, "a"), testBlob(t, "b")
+	blobs := []*blob.Blob{a, b, testBlob(t, "a"), b}
+	results, err := s.Submit(context.Background(), blobs, nil)
+	require.NoError(t, err)
+	require.Equal(t, []*blob.Blob{a, b}, submitted)
+
+	require.Len(t, results, 4)
+	for i, res := range results {
+		require.Same(t, blobs[i], res.Blob)
+		require.EqualValues(t, 5, res.Height)
+	}
+	require.Equal(t, []int{0, 1, 0, 1}, []int{results[0].Index, results[1].Index, results[2].Index, results[3].Index})
+
+	// the same data in another namespace isn't a duplicate
+	namespace, err := share.NewBlobNamespaceV0([]byte{5, 6, 7, 8})
+	require.NoError(t, err)
+	other, err := blob.NewBlobV0(namespace, []byte("a"))
+	require.NoError(t, err)
+	unique, indices := blob.Deduplicate([]*blob.Blob{a, other, nil})
+	require.Equal(t, []*blob.Blob{a, other, nil}, unique)
+	require.Equal(t, []int{0, 1, 2}, indices)
+}
@@ -0,0 +1,31 @@
+package client
+
+import (
+	"context"
+	"time"
+)
+
+// defaultTimeouts returns an interceptor bounding the duration of calls without a timeout
+// set with WithCallTimeout by the default timeout of their method, their module or, keyed
+// by "", all calls. Subscriptions live as long as the context of their call, so they aren't bounded.
+func defaultTimeouts(timeouts map[string]time.Duration) interceptor {
+	return func(ctx context.Context, c *call, next invoker) ([]interface{}, error) {
+		if c.subscription || callOptionsFrom(ctx).timeout > 0 {
+			return next(ctx, c)
+		}
+		timeout, ok := timeouts[c.name()]
+		if !ok {
+			timeout, ok = timeouts[c.module]
+		}
+		if !ok {
+			timeout = timeouts[""]
+		}
+		if timeout <= 0 {
+			return next(ctx, c)
+		}
+
+		ctx, cancel := context.WithTimeout(ctx, timeout)
+		defer cancel()
+		return next(ctx, c)
+	}
+}
@@ -0,0 +1,48 @@
+package client
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestDefaultTimeouts(t *testing.T) {
+	intercept := defaultTimeouts(map[string]time.Duration{
+		"":             time.Minute,
+		"header":       10 * time.Second,
+		"share.GetEDS": 2 * time.Minute,
+	})
+
+	// deadline returns the remaining time of the call, or zero if it isn't bounded
+	deadline := func(ctx context.Context, c *call) time.Duration {
+		var remaining time.Duration
+		_, err := intercept(ctx, c, func(ctx context.Context, _ *call) ([]interface{}, error) {
+			if d, ok := ctx.Deadline(); ok {
+				remaining = time.Until(d)
+			}
+			return nil, nil
+		})
+		require.NoError(t, err)
+		return remaining
+	}
+
+	ctx := context.Background()
+	for _, tc := range []struct {
+		call *call
+		want time.Duration
+	}{
+		{&call{module: "header", method: "GetByHeight"}, 10 * time.Second},
+		{&call{module: "share", method: "GetEDS"}, 2 * time.Minute},
+		{&call{module: "share", method: "GetRange"}, time.Minute},
+		{&call{module: "header", method: "Subscribe", subscription: true}, 0},
+	} {
+		remaining := deadline(ctx, tc.call)
+		require.InDelta(t, tc.want, remaining, float64(time.Second), tc.call.name())
+	}
+
+	// the timeout of the call takes precedence
+	remaining := deadline(WithCallOptions(ctx, WithCallTimeout(time.Hour)), &call{module: "header", method: "GetByHeight"})
+	require.Zero(t, remaining)
+}
@@ -0,0 +1,41 @@
+package client
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"time"
+
+	"github.com/filecoin-project/go-jsonrpc/auth"
+)
+
+// jwtHeader is the encoded header of HS256 signed JWTs.
+var jwtHeader = base64.RawURLEncoding.EncodeToString([]byte(`{"alg":"HS256","typ":"JWT"}`))
+
+// MintToken mints a token granting the permissions, accepted by a celestia-node whose
+// JWT key secret is given, e.g. read from the "keys/NJ3XILLTMVRXEZLUFZVHO5A" file in the
+// node store. If ttl is positive, the token expires after it.
+func MintToken(secret []byte, perms []auth.Permission, ttl time.Duration) (string, error) {
+	if len(secret) == 0 {
+		return "", errors.New("empty key secret")
+	}
+
+	claims := struct {
+		Allow     []auth.Permission
+		ExpiresAt int64 `json:"exp,omitempty"`
+	}{Allow: perms}
+	if ttl > 0 {
+		claims.ExpiresAt = time.Now().Add(ttl).Unix()
+	}
+	payload, err := json.Marshal(claims)
+	if err != nil {
+		return "", err
+	}
+
+	unsigned := jwtHeader + "." + base64.RawURLEncoding.EncodeToString(payload)
+	mac := hmac.New(sha256.New, secret)
+	mac.Write([]byte(unsigned))
+	return unsigned + "." + base64.RawURLEncoding.EncodeToString(mac.Sum(nil)), nil
+}
@@ -0,0 +1,56 @@
+package client
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/filecoin-project/go-jsonrpc/auth"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMintToken(t *testing.T) {
+	secret := []byte("secret")
+	token, err := MintToken(secret, []auth.Permission{"public", "read"}, time.Hour)
+	require.NoError(t, err)
+
+	parts := strings.Split(token, ".")
+	require.Len(t, parts, 3)
+
+	header, err := base64.RawURLEncoding.DecodeString(parts[0])
+	require.NoError(t, err)
+	require.JSONEq(t, `{"alg":"HS256","typ":"JWT"}`, string(header))
+
+	mac := hmac.New(sha256.New, secret)
+	mac.Write([]byte(parts[0] + "." + parts[1]))
+	require.Equal(t, base64.RawURLEncoding.EncodeToString(mac.Sum(nil)), parts[2])
+
+	payload, err := base64.RawURLEncoding.DecodeString(parts[1])
+	require.NoError(t, err)
+	var claims struct {
+		Allow     []auth.Permission
+		ExpiresAt int64 `json:"exp"`
+	}
+	require.NoError(t, json.Unmarshal(payload, &claims))
+	require.Equal(t, []auth.Permission{"public", "read"}, claims.Allow)
+	require.InDelta(t, time.Now().Add(time.Hour).Unix(), claims.ExpiresAt, 5)
+
+	perms, err := permissionsFromToken(token)
+	require.NoError(t, err)
+	require.Equal(t, claims.Allow, perms)
+}
+
+func TestMintTokenWithoutExpiry(t *testing.T) {
+	token, err := MintToken([]byte("secret"), []auth.Permission{"admin"}, 0)
+	require.NoError(t, err)
+	payload, err := base64.RawURLEncoding.DecodeString(strings.Split(token, ".")[1])
+	require.NoError(t, err)
+	require.NotContains(t, string(payload), "exp")
+
+	_, err = MintToken(nil, []auth.Permission{"admin"}, 0)
+	require.Error(t, err)
+}
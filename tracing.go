@@ -0,0 +1,92 @@
+package client
+
+import (
+	"context"
+	"reflect"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/trace"
+)
+
+const tracerName = "github.com/celestiaorg/celestia-openrpc"
+
+// traceCalls returns an interceptor recording a span for every call, parented by the
+// span of the caller's context. Items delivered by subscriptions are recorded as spans as well.
+func traceCalls(tp trace.TracerProvider) interceptor {
+	tracer := tp.Tracer(tracerName)
+	return func(ctx context.Context, c *call, next invoker) ([]interface{}, error) {
+		ctx, span := tracer.Start(ctx, c.name(),
+			trace.WithSpanKind(trace.SpanKindClient),
+			trace.WithAttributes(callAttributes(c)...),
+		)
+		defer span.End()
+
+		out, err := next(ctx, c)
+		if err != nil {
+			span.RecordError(err)
+			span.SetStatus(codes.Error, err.Error())
+			return out, err
+		}
+
+		for i, res := range out {
+			v := reflect.ValueOf(res)
+			switch v.Kind() {
+			case reflect.Slice, reflect.Map:
+				span.SetAttributes(attribute.Int("celestia.result.size", v.Len()))
+			case reflect.Chan:
+				out[i] = traceSubscription(ctx, tracer, c.name(), v)
+			default:
+			}
+		}
+		return out, nil
+	}
+}
+
+// callAttributes returns the attributes describing the call. Heights are
+// the only parameters of type uint64 across the API.
+func callAttributes(c *call) []attribute.KeyValue {
+	attrs := []attribute.KeyValue{
+		attribute.String("rpc.system", "jsonrpc"),
+		attribute.String("rpc.service", c.module),
+		attribute.String("rpc.method", c.method),
+	}
+	for _, arg := range c.args {
+		if height, ok := arg.(uint64); ok {
+			//nolint:gosec
+			attrs = append(attrs, attribute.Int64("celestia.height", int64(height)))
+		}
+	}
+	return attrs
+}
+
+// traceSubscription forwards the items of the subscription channel to a new channel
+// of the same type, recording a span for every item.
+func traceSubscription(ctx context.Context, tracer trace.Tracer, method string, sub reflect.Value) interface{} {
+	out := reflect.MakeChan(reflect.ChanOf(reflect.BothDir, sub.Type().Elem()), 0)
+	go func() {
+		defer out.Close()
+		done := reflect.ValueOf(ctx.Done())
+		for {
+			chosen, item, ok := reflect.Select([]reflect.SelectCase{
+				{Dir: reflect.SelectRecv, Chan: sub},
+				{Dir: reflect.SelectRecv, Chan: done},
+			})
+			if chosen == 1 || !ok {
+				return
+			}
+
+			_, span := tracer.Start(ctx, method+".event", trace.WithSpanKind(trace.SpanKindConsumer))
+			span.End()
+
+			chosen, _, _ = reflect.Select([]reflect.SelectCase{
+				{Dir: reflect.SelectSend, Chan: out, Send: item},
+				{Dir: reflect.SelectRecv, Chan: done},
+			})
+			if chosen == 1 {
+				return
+			}
+		}
+	}()
+	return out.Convert(sub.Type()).Interface()
+}
@@ -0,0 +1,119 @@
+package client
+
+import (
+	"context"
+	"sync"
+	"syscall"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/trace"
+	"go.opentelemetry.io/otel/trace/embedded"
+)
+
+// spanRecorder is a TracerProvider recording the spans started by its tracers.
+type spanRecorder struct {
+	embedded.TracerProvider
+	embedded.Tracer
+
+	mu    sync.Mutex
+	spans []*recordedSpan
+}
+
+func (r *spanRecorder) Tracer(string, ...trace.TracerOption) trace.Tracer {
+	return r
+}
+
+func (r *spanRecorder) Start(ctx context.Context, name string, opts ...trace.SpanStartOption) (context.Context, trace.Span) {
+	cfg := trace.NewSpanStartConfig(opts...)
+	span := &recordedSpan{
+		Span:  trace.SpanFromContext(context.Background()),
+		name:  name,
+		kind:  cfg.SpanKind(),
+		attrs: cfg.Attributes(),
+	}
+	r.mu.Lock()
+	r.spans = append(r.spans, span)
+	r.mu.Unlock()
+	return ctx, span
+}
+
+func (r *spanRecorder) recorded() []*recordedSpan {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return append([]*recordedSpan(nil), r.spans...)
+}
+
+type recordedSpan struct {
+	trace.Span
+
+	name   string
+	kind   trace.SpanKind
+	attrs  []attribute.KeyValue
+	status codes.Code
+}
+
+func (s *recordedSpan) SetAttributes(attrs ...attribute.KeyValue) {
+	s.attrs = append(s.attrs, attrs...)
+}
+
+func (s *recordedSpan) SetStatus(code codes.Code, _ string) {
+	s.status = code
+}
+
+func TestTraceCalls(t *testing.T) {
+	recorder := &spanRecorder{}
+	intercept := traceCalls(recorder)
+
+	next := func(context.Context, *call) ([]interface{}, error) {
+		return []interface{}{[]byte("data")}, nil
+	}
+	_, err := intercept(context.Background(), &call{module: "share", method: "GetRange", perm: "read", args: []interface{}{uint64(7)}}, next)
+	require.NoError(t, err)
+
+	failing, _ := failingInvoker(1, syscall.ECONNRESET)
+	_, err = intercept(context.Background(), &call{module: "header", method: "NetworkHead", perm: "read"}, failing)
+	require.Error(t, err)
+
+	spans := recorder.recorded()
+	require.Len(t, spans, 2)
+	require.Equal(t, "share.GetRange", spans[0].name)
+	require.Equal(t, trace.SpanKindClient, spans[0].kind)
+	require.Contains(t, spans[0].attrs, attribute.String("rpc.service", "share"))
+	require.Contains(t, spans[0].attrs, attribute.Int64("celestia.height", 7))
+	require.Contains(t, spans[0].attrs, attribute.Int("celestia.result.size", 4))
+	require.Equal(t, codes.Unset, spans[0].status)
+	require.Equal(t, codes.Error, spans[1].status)
+}
+
+func TestTraceSubscription(t *testing.T) {
+	recorder := &spanRecorder{}
+	intercept := traceCalls(recorder)
+
+	sub := make(chan int, 2)
+	sub <- 1
+	sub <- 2
+	close(sub)
+	next := func(context.Context, *call) ([]interface{}, error) {
+		return []interface{}{(<-chan int)(sub)}, nil
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+	out, err := intercept(ctx, &call{module: "header", method: "Subscribe", perm: "read"}, next)
+	require.NoError(t, err)
+
+	var items []int
+	for item := range out[0].(<-chan int) {
+		items = append(items, item)
+	}
+	require.Equal(t, []int{1, 2}, items)
+
+	spans := recorder.recorded()
+	require.Len(t, spans, 3)
+	require.Equal(t, "header.Subscribe.event", spans[2].name)
+	require.Equal(t, trace.SpanKindConsumer, spans[2].kind)
+}
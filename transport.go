@@ -0,0 +1,158 @@
+package client
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+
+	"github.com/filecoin-project/go-jsonrpc"
+)
+
+// Transport connects the modules of the client to a node.
+type Transport interface {
+	// Dial connects the module of the given RPC namespace to the node at addr by
+	// filling the methods of out, a pointer to the raw module struct.
+	Dial(
+		ctx context.Context,
+		addr, namespace string,
+		out interface{},
+		header http.Header,
+		opts ...jsonrpc.Option,
+	) (jsonrpc.ClientCloser, error)
+}
+
+// DefaultTransport picks HTTP or WebSocket based on the scheme of the address.
+// Addresses of the form "unix:///path/to/socket" are served over HTTP through the unix socket.
+var DefaultTransport Transport = schemeTransport{}
+
+type schemeTransport struct {
+	// wrap wraps the round tripper of HTTP requests, if set.
+	wrap func(http.RoundTripper) http.RoundTripper
+	// ws limits the size of the messages of WebSocket connections and times their pings.
+	ws wsOptions
+}
+
+func (t schemeTransport) Dial(
+	ctx context.Context,
+	addr, namespace string,
+	out interface{},
+	header http.Header,
+	opts ...jsonrpc.Option,
+) (jsonrpc.ClientCloser, error) {
+	if _, ok := unixSocketPath(addr); ok || (t.wrap != nil && isHTTPAddr(addr)) {
+		return HTTPTransport{wrap: t.wrap}.Dial(ctx, addr, namespace, out, header, opts...)
+	}
+	return t.ws.dial(ctx, addr, namespace, out, header, opts...)
+}
+
+// HTTPTransport sends every request as a separate HTTP request, regardless of the scheme
+// of the address. Subscriptions are not supported.
+type HTTPTransport struct {
+	// Client is the HTTP client sending the requests. If nil, http.DefaultClient is used.
+	// It is ignored for addresses of unix sockets.
+	Client *http.Client
+
+	// wrap wraps the round tripper of the client, if set.
+	wrap func(http.RoundTripper) http.RoundTripper
+}
+
+func (t HTTPTransport) Dial(
+	ctx context.Context,
+	addr, namespace string,
+	out interface{},
+	header http.Header,
+	opts ...jsonrpc.Option,
+) (jsonrpc.ClientCloser, error) {
+	url, client := httpEndpoint(addr, t.Client, unixClient(ctx))
+	client = wrapClient(client, t.wrap)
+	if client != nil {
+		opts = append(opts, jsonrpc.WithHTTPClient(client))
+	}
+	return jsonrpc.NewMergeClient(ctx, url, namespace, []interface{}{out}, header, opts...)
+}
+
+// WebSocketTransport multiplexes all requests over a WebSocket connection, regardless
+// of the scheme of the address. Unix sockets are not supported.
+type WebSocketTransport struct {
+	// ws limits the size of the messages of the connections and times their pings.
+	ws wsOptions
+}
+
+func (t WebSocketTransport) Dial(
+	ctx context.Context,
+	addr, namespace string,
+	out interface{},
+	header http.Header,
+	opts ...jsonrpc.Option,
+) (jsonrpc.ClientCloser, error) {
+	if _, ok := unixSocketPath(addr); ok {
+		return nil, fmt.Errorf("websocket transport does not support unix socket %s", addr)
+	}
+	return t.ws.dial(ctx, wsAddr(addr), namespace, out, header, opts...)
+}
+
+// TransportFunc is a Transport handing the encoded requests to the function, which returns
+// the encoded responses, e.g. served by an in-memory node in tests. Subscriptions are not supported.
+type TransportFunc func(ctx context.Context, addr string, header http.Header, req []byte) (io.ReadCloser, error)
+
+func (f TransportFunc) Dial(
+	_ context.Context,
+	addr, namespace string,
+	out interface{},
+	header http.Header,
+	opts ...jsonrpc.Option,
+) (jsonrpc.ClientCloser, error) {
+	do := func(ctx context.Context, req []byte) (io.ReadCloser, error) {
+		return f(ctx, addr, header, req)
+	}
+	return jsonrpc.NewCustomClient(namespace, []interface{}{out}, do, opts...)
+}
+
+// wrapClient returns a copy of the client, http.DefaultClient if nil, whose round tripper
+// is wrapped with wrap. The client is returned as is if wrap is nil.
+func wrapClient(client *http.Client, wrap func(http.RoundTripper) http.RoundTripper) *http.Client {
+	if wrap == nil {
+		return client
+	}
+	if client == nil {
+		client = http.DefaultClient
+	}
+	next := client.Transport
+	if next == nil {
+		next = http.DefaultTransport
+	}
+	wrapped := *client
+	wrapped.Transport = wrap(next)
+	return &wrapped
+}
+
+// isHTTPAddr reports whether the address is the one of an HTTP server.
+func isHTTPAddr(addr string) bool {
+	return strings.HasPrefix(addr, "http://") || strings.HasPrefix(addr, "https://")
+}
+
+// httpAddr converts the address of an endpoint into the one of its HTTP server.
+func httpAddr(addr string) string {
+	switch {
+	case strings.HasPrefix(addr, "ws://"):
+		return "http://" + strings.TrimPrefix(addr, "ws://")
+	case strings.HasPrefix(addr, "wss://"):
+		return "https://" + strings.TrimPrefix(addr, "wss://")
+	default:
+		return addr
+	}
+}
+
+// wsAddr converts the address of an endpoint into the one of its WebSocket server.
+func wsAddr(addr string) string {
+	switch {
+	case strings.HasPrefix(addr, "http://"):
+		return "ws://" + strings.TrimPrefix(addr, "http://")
+	case strings.HasPrefix(addr, "https://"):
+		return "wss://" + strings.TrimPrefix(addr, "https://")
+	default:
+		return addr
+	}
+}
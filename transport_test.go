@@ -0,0 +1,111 @@
+package client
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/celestiaorg/celestia-openrpc/types/node"
+)
+
+// rpcResponse returns the JSON-RPC response to the request answering it with result.
+func rpcResponse(t *testing.T, req []byte, result interface{}) []byte {
+	var r struct {
+		ID json.RawMessage `json:"id"`
+	}
+	require.NoError(t, json.Unmarshal(req, &r))
+	out, err := json.Marshal(result)
+	require.NoError(t, err)
+	return []byte(fmt.Sprintf(`{"jsonrpc":"2.0","id":%s,"result":%s}`, r.ID, out))
+}
+
+// testNode serves the JSON-RPC API of a node over HTTP, answering calls with handle.
+// Errors returned by handle are sent as JSON-RPC error objects. The data of an RPCError
+// is sent as meta, as done by nodes built on go-jsonrpc.
+func testNode(
+	t *testing.T,
+	handle func(r *http.Request, method string, params json.RawMessage) (interface{}, error),
+) *httptest.Server {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var req struct {
+			ID     json.RawMessage `json:"id"`
+			Method string          `json:"method"`
+			Params json.RawMessage `json:"params"`
+		}
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+
+		resp := map[string]interface{}{"jsonrpc": "2.0", "id": req.ID}
+		result, err := handle(r, req.Method, req.Params)
+		var rpcErr *RPCError
+		switch {
+		case errors.As(err, &rpcErr):
+			resp["error"] = errorObject{Code: rpcErr.Code, Message: rpcErr.Message, Meta: rpcErr.Data}
+		case err != nil:
+			resp["error"] = errorObject{Code: 1, Message: err.Error()}
+		default:
+			resp["result"] = result
+		}
+		_ = json.NewEncoder(w).Encode(resp)
+	}))
+	t.Cleanup(srv.Close)
+	return srv
+}
+
+func TestTransportFunc(t *testing.T) {
+	var method string
+	transport := TransportFunc(func(_ context.Context, addr string, header http.Header, req []byte) (io.ReadCloser, error) {
+		require.Equal(t, "memory", addr)
+		require.Equal(t, "Bearer token", header.Get("Authorization"))
+		var r struct {
+			Method string `json:"method"`
+		}
+		require.NoError(t, json.Unmarshal(req, &r))
+		method = r.Method
+		return io.NopCloser(bytes.NewReader(rpcResponse(t, req, true))), nil
+	})
+
+	var api node.API
+	closer, err := transport.Dial(context.Background(), "memory", "node", &api, http.Header{"Authorization": {"Bearer token"}})
+	require.NoError(t, err)
+	defer closer()
+
+	ready, err := api.Ready(context.Background())
+	require.NoError(t, err)
+	require.True(t, ready)
+	require.Equal(t, "node.Ready", method)
+}
+
+func TestTransportFuncError(t *testing.T) {
+	errUnavailable := errors.New("node unavailable")
+	transport := TransportFunc(func(context.Context, string, http.Header, []byte) (io.ReadCloser, error) {
+		return nil, errUnavailable
+	})
+
+	var api node.API
+	closer, err := transport.Dial(context.Background(), "memory", "node", &api, nil)
+	require.NoError(t, err)
+	defer closer()
+
+	_, err = api.Ready(context.Background())
+	require.ErrorContains(t, err, errUnavailable.Error())
+}
+
+func TestAddrConversion(t *testing.T) {
+	require.Equal(t, "http://localhost:26658", httpAddr("ws://localhost:26658"))
+	require.Equal(t, "https://node.example", httpAddr("wss://node.example"))
+	require.Equal(t, "http://localhost:26658", httpAddr("http://localhost:26658"))
+	require.Equal(t, "ws://localhost:26658", wsAddr("http://localhost:26658"))
+	require.Equal(t, "wss://node.example", wsAddr("https://node.example"))
+	require.Equal(t, "ws://localhost:26658", wsAddr("ws://localhost:26658"))
+}
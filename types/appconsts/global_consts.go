@@ -37,6 +37,14 @@ const (
 	// ShareVersionZero is the first share version format.
 	ShareVersionZero = uint8(0)
 
+	// ShareVersionOne is the share version format of blobs carrying the
+	// address of their signer in the first share.
+	ShareVersionOne = uint8(1)
+
+	// SignerSize is the size of the signer address prepended to the data of
+	// share version one blobs.
+	SignerSize = 20
+
 	// DefaultShareVersion is the defacto share version. Use this if you are
 	// unsure of which version to use.
 	DefaultShareVersion = ShareVersionZero
@@ -70,6 +78,14 @@ const (
 
 	// MaxShareVersion is the maximum value a share version can be.
 	MaxShareVersion = 127
+
+	// PFBGasFixedCost is a rough estimate of the fixed cost of a PayForBlobs
+	// transaction, excluding the gas consumed by the blobs themselves.
+	PFBGasFixedCost = 75000
+
+	// BytesPerBlobInfo is a rough estimation of the amount of bytes used by
+	// a single blob's information in a PayForBlobs transaction.
+	BytesPerBlobInfo = 70
 )
 
 var (
@@ -77,5 +93,5 @@ var (
 	DefaultCodec = rsmt2d.NewLeoRSCodec
 
 	// SupportedShareVersions is a list of supported share versions.
-	SupportedShareVersions = []uint8{ShareVersionZero}
+	SupportedShareVersions = []uint8{ShareVersionZero, ShareVersionOne}
 )
@@ -13,10 +13,18 @@ const (
 	// maximum number of bytes allowed in a valid block.
 	DefaultMaxBytes = DefaultGovMaxSquareSize * DefaultGovMaxSquareSize * ContinuationSparseShareContentSize
 
+	// DefaultMaxTxSize is the default maximum size in bytes of a transaction,
+	// including the blobs of a PayForBlobs transaction.
+	DefaultMaxTxSize = 2 * 1024 * 1024
+
 	// DefaultGasPerBlobByte is the default gas cost deducted per byte of blob
 	// included in a PayForBlobs txn
 	DefaultGasPerBlobByte = 8
 
+	// DefaultTxSizeCostPerByte is the default gas cost deducted per byte of a
+	// transaction, as defined by the auth module.
+	DefaultTxSizeCostPerByte = 10
+
 	// DefaultMinGasPrice is the default min gas price that gets set in the app.toml file.
 	// The min gas price acts as a filter. Transactions below that limit will not pass
 	// a nodes `CheckTx` and thus not be proposed by that node.
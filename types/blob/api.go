@@ -2,6 +2,7 @@ package blob
 
 import (
 	"context"
+	"errors"
 
 	"github.com/celestiaorg/celestia-openrpc/types/share"
 )
@@ -33,3 +34,47 @@ type API struct {
 		share.Namespace,
 	) (<-chan *SubscriptionResponse, error) `perm:"read"`
 }
+
+// GetByData retrieves the share version 0 blob holding the data under the namespace at the
+// height. The commitment is computed from the data, so callers don't need to store it along
+// with the data to look the blob up. Blobs of share version 1 depend on their signer, so they
+// have to be looked up by the commitment of NewBlobV1.
+func (api *API) GetByData(ctx context.Context, height uint64, namespace share.Namespace, data []byte) (*Blob, error) {
+	b, err := NewBlobV0(namespace, data)
+	if err != nil {
+		return nil, err
+	}
+	return api.Get(ctx, height, namespace, b.Commitment)
+}
+
+// GetAllByNamespace retrieves the blobs of all the namespaces at the height in a single call
+// and returns them grouped per namespace, in the order of the namespaces. Namespaces without
+// blobs get an empty group, so heights without any blobs don't fail with ErrBlobNotFound.
+func (api *API) GetAllByNamespace(
+	ctx context.Context,
+	height uint64,
+	namespaces []share.Namespace,
+) ([][]*Blob, error) {
+	if len(namespaces) == 0 {
+		return nil, errors.New("blob: no namespaces to retrieve")
+	}
+	blobs, err := api.GetAll(ctx, height, namespaces)
+	if err != nil && !errors.Is(err, ErrBlobNotFound) {
+		return nil, err
+	}
+	return GroupByNamespace(blobs, namespaces), nil
+}
+
+// GroupByNamespace groups the blobs per namespace, in the order of the namespaces, keeping
+// the order of the blobs within a namespace. Blobs of other namespaces are left out.
+func GroupByNamespace(blobs []*Blob, namespaces []share.Namespace) [][]*Blob {
+	groups := make([][]*Blob, len(namespaces))
+	for i, namespace := range namespaces {
+		for _, b := range blobs {
+			if namespace.Equals(share.Namespace(b.Namespace().Bytes())) {
+				groups[i] = append(groups[i], b)
+			}
+		}
+	}
+	return groups
+}
@@ -0,0 +1,68 @@
+package blob
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/celestiaorg/celestia-openrpc/types/share"
+)
+
+func TestGetByData(t *testing.T) {
+	b := testBlob(t, "hello")
+	namespace := share.Namespace(b.Namespace().Bytes())
+	api := &API{}
+	api.Get = func(_ context.Context, height uint64, _ share.Namespace, commitment Commitment) (*Blob, error) {
+		require.EqualValues(t, 3, height)
+		if !commitment.Equal(b.Commitment) {
+			return nil, ErrBlobNotFound
+		}
+		return b, nil
+	}
+
+	got, err := api.GetByData(context.Background(), 3, namespace, []byte("hello"))
+	require.NoError(t, err)
+	require.Equal(t, b, got)
+	_, err = api.GetByData(context.Background(), 3, namespace, []byte("other"))
+	require.ErrorIs(t, err, ErrBlobNotFound)
+	_, err = api.GetByData(context.Background(), 3, namespace, nil)
+	require.ErrorIs(t, err, ErrInvalidSize)
+}
+
+func TestGetAllByNamespace(t *testing.T) {
+	a1, a2 := testBlob(t, "a1"), testBlob(t, "a2")
+	other, err := share.NewBlobNamespaceV0([]byte{5, 6, 7, 8})
+	require.NoError(t, err)
+	b1, err := NewBlobV0(other, []byte("b1"))
+	require.NoError(t, err)
+	empty, err := share.NewBlobNamespaceV0([]byte{9})
+	require.NoError(t, err)
+
+	var calls int
+	found := []*Blob{a1, b1, a2}
+	api := &API{}
+	api.GetAll = func(_ context.Context, _ uint64, namespaces []share.Namespace) ([]*Blob, error) {
+		calls++
+		require.Len(t, namespaces, 3)
+		if found == nil {
+			return nil, ErrBlobNotFound
+		}
+		return found, nil
+	}
+
+	namespaces := []share.Namespace{other, share.Namespace(a1.Namespace().Bytes()), empty}
+	groups, err := api.GetAllByNamespace(context.Background(), 3, namespaces)
+	require.NoError(t, err)
+	require.Equal(t, [][]*Blob{{b1}, {a1, a2}, nil}, groups)
+	require.Equal(t, 1, calls)
+
+	// heights without any blobs yield empty groups
+	found = nil
+	groups, err = api.GetAllByNamespace(context.Background(), 3, namespaces)
+	require.NoError(t, err)
+	require.Equal(t, [][]*Blob{nil, nil, nil}, groups)
+
+	_, err = api.GetAllByNamespace(context.Background(), 3, nil)
+	require.Error(t, err)
+}
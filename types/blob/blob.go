@@ -5,6 +5,7 @@ import (
 	"encoding/json"
 	"errors"
 	"fmt"
+	"slices"
 
 	"github.com/celestiaorg/nmt"
 	"github.com/celestiaorg/nmt/namespace"
@@ -14,8 +15,6 @@ import (
 	"github.com/celestiaorg/celestia-openrpc/types/share"
 
 	"github.com/celestiaorg/go-square/blob"
-	"github.com/celestiaorg/go-square/inclusion"
-	"github.com/celestiaorg/go-square/merkle"
 )
 
 const (
@@ -28,10 +27,24 @@ const (
 var (
 	ErrBlobNotFound = errors.New("blob: not found")
 	ErrInvalidProof = errors.New("blob: invalid proof")
+
+	// ErrInvalidSize is returned by the constructors of blobs whose data is empty or
+	// doesn't fit into a square of the maximum size.
+	ErrInvalidSize = errors.New("blob: invalid data size")
+	// ErrInvalidNamespace is returned by the constructors of blobs whose namespace is malformed.
+	ErrInvalidNamespace = errors.New("blob: invalid namespace")
+	// ErrReservedNamespace is returned by the constructors of blobs whose namespace is
+	// reserved by the protocol, so it can't hold blobs.
+	ErrReservedNamespace = errors.New("blob: reserved namespace")
+	// ErrUnsupportedShareVersion is returned by the constructors of blobs whose share
+	// version isn't supported.
+	ErrUnsupportedShareVersion = errors.New("blob: unsupported share version")
+	// ErrInvalidSigner is returned by the constructors of share version 1 blobs without a
+	// valid signer, and of other blobs with one.
+	ErrInvalidSigner = errors.New("blob: invalid signer")
 )
 
 // CommitmentProof is an inclusion proof of a commitment to the data root.
-// TODO: The verification methods are not copied over from celestia-node because of problematic imports.
 type CommitmentProof struct {
 	// SubtreeRoots are the subtree roots of the blob's data that are
 	// used to create the commitment.
@@ -49,6 +62,8 @@ type CommitmentProof struct {
 	NamespaceVersion uint8           `json:"namespace_version"`
 }
 
+// SubscriptionResponse is an item of a Subscribe subscription, holding the blobs of
+// the namespace included at the height. Blobs is empty for heights without any.
 type SubscriptionResponse struct {
 	Blobs  []*Blob
 	Height uint64
@@ -86,6 +101,9 @@ type Blob struct {
 	// this is to avoid converting to and from app's type
 	namespace share.Namespace
 
+	// signer is the address of the account which paid for a share version one blob.
+	signer []byte
+
 	// index represents the index of the blob's first share in the EDS.
 	// Only retrieved, on-chain blobs will have the index set. Default is -1.
 	index int
@@ -97,12 +115,21 @@ func NewBlobV0(namespace share.Namespace, data []byte) (*Blob, error) {
 	return NewBlob(appconsts.ShareVersionZero, namespace, data)
 }
 
+// NewBlobV1 constructs a new blob from the provided Namespace, data and the address of
+// the account signing the submission, which is embedded into the first share of the blob.
+// The blob will be formatted as v1 shares, which are supported by celestia-app v3 onwards.
+func NewBlobV1(namespace share.Namespace, data []byte, signer []byte) (*Blob, error) {
+	return newBlob(appconsts.ShareVersionOne, namespace, data, signer)
+}
+
 // NewBlob constructs a new blob from the provided Namespace, data and share version.
+// Share version 1 blobs carry a signer, so they must be constructed with NewBlobV1.
 func NewBlob(shareVersion uint8, namespace share.Namespace, data []byte) (*Blob, error) {
-	if len(data) == 0 || len(data) > appconsts.DefaultMaxBytes {
-		return nil, fmt.Errorf("blob data must be > 0 && <= %d, but it was %d bytes", appconsts.DefaultMaxBytes, len(data))
-	}
-	if err := namespace.ValidateForBlob(); err != nil {
+	return newBlob(shareVersion, namespace, data, nil)
+}
+
+func newBlob(shareVersion uint8, namespace share.Namespace, data []byte, signer []byte) (*Blob, error) {
+	if err := validate(shareVersion, namespace, data, signer); err != nil {
 		return nil, err
 	}
 
@@ -113,20 +140,51 @@ func NewBlob(shareVersion uint8, namespace share.Namespace, data []byte) (*Blob,
 		NamespaceVersion: uint32(namespace.Version()),
 	}
 
-	com, err := inclusion.CreateCommitment(&blob, merkle.HashFromByteSlices, appconsts.DefaultSubtreeRootThreshold)
+	//nolint:govet
+	b := &Blob{Blob: blob, namespace: namespace, signer: signer, index: -1}
+	com, err := CreateCommitment(b)
 	if err != nil {
 		return nil, err
 	}
-	//nolint:govet
-	return &Blob{Blob: blob, Commitment: com, namespace: namespace, index: -1}, nil
+	b.Commitment = com
+	return b, nil
+}
+
+// validate checks the fields of a blob, so invalid blobs are rejected on construction
+// instead of failing at the node.
+func validate(shareVersion uint8, namespace share.Namespace, data []byte, signer []byte) error {
+	if len(data) == 0 || len(data)+len(signer) > appconsts.DefaultMaxBytes {
+		return fmt.Errorf("%w: blob data must be > 0 && <= %d, but it was %d bytes",
+			ErrInvalidSize, appconsts.DefaultMaxBytes-len(signer), len(data))
+	}
+	if err := namespace.Validate(); err != nil {
+		return fmt.Errorf("%w: %v", ErrInvalidNamespace, err)
+	}
+	if err := namespace.ValidateForBlob(); err != nil {
+		return fmt.Errorf("%w: %v", ErrReservedNamespace, err)
+	}
+	if !slices.Contains(appconsts.SupportedShareVersions, shareVersion) {
+		return fmt.Errorf("%w: %d", ErrUnsupportedShareVersion, shareVersion)
+	}
+	if shareVersion == appconsts.ShareVersionOne && len(signer) != appconsts.SignerSize {
+		return fmt.Errorf("%w: share version 1 blobs require a signer of %d bytes, got %d",
+			ErrInvalidSigner, appconsts.SignerSize, len(signer))
+	}
+	if shareVersion != appconsts.ShareVersionOne && len(signer) != 0 {
+		return fmt.Errorf("%w: share version %d blobs can't carry a signer", ErrInvalidSigner, shareVersion)
+	}
+	return nil
 }
 
+// jsonBlob is the encoding of blobs used by celestia-node, so blobs returned by the node
+// can be persisted and loaded again without losing any of their fields.
 type jsonBlob struct {
 	Namespace    share.Namespace `json:"namespace"`
 	Data         []byte          `json:"data"`
 	ShareVersion uint32          `json:"share_version"`
 	Commitment   Commitment      `json:"commitment"`
-	Index        int             `json:"index"`
+	Signer       []byte          `json:"signer,omitempty"`
+	Index        *int            `json:"index,omitempty"`
 }
 
 func (b *Blob) MarshalJSON() ([]byte, error) {
@@ -139,7 +197,8 @@ func (b *Blob) MarshalJSON() ([]byte, error) {
 		Data:         b.Data,
 		ShareVersion: b.ShareVersion,
 		Commitment:   b.Commitment,
-		Index:        b.index,
+		Signer:       b.signer,
+		Index:        &b.index,
 	}
 	return json.Marshal(blob)
 }
@@ -150,6 +209,9 @@ func (b *Blob) UnmarshalJSON(data []byte) error {
 	if err != nil {
 		return err
 	}
+	if _, err := share.NamespaceFromBytes(blob.Namespace); err != nil {
+		return err
+	}
 
 	b.Blob.NamespaceVersion = uint32(blob.Namespace.Version())
 	b.Blob.NamespaceId = blob.Namespace.ID()
@@ -157,14 +219,64 @@ func (b *Blob) UnmarshalJSON(data []byte) error {
 	b.Blob.ShareVersion = blob.ShareVersion
 	b.Commitment = blob.Commitment
 	b.namespace = blob.Namespace
-	b.index = blob.Index
+	b.signer = blob.Signer
+	// nodes which don't report the index of blobs omit it
+	b.index = -1
+	if blob.Index != nil {
+		b.index = *blob.Index
+	}
 	return nil
 }
 
+// Signer returns the address of the account which paid for a share version 1 blob,
+// or nil for other blobs.
+func (b *Blob) Signer() []byte {
+	return b.signer
+}
+
+// Equal reports whether the blobs hold the same data under the same namespace, with the
+// same share version, signer and commitment. The index of the blobs isn't compared, so a
+// submitted blob equals the one retrieved from the node.
+func (b *Blob) Equal(other *Blob) bool {
+	if b == nil || other == nil {
+		return b == other
+	}
+	return bytes.Equal(b.Namespace().Bytes(), other.Namespace().Bytes()) &&
+		b.ShareVersion == other.ShareVersion &&
+		bytes.Equal(b.Data, other.Data) &&
+		bytes.Equal(b.signer, other.signer) &&
+		b.Commitment.Equal(other.Commitment)
+}
+
+// Index returns the index of the blob's first share in the extended data square,
+// as reported by the node the blob was retrieved from, or -1 if it isn't known.
 func (b *Blob) Index() int {
 	return b.index
 }
 
+// ShareRange returns the indexes of the blob's first share and the one after its last in
+// the original data square of the given width, as expected by share.API.GetRange.
+// Fails for blobs whose index isn't known, e.g. ones which weren't retrieved from a node.
+func (b *Blob) ShareRange(squareWidth int) (start, end int, err error) {
+	if b.index < 0 {
+		return 0, 0, errors.New("blob: index is unknown")
+	}
+	if squareWidth <= 0 {
+		return 0, 0, fmt.Errorf("blob: invalid square width %d", squareWidth)
+	}
+	length, err := b.Length()
+	if err != nil {
+		return 0, 0, err
+	}
+	// the rows of the extended square are twice as wide as the ones of the original square
+	row, col := b.index/(2*squareWidth), b.index%(2*squareWidth)
+	if col >= squareWidth {
+		return 0, 0, fmt.Errorf("blob: index %d is outside the original square", b.index)
+	}
+	start = row*squareWidth + col
+	return start, start + length, nil
+}
+
 // Length returns the number of shares in the blob.
 func (b *Blob) Length() (int, error) {
 	s, err := BlobsToShares(b)
@@ -185,6 +297,10 @@ func (b *Blob) Length() (int, error) {
 	if err != nil {
 		return 0, err
 	}
+	if len(b.signer) > 0 {
+		// the signer is stored in the first share, next to the data
+		seqLength += appconsts.SignerSize
+	}
 
 	return share.SparseSharesNeeded(seqLength), nil
 }
@@ -0,0 +1,139 @@
+package blob
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/celestiaorg/celestia-openrpc/types/appconsts"
+	"github.com/celestiaorg/celestia-openrpc/types/share"
+)
+
+func testBlob(t *testing.T, data string) *Blob {
+	t.Helper()
+	namespace, err := share.NewBlobNamespaceV0([]byte{1, 2, 3, 4})
+	require.NoError(t, err)
+	b, err := NewBlobV0(namespace, []byte(data))
+	require.NoError(t, err)
+	return b
+}
+
+func testShares(t *testing.T, data string) []share.Share {
+	t.Helper()
+	shares, err := BlobsToShares(testBlob(t, data))
+	require.NoError(t, err)
+	return shares
+}
+
+func TestNewBlobValidation(t *testing.T) {
+	namespace, err := share.NewBlobNamespaceV0([]byte{1, 2, 3, 4})
+	require.NoError(t, err)
+
+	_, err = NewBlobV0(namespace, nil)
+	require.ErrorIs(t, err, ErrInvalidSize)
+	_, err = NewBlobV0(namespace, make([]byte, appconsts.DefaultMaxBytes+1))
+	require.ErrorIs(t, err, ErrInvalidSize)
+	// the signer takes up room of the data
+	_, err = NewBlobV1(namespace, make([]byte, appconsts.DefaultMaxBytes), testSigner)
+	require.ErrorIs(t, err, ErrInvalidSize)
+
+	_, err = NewBlobV0(share.Namespace{1, 2, 3}, []byte("data"))
+	require.ErrorIs(t, err, ErrInvalidNamespace)
+	reserved := []share.Namespace{share.MaxReservedNamespace, share.TailPaddingNamespace, share.ParitySharesNamespace}
+	for _, namespace := range reserved {
+		_, err = NewBlobV0(namespace, []byte("data"))
+		require.ErrorIs(t, err, ErrReservedNamespace)
+	}
+
+	_, err = NewBlob(2, namespace, []byte("data"))
+	require.ErrorIs(t, err, ErrUnsupportedShareVersion)
+	_, err = NewBlob(appconsts.ShareVersionOne, namespace, []byte("data"))
+	require.ErrorIs(t, err, ErrInvalidSigner)
+	_, err = NewBlobV1(namespace, []byte("data"), testSigner[:10])
+	require.ErrorIs(t, err, ErrInvalidSigner)
+}
+
+func TestBlobEqual(t *testing.T) {
+	b := testBlob(t, "hello")
+	require.True(t, b.Equal(testBlob(t, "hello")))
+	// the index of retrieved blobs doesn't matter
+	indexed := *b
+	indexed.index = 5
+	require.True(t, b.Equal(&indexed))
+	require.False(t, b.Equal(testBlob(t, "other")))
+	require.False(t, b.Equal(signedBlob(t, "hello")))
+	require.False(t, b.Equal(nil))
+	require.True(t, (*Blob)(nil).Equal(nil))
+
+	other, err := share.NewBlobNamespaceV0([]byte{9, 9, 9, 9})
+	require.NoError(t, err)
+	moved, err := NewBlobV0(other, []byte("hello"))
+	require.NoError(t, err)
+	require.False(t, b.Equal(moved))
+}
+
+func TestSortBlobs(t *testing.T) {
+	low, err := share.NewBlobNamespaceV0([]byte{1})
+	require.NoError(t, err)
+	high, err := share.NewBlobNamespaceV0([]byte{2})
+	require.NoError(t, err)
+	blobOf := func(namespace share.Namespace, data string) *Blob {
+		b, err := NewBlobV0(namespace, []byte(data))
+		require.NoError(t, err)
+		return b
+	}
+	h1, l1, h2, l2 := blobOf(high, "h1"), blobOf(low, "l1"), blobOf(high, "h2"), blobOf(low, "l2")
+
+	blobs := []*Blob{h1, l1, h2, l2}
+	SortBlobs(blobs)
+	// blobs of the same namespace keep their order
+	require.Equal(t, []*Blob{l1, l2, h1, h2}, blobs)
+
+	// which is the order of the blobs in the shares
+	shares, err := BlobsToShares(h1, l1, h2, l2)
+	require.NoError(t, err)
+	parsed, err := ParseBlobs(shares)
+	require.NoError(t, err)
+	require.Len(t, parsed, len(blobs))
+	for i := range blobs {
+		require.True(t, blobs[i].Equal(parsed[i]))
+	}
+}
+
+func TestParseBlobs(t *testing.T) {
+	small, large := testBlob(t, "small"), testBlob(t, strings.Repeat("l", 2000))
+	padding, err := share.NamespacePaddingShare(small.Namespace())
+	require.NoError(t, err)
+
+	var shares []share.Share
+	for _, b := range []*Blob{small, large} {
+		bShares, err := BlobsToShares(b)
+		require.NoError(t, err)
+		shares = append(append(shares, bShares...), padding.ToBytes())
+	}
+	parsed, err := ParseBlobs(shares)
+	require.NoError(t, err)
+	require.Len(t, parsed, 2)
+	require.True(t, small.Equal(parsed[0]))
+	require.True(t, large.Equal(parsed[1]))
+
+	// the shares of a namespace as returned by the Share API
+	parsed, err = ParseNamespacedShares(share.NamespacedShares{{Shares: shares[:2]}, {Shares: shares[2:]}})
+	require.NoError(t, err)
+	require.Len(t, parsed, 2)
+
+	largeShares := shares[2 : len(shares)-1]
+	_, err = ParseBlobs(largeShares[:len(largeShares)-1])
+	require.Error(t, err)
+	_, err = ParseBlobs(largeShares[1:])
+	require.Error(t, err)
+	// a blob can't be interrupted by padding
+	_, err = ParseBlobs(append([]share.Share{largeShares[0], padding.ToBytes()}, largeShares[1:]...))
+	require.Error(t, err)
+
+	tampered := append([]byte{}, shares[0]...)
+	tampered[len(tampered)-1] = 1
+	_, err = ParseBlobs([]share.Share{tampered})
+	require.Error(t, err)
+}
@@ -0,0 +1,48 @@
+package blob
+
+import (
+	"bytes"
+	"encoding/json"
+	"os"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestBlobJSONCompatibility(t *testing.T) {
+	// blobs as returned by celestia-node
+	raw, err := os.ReadFile("testdata/blobs.json")
+	require.NoError(t, err)
+
+	var blobs []*Blob
+	require.NoError(t, json.Unmarshal(raw, &blobs))
+	require.Len(t, blobs, 2)
+	require.Equal(t, []byte("hello"), blobs[0].Data)
+	require.Equal(t, 40, blobs[0].Index())
+	require.Nil(t, blobs[0].Signer())
+	require.Equal(t, testBlob(t, "hello").Namespace(), blobs[0].Namespace())
+	require.EqualValues(t, 1, blobs[1].ShareVersion)
+	require.Equal(t, testSigner, blobs[1].Signer())
+	require.Equal(t, -1, blobs[1].Index())
+
+	// the blobs are encoded exactly as the node encodes them
+	encoded, err := json.Marshal(blobs)
+	require.NoError(t, err)
+	var compact bytes.Buffer
+	require.NoError(t, json.Compact(&compact, raw))
+	require.Equal(t, compact.String(), string(encoded))
+}
+
+func TestBlobJSONRoundTrip(t *testing.T) {
+	for _, b := range []*Blob{testBlob(t, "hello"), signedBlob(t, "hello")} {
+		encoded, err := json.Marshal(b)
+		require.NoError(t, err)
+		var decoded Blob
+		require.NoError(t, json.Unmarshal(encoded, &decoded))
+		require.Equal(t, b, &decoded)
+	}
+
+	var b Blob
+	require.Error(t, json.Unmarshal([]byte(`{"namespace":"AQID","data":"aGk="}`), &b))
+	require.Error(t, json.Unmarshal([]byte(`{"data":"aGk="}`), &b))
+}
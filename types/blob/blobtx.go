@@ -0,0 +1,118 @@
+package blob
+
+import (
+	"errors"
+	"fmt"
+
+	"google.golang.org/protobuf/encoding/protowire"
+)
+
+// ProtoBlobTxTypeID is the type ID distinguishing a BlobTx from other transactions.
+const ProtoBlobTxTypeID = "BLOB"
+
+// field numbers of celestia-app's BlobTx message
+const (
+	protoBlobTxTx     protowire.Number = 1
+	protoBlobTxBlobs  protowire.Number = 2
+	protoBlobTxTypeID protowire.Number = 3
+)
+
+// ErrNotBlobTx is returned by UnmarshalBlobTx for transactions which aren't a BlobTx.
+var ErrNotBlobTx = errors.New("blob: not a BlobTx")
+
+// BlobTx is a signed transaction paying for blobs, along with the blobs, as broadcast
+// to celestia-app. The blobs are stripped from the transaction once it's in a block.
+type BlobTx struct {
+	// Tx is the signed transaction holding the MsgPayForBlobs.
+	Tx    []byte
+	Blobs []*Blob
+}
+
+// MarshalBlobTx wraps the signed transaction and the blobs it pays for into a BlobTx,
+// ready to be broadcast.
+func MarshalBlobTx(tx []byte, blobs ...*Blob) ([]byte, error) {
+	if len(blobs) == 0 {
+		return nil, errors.New("blob: BlobTx holds no blobs")
+	}
+	var bz []byte
+	bz = protowire.AppendTag(bz, protoBlobTxTx, protowire.BytesType)
+	bz = protowire.AppendBytes(bz, tx)
+	for _, b := range blobs {
+		blobBz, err := b.MarshalProto()
+		if err != nil {
+			return nil, err
+		}
+		bz = protowire.AppendTag(bz, protoBlobTxBlobs, protowire.BytesType)
+		bz = protowire.AppendBytes(bz, blobBz)
+	}
+	bz = protowire.AppendTag(bz, protoBlobTxTypeID, protowire.BytesType)
+	return protowire.AppendString(bz, ProtoBlobTxTypeID), nil
+}
+
+// UnmarshalBlobTx unwraps a BlobTx into the signed transaction and its blobs. It fails with
+// ErrNotBlobTx if the transaction isn't a BlobTx, e.g. a regular transaction of a block.
+func UnmarshalBlobTx(raw []byte) (*BlobTx, error) {
+	var (
+		tx        []byte
+		blobProto [][]byte
+		typeID    string
+	)
+	for data := raw; len(data) > 0; {
+		num, typ, n := protowire.ConsumeTag(data)
+		if n < 0 {
+			return nil, ErrNotBlobTx
+		}
+		data = data[n:]
+
+		switch {
+		case typ == protowire.BytesType && num == protoBlobTxTx:
+			tx, n = protowire.ConsumeBytes(data)
+		case typ == protowire.BytesType && num == protoBlobTxBlobs:
+			var v []byte
+			v, n = protowire.ConsumeBytes(data)
+			blobProto = append(blobProto, v)
+		case typ == protowire.BytesType && num == protoBlobTxTypeID:
+			typeID, n = protowire.ConsumeString(data)
+		default:
+			n = protowire.ConsumeFieldValue(num, typ, data)
+		}
+		if n < 0 {
+			return nil, ErrNotBlobTx
+		}
+		data = data[n:]
+	}
+	// regular transactions may happen to decode, but they don't carry the type ID
+	if typeID != ProtoBlobTxTypeID {
+		return nil, ErrNotBlobTx
+	}
+
+	btx := &BlobTx{Tx: append([]byte(nil), tx...)}
+	for i, bz := range blobProto {
+		b := new(Blob)
+		if err := b.UnmarshalProto(bz); err != nil {
+			return nil, fmt.Errorf("blob %d of BlobTx: %w", i, err)
+		}
+		btx.Blobs = append(btx.Blobs, b)
+	}
+	if len(btx.Blobs) == 0 {
+		return nil, errors.New("blob: BlobTx holds no blobs")
+	}
+	return btx, nil
+}
+
+// BlobsFromTxs extracts the blobs of the BlobTxs among the raw transactions, e.g. the
+// transactions of a block as broadcast, skipping the other transactions.
+func BlobsFromTxs(txs [][]byte) ([]*Blob, error) {
+	var blobs []*Blob
+	for i, tx := range txs {
+		btx, err := UnmarshalBlobTx(tx)
+		if errors.Is(err, ErrNotBlobTx) {
+			continue
+		}
+		if err != nil {
+			return nil, fmt.Errorf("transaction %d: %w", i, err)
+		}
+		blobs = append(blobs, btx.Blobs...)
+	}
+	return blobs, nil
+}
@@ -0,0 +1,32 @@
+package blob
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestBlobTx(t *testing.T) {
+	unsigned, signed := testBlob(t, "hello"), signedBlob(t, "world")
+	tx := []byte("signed transaction")
+	raw, err := MarshalBlobTx(tx, unsigned, signed)
+	require.NoError(t, err)
+
+	btx, err := UnmarshalBlobTx(raw)
+	require.NoError(t, err)
+	require.Equal(t, tx, btx.Tx)
+	require.Len(t, btx.Blobs, 2)
+	require.True(t, unsigned.Equal(btx.Blobs[0]))
+	require.True(t, signed.Equal(btx.Blobs[1]))
+
+	// regular transactions are told apart by the missing type ID
+	_, err = UnmarshalBlobTx(tx)
+	require.ErrorIs(t, err, ErrNotBlobTx)
+	_, err = MarshalBlobTx(tx)
+	require.Error(t, err)
+
+	blobs, err := BlobsFromTxs([][]byte{tx, raw, {0xff}})
+	require.NoError(t, err)
+	require.Len(t, blobs, 2)
+	require.True(t, signed.Equal(blobs[1]))
+}
@@ -0,0 +1,106 @@
+package blob
+
+import (
+	"context"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/celestiaorg/celestia-openrpc/types/share"
+)
+
+// CheckpointStore persists the progress of scans, so a scan of a namespace interrupted,
+// e.g. by a restart, resumes after the last height processed instead of starting over.
+// Implementations must be safe for concurrent use.
+type CheckpointStore interface {
+	// Checkpoint returns the last height of the namespace processed, or zero if none was.
+	Checkpoint(ctx context.Context, namespace share.Namespace) (uint64, error)
+	// SaveCheckpoint records the height as the last one of the namespace processed.
+	SaveCheckpoint(ctx context.Context, namespace share.Namespace, height uint64) error
+}
+
+// MemoryCheckpointStore is a CheckpointStore keeping the checkpoints in memory,
+// e.g. for tests or scans restarted within the same process.
+type MemoryCheckpointStore struct {
+	mu          sync.Mutex
+	checkpoints map[string]uint64
+}
+
+// NewMemoryCheckpointStore returns an empty in-memory checkpoint store.
+func NewMemoryCheckpointStore() *MemoryCheckpointStore {
+	return &MemoryCheckpointStore{checkpoints: make(map[string]uint64)}
+}
+
+// Checkpoint implements CheckpointStore.
+func (s *MemoryCheckpointStore) Checkpoint(_ context.Context, namespace share.Namespace) (uint64, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.checkpoints[string(namespace)], nil
+}
+
+// SaveCheckpoint implements CheckpointStore.
+func (s *MemoryCheckpointStore) SaveCheckpoint(_ context.Context, namespace share.Namespace, height uint64) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.checkpoints[string(namespace)] = height
+	return nil
+}
+
+// FileCheckpointStore is a CheckpointStore keeping the checkpoint of every namespace
+// in a file of a directory, named after the hex encoded namespace.
+type FileCheckpointStore struct {
+	dir string
+}
+
+// NewFileCheckpointStore returns a checkpoint store keeping the checkpoints in dir,
+// which is created if it doesn't exist.
+func NewFileCheckpointStore(dir string) (*FileCheckpointStore, error) {
+	if err := os.MkdirAll(dir, 0o750); err != nil {
+		return nil, err
+	}
+	return &FileCheckpointStore{dir: dir}, nil
+}
+
+// Checkpoint implements CheckpointStore.
+func (s *FileCheckpointStore) Checkpoint(_ context.Context, namespace share.Namespace) (uint64, error) {
+	bz, err := os.ReadFile(s.path(namespace))
+	if errors.Is(err, os.ErrNotExist) {
+		return 0, nil
+	}
+	if err != nil {
+		return 0, err
+	}
+	height, err := strconv.ParseUint(strings.TrimSpace(string(bz)), 10, 64)
+	if err != nil {
+		return 0, fmt.Errorf("blob: malformed checkpoint of namespace %s: %w", namespace, err)
+	}
+	return height, nil
+}
+
+// SaveCheckpoint implements CheckpointStore.
+func (s *FileCheckpointStore) SaveCheckpoint(_ context.Context, namespace share.Namespace, height uint64) error {
+	// the checkpoint is replaced atomically, so it is never lost halfway through a write
+	tmp, err := os.CreateTemp(s.dir, ".checkpoint-*")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(tmp.Name()) //nolint:errcheck
+
+	if _, err := tmp.WriteString(strconv.FormatUint(height, 10)); err != nil {
+		_ = tmp.Close()
+		return err
+	}
+	if err := tmp.Close(); err != nil {
+		return err
+	}
+	return os.Rename(tmp.Name(), s.path(namespace))
+}
+
+func (s *FileCheckpointStore) path(namespace share.Namespace) string {
+	return filepath.Join(s.dir, hex.EncodeToString(namespace))
+}
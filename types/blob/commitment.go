@@ -0,0 +1,57 @@
+package blob
+
+import (
+	"github.com/celestiaorg/nmt"
+
+	"github.com/celestiaorg/celestia-openrpc/types/appconsts"
+	"github.com/celestiaorg/celestia-openrpc/types/share"
+
+	"github.com/celestiaorg/go-square/merkle"
+)
+
+// CreateCommitment computes the share commitment of the blob exactly as celestia-app does:
+// the blob is split into shares, which are grouped into subtrees whose NMT roots are merkleized.
+// The commitment doesn't depend on the node, so it can be computed before submitting the blob,
+// e.g. to deduplicate blobs or to check the blobs returned by the node.
+func CreateCommitment(b *Blob) (Commitment, error) {
+	shares, err := BlobsToShares(b)
+	if err != nil {
+		return nil, err
+	}
+
+	// the subtrees are as wide as the blob's shares are aligned to in the square, see ADR-013
+	subtreeWidth := share.SubTreeWidth(len(shares), appconsts.DefaultSubtreeRootThreshold)
+	namespace := b.Namespace().Bytes()
+	var subtreeRoots [][]byte
+	for _, size := range merkleMountainRangeSizes(len(shares), subtreeWidth) {
+		tree := nmt.New(share.NewSHA256Hasher(), nmt.NamespaceIDSize(appconsts.NamespaceSize),
+			nmt.IgnoreMaxNamespace(NMTIgnoreMaxNamespace))
+		for _, shr := range shares[:size] {
+			if err := tree.Push(append(append([]byte{}, namespace...), shr...)); err != nil {
+				return nil, err
+			}
+		}
+		root, err := tree.Root()
+		if err != nil {
+			return nil, err
+		}
+		subtreeRoots = append(subtreeRoots, root)
+		shares = shares[size:]
+	}
+	return merkle.HashFromByteSlices(subtreeRoots), nil
+}
+
+// merkleMountainRangeSizes returns the sizes of the trees of a merkle mountain range
+// with total leaves, where no tree is bigger than maxTreeSize.
+func merkleMountainRangeSizes(total, maxTreeSize int) []int {
+	var sizes []int
+	for total > 0 {
+		size := maxTreeSize
+		if total < maxTreeSize {
+			size, _ = share.RoundDownPowerOfTwo(total)
+		}
+		sizes = append(sizes, size)
+		total -= size
+	}
+	return sizes
+}
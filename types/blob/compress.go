@@ -0,0 +1,142 @@
+package blob
+
+import (
+	"bytes"
+	"compress/gzip"
+	"errors"
+	"fmt"
+	"io"
+
+	"github.com/klauspost/compress/zstd"
+
+	"github.com/celestiaorg/celestia-openrpc/types/share"
+)
+
+// Codec is the compression codec of the data of a compressed blob.
+type Codec uint8
+
+const (
+	// CodecNone marks compressed blobs whose data didn't shrink, so it's stored as is.
+	CodecNone Codec = iota
+	CodecGzip
+	CodecZstd
+)
+
+func (c Codec) String() string {
+	switch c {
+	case CodecNone:
+		return "none"
+	case CodecGzip:
+		return "gzip"
+	case CodecZstd:
+		return "zstd"
+	default:
+		return fmt.Sprintf("codec(%d)", uint8(c))
+	}
+}
+
+// MaxDecompressedSize is the maximum size of the decompressed data of a blob, which
+// guards against blobs crafted to decompress into excessive amounts of data.
+const MaxDecompressedSize = 64 << 20
+
+// ErrInvalidCompression is returned for compressed blobs whose data can't be decompressed.
+var ErrInvalidCompression = errors.New("blob: invalid compressed data")
+
+// compressionMagic prefixes the envelope of compressed blobs, followed by the codec byte.
+var compressionMagic = []byte("CBZ")
+
+// NewCompressedBlob constructs a share version 0 blob whose data is compressed with the
+// codec and wrapped in a small envelope carrying the codec. If compression doesn't shrink
+// the data, it's stored as is with CodecNone. The original data is returned by
+// DecompressedData.
+func NewCompressedBlob(namespace share.Namespace, data []byte, codec Codec) (*Blob, error) {
+	compressed, err := compress(data, codec)
+	if err != nil {
+		return nil, err
+	}
+	if len(compressed) >= len(data) {
+		codec, compressed = CodecNone, data
+	}
+
+	envelope := make([]byte, 0, len(compressionMagic)+1+len(compressed))
+	envelope = append(append(envelope, compressionMagic...), byte(codec))
+	return NewBlobV0(namespace, append(envelope, compressed...))
+}
+
+// Codec returns the codec of the blob's data and whether the blob was constructed
+// by NewCompressedBlob.
+func (b *Blob) Codec() (Codec, bool) {
+	if len(b.Data) <= len(compressionMagic) || !bytes.HasPrefix(b.Data, compressionMagic) {
+		return CodecNone, false
+	}
+	return Codec(b.Data[len(compressionMagic)]), true
+}
+
+// DecompressedData returns the original data of blobs constructed by NewCompressedBlob,
+// and the data of any other blob as is, so blobs can be read the same way whether they
+// were compressed or not.
+func (b *Blob) DecompressedData() ([]byte, error) {
+	codec, ok := b.Codec()
+	if !ok {
+		return b.Data, nil
+	}
+	compressed := b.Data[len(compressionMagic)+1:]
+
+	var r io.Reader
+	switch codec {
+	case CodecNone:
+		return compressed, nil
+	case CodecGzip:
+		gr, err := gzip.NewReader(bytes.NewReader(compressed))
+		if err != nil {
+			return nil, fmt.Errorf("%w: %v", ErrInvalidCompression, err)
+		}
+		defer gr.Close()
+		r = gr
+	case CodecZstd:
+		zr, err := zstd.NewReader(bytes.NewReader(compressed), zstd.WithDecoderMaxMemory(MaxDecompressedSize))
+		if err != nil {
+			return nil, fmt.Errorf("%w: %v", ErrInvalidCompression, err)
+		}
+		defer zr.Close()
+		r = zr
+	default:
+		return nil, fmt.Errorf("%w: unknown codec %s", ErrInvalidCompression, codec)
+	}
+
+	data, err := io.ReadAll(io.LimitReader(r, MaxDecompressedSize+1))
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidCompression, err)
+	}
+	if len(data) > MaxDecompressedSize {
+		return nil, fmt.Errorf("%w: data exceeds %d bytes", ErrInvalidCompression, MaxDecompressedSize)
+	}
+	return data, nil
+}
+
+// compress compresses the data with the codec.
+func compress(data []byte, codec Codec) ([]byte, error) {
+	switch codec {
+	case CodecNone:
+		return data, nil
+	case CodecGzip:
+		var buf bytes.Buffer
+		w := gzip.NewWriter(&buf)
+		if _, err := w.Write(data); err != nil {
+			return nil, err
+		}
+		if err := w.Close(); err != nil {
+			return nil, err
+		}
+		return buf.Bytes(), nil
+	case CodecZstd:
+		w, err := zstd.NewWriter(nil)
+		if err != nil {
+			return nil, err
+		}
+		defer w.Close()
+		return w.EncodeAll(data, nil), nil
+	default:
+		return nil, fmt.Errorf("blob: unknown codec %s", codec)
+	}
+}
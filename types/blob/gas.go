@@ -0,0 +1,81 @@
+package blob
+
+import (
+	"fmt"
+	"math"
+
+	"github.com/celestiaorg/celestia-openrpc/types/appconsts"
+	"github.com/celestiaorg/celestia-openrpc/types/share"
+)
+
+// EstimateGas estimates the gas required by a PayForBlobs transaction paying for the blobs,
+// following the formula used by celestia-app: every share occupied by the blobs costs
+// DefaultGasPerBlobByte per byte, on top of the size of their info in the transaction and
+// the fixed cost of a PayForBlobs transaction. It can be used to set the gas limit of
+// submissions without asking the node to estimate it.
+func EstimateGas(blobs ...*Blob) uint64 {
+	var shares int
+	for _, b := range blobs {
+		//nolint:gosec
+		shares += share.SparseSharesNeeded(uint32(sequenceLen(b)))
+	}
+	return gasForShares(shares, len(blobs))
+}
+
+// BlobCost is the cost of submitting blobs in a single PayForBlobs transaction.
+type BlobCost struct {
+	// Shares is the number of shares the blobs occupy.
+	Shares int
+	// SquareSize is the width of the smallest square holding the blobs on their own,
+	// which bounds the impact of the blobs on the size of the block.
+	SquareSize int
+	// Gas is the gas the transaction is estimated to use, see EstimateGas.
+	Gas uint64
+}
+
+// Fee returns the fee in utia paid for the gas of the blobs at the gas price.
+func (c BlobCost) Fee(gasPrice float64) uint64 {
+	if gasPrice < 0 {
+		return 0
+	}
+	return uint64(math.Ceil(gasPrice * float64(c.Gas)))
+}
+
+// CalculateBlobCost returns the cost of submitting share version 0 blobs with data of the
+// given sizes in bytes, without constructing the blobs, e.g. for dashboards or to check
+// the cost of a submission before preparing it.
+func CalculateBlobCost(sizes []int) (BlobCost, error) {
+	var shares int
+	for i, size := range sizes {
+		if size <= 0 || size > appconsts.DefaultMaxBytes {
+			return BlobCost{}, fmt.Errorf("%w: blob %d has %d bytes", ErrInvalidSize, i, size)
+		}
+		//nolint:gosec
+		shares += share.SparseSharesNeeded(uint32(size))
+	}
+	return BlobCost{
+		Shares:     shares,
+		SquareSize: share.BlobMinSquareSize(shares),
+		Gas:        gasForShares(shares, len(sizes)),
+	}, nil
+}
+
+// gasForShares returns the gas used by a PayForBlobs transaction paying for count
+// blobs occupying the shares.
+func gasForShares(shares, count int) uint64 {
+	//nolint:gosec
+	gas := uint64(shares) * appconsts.ShareSize * appconsts.DefaultGasPerBlobByte
+	//nolint:gosec
+	gas += appconsts.DefaultTxSizeCostPerByte * appconsts.BytesPerBlobInfo * uint64(count)
+	return gas + appconsts.PFBGasFixedCost
+}
+
+// sequenceLen returns the number of bytes the blob occupies in its shares,
+// including the signer carried by share version 1 blobs.
+func sequenceLen(b *Blob) int {
+	//nolint:gosec
+	if uint8(b.ShareVersion) == appconsts.ShareVersionOne {
+		return appconsts.SignerSize + len(b.Data)
+	}
+	return len(b.Data)
+}
@@ -2,6 +2,7 @@ package blob
 
 import (
 	"bytes"
+	"fmt"
 	"sort"
 
 	"github.com/celestiaorg/celestia-openrpc/types/core"
@@ -19,12 +20,15 @@ func BlobsToShares(blobs ...*Blob) ([]share.Share, error) {
 			Data:             blob.Data,
 			//nolint:gosec
 			ShareVersion: uint8(blob.ShareVersion),
+			Signer:       blob.signer,
 		}
 	}
 
-	sort.Slice(b, func(i, j int) bool {
-		val := bytes.Compare(b[i].NamespaceID, b[j].NamespaceID)
-		return val < 0
+	sort.SliceStable(b, func(i, j int) bool {
+		if b[i].NamespaceVersion != b[j].NamespaceVersion {
+			return b[i].NamespaceVersion < b[j].NamespaceVersion
+		}
+		return bytes.Compare(b[i].NamespaceID, b[j].NamespaceID) < 0
 	})
 
 	rawShares, err := share.SplitBlobs(b...)
@@ -33,3 +37,70 @@ func BlobsToShares(blobs ...*Blob) ([]share.Share, error) {
 	}
 	return share.ToBytes(rawShares), nil
 }
+
+// ParseBlobs is the inverse of BlobsToShares: it reassembles the blobs stored in the sparse shares.
+// Padding shares between blobs are skipped, so the shares of a namespace as returned by the Share
+// API can be passed in as well. The shares of each blob are checked to form a single sequence of
+// the announced length, followed by zeroed padding only, as share.ParseSparseShares does. Blobs of
+// share version one are returned with their signer.
+func ParseBlobs(shares []share.Share) ([]*Blob, error) {
+	appShares, err := share.FromBytes(shares)
+	if err != nil {
+		return nil, err
+	}
+	sequences, err := share.ParseSparseShares(appShares)
+	if err != nil {
+		return nil, err
+	}
+	blobs := make([]*Blob, 0, len(sequences))
+	for _, seq := range sequences {
+		b, err := newBlob(seq.ShareVersion, seq.Namespace, seq.Data, seq.Signer)
+		if err != nil {
+			return nil, fmt.Errorf("blob starting at share %d: %w", seq.Shares.Start, err)
+		}
+		blobs = append(blobs, b)
+	}
+	return blobs, nil
+}
+
+// ParseNamespacedShares reassembles the blobs stored in the shares of a namespace,
+// as returned by GetSharesByNamespace. See ParseBlobs.
+func ParseNamespacedShares(shares share.NamespacedShares) ([]*Blob, error) {
+	return ParseBlobs(shares.Flatten())
+}
+
+// SortBlobs sorts the blobs by namespace in place, the way they are ordered in the square
+// on submission. Blobs of the same namespace keep their relative order, so the order of
+// submitted blobs can be correlated with the order of the blobs returned by the node.
+func SortBlobs(blobs []*Blob) {
+	sort.SliceStable(blobs, func(i, j int) bool {
+		return bytes.Compare(blobs[i].Namespace().Bytes(), blobs[j].Namespace().Bytes()) < 0
+	})
+}
+
+// Deduplicate returns the blobs with byte-identical duplicates within the same namespace left
+// out, in the order of their first occurrence, along with the index among the returned blobs
+// of every one of the given blobs. Identical blobs share their commitment, so paying for
+// them more than once only adds to the fee. Nil blobs are kept as they are.
+func Deduplicate(blobs []*Blob) ([]*Blob, []int) {
+	unique := make([]*Blob, 0, len(blobs))
+	indices := make([]int, len(blobs))
+	seen := make(map[string]int, len(blobs))
+	for i, b := range blobs {
+		if b == nil {
+			indices[i] = len(unique)
+			unique = append(unique, b)
+			continue
+		}
+		// the commitment covers the namespace, the data and the signer of the blob
+		key := string(b.Commitment)
+		idx, ok := seen[key]
+		if !ok {
+			idx = len(unique)
+			seen[key] = idx
+			unique = append(unique, b)
+		}
+		indices[i] = idx
+	}
+	return unique, indices
+}
@@ -0,0 +1,66 @@
+package blob
+
+import (
+	"fmt"
+
+	"github.com/celestiaorg/celestia-openrpc/types/appconsts"
+	"github.com/celestiaorg/celestia-openrpc/types/share"
+)
+
+// Limits are the limits the network enforces on blobs and the transactions paying for them.
+type Limits struct {
+	// AppVersion is the version of celestia-app the limits apply to.
+	AppVersion uint64
+	// MaxSquareSize is the maximum width of the original data square.
+	MaxSquareSize int
+	// MaxTxSize is the maximum size in bytes of a transaction, including its blobs.
+	MaxTxSize int
+	// MaxBlobSize is the maximum size in bytes of the data of a blob, which is bounded
+	// by both the square and the transaction size.
+	MaxBlobSize int
+}
+
+// DefaultLimits returns the limits of the app version under the default governance parameters.
+func DefaultLimits(appVersion uint64) Limits {
+	return NewLimits(appVersion, appconsts.DefaultGovMaxSquareSize)
+}
+
+// NewLimits returns the limits of the app version with the maximum square size set by
+// governance, e.g. as returned by consensus.Client.GovMaxSquareSize.
+func NewLimits(appVersion uint64, govMaxSquareSize int) Limits {
+	squareSize := min(govMaxSquareSize, appconsts.SquareSizeUpperBound(appVersion))
+	return Limits{
+		AppVersion:    appVersion,
+		MaxSquareSize: squareSize,
+		MaxTxSize:     appconsts.DefaultMaxTxSize,
+		MaxBlobSize: min(
+			squareSize*squareSize*appconsts.ContinuationSparseShareContentSize,
+			appconsts.DefaultMaxTxSize-appconsts.BytesPerBlobInfo,
+		),
+	}
+}
+
+// ValidateBlobs checks that the blobs can be paid for in a single transaction within the
+// limits, failing with ErrInvalidSize otherwise. The padding between blobs is disregarded,
+// so blobs passing the check may still not fit into a full square.
+func (l Limits) ValidateBlobs(blobs ...*Blob) error {
+	var txSize, shares int
+	for i, b := range blobs {
+		size := sequenceLen(b)
+		if size > l.MaxBlobSize {
+			return fmt.Errorf("%w: blob %d has %d bytes, the limit is %d", ErrInvalidSize, i, size, l.MaxBlobSize)
+		}
+		txSize += size + appconsts.BytesPerBlobInfo
+		//nolint:gosec
+		shares += share.SparseSharesNeeded(uint32(size))
+	}
+	if txSize > l.MaxTxSize {
+		return fmt.Errorf("%w: the blobs take %d bytes of the transaction, the limit is %d",
+			ErrInvalidSize, txSize, l.MaxTxSize)
+	}
+	if shares > l.MaxSquareSize*l.MaxSquareSize {
+		return fmt.Errorf("%w: the blobs take %d shares, a square of width %d holds %d",
+			ErrInvalidSize, shares, l.MaxSquareSize, l.MaxSquareSize*l.MaxSquareSize)
+	}
+	return nil
+}
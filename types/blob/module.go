@@ -0,0 +1,80 @@
+package blob
+
+import (
+	"context"
+
+	"github.com/celestiaorg/celestia-openrpc/types/share"
+)
+
+// Module is the interface of the blob module. Use Wrap to obtain it from an API
+// and NewAPI to turn an implementation back into an API.
+type Module interface {
+	// Submit sends Blobs and reports the height in which they were included.
+	// Allows sending multiple Blobs atomically synchronously.
+	// Uses default wallet registered on the Node.
+	Submit(context.Context, []*Blob, *SubmitOptions) (uint64, error)
+	// Get retrieves the blob by commitment under the given namespace and height.
+	Get(context.Context, uint64, share.Namespace, Commitment) (*Blob, error)
+	// GetAll returns all blobs at the given height under the given namespaces.
+	GetAll(context.Context, uint64, []share.Namespace) ([]*Blob, error)
+	// GetProof retrieves proofs in the given namespaces at the given height by commitment.
+	GetProof(context.Context, uint64, share.Namespace, Commitment) (*Proof, error)
+	// Included checks whether a blob's given commitment(Merkle subtree root) is included at
+	// given height and under the namespace.
+	Included(context.Context, uint64, share.Namespace, *Proof, Commitment) (bool, error)
+	// GetCommitmentProof generates a commitment proof for a share commitment.
+	GetCommitmentProof(ctx context.Context, height uint64, namespace share.Namespace, shareCommitment []byte) (*CommitmentProof, error)
+	// Subscribe to published blobs from the given namespace as they are included.
+	Subscribe(context.Context, share.Namespace) (<-chan *SubscriptionResponse, error)
+}
+
+// Wrap returns the API as Module.
+func Wrap(api *API) Module {
+	return module{API: api}
+}
+
+// NewAPI returns an API whose methods call the ones of the module.
+func NewAPI(m Module) API {
+	return API{
+		Submit:             m.Submit,
+		Get:                m.Get,
+		GetAll:             m.GetAll,
+		GetProof:           m.GetProof,
+		Included:           m.Included,
+		GetCommitmentProof: m.GetCommitmentProof,
+		Subscribe:          m.Subscribe,
+	}
+}
+
+// module adapts API to Module.
+type module struct {
+	*API
+}
+
+func (m module) Submit(ctx context.Context, blobs []*Blob, options *SubmitOptions) (uint64, error) {
+	return m.API.Submit(ctx, blobs, options)
+}
+
+func (m module) Get(ctx context.Context, height uint64, namespace share.Namespace, commitment Commitment) (*Blob, error) {
+	return m.API.Get(ctx, height, namespace, commitment)
+}
+
+func (m module) GetAll(ctx context.Context, height uint64, namespaces []share.Namespace) ([]*Blob, error) {
+	return m.API.GetAll(ctx, height, namespaces)
+}
+
+func (m module) GetProof(ctx context.Context, height uint64, namespace share.Namespace, commitment Commitment) (*Proof, error) {
+	return m.API.GetProof(ctx, height, namespace, commitment)
+}
+
+func (m module) Included(ctx context.Context, height uint64, namespace share.Namespace, proof *Proof, commitment Commitment) (bool, error) {
+	return m.API.Included(ctx, height, namespace, proof, commitment)
+}
+
+func (m module) GetCommitmentProof(ctx context.Context, height uint64, namespace share.Namespace, shareCommitment []byte) (*CommitmentProof, error) {
+	return m.API.GetCommitmentProof(ctx, height, namespace, shareCommitment)
+}
+
+func (m module) Subscribe(ctx context.Context, namespace share.Namespace) (<-chan *SubscriptionResponse, error) {
+	return m.API.Subscribe(ctx, namespace)
+}
@@ -0,0 +1,189 @@
+package blob
+
+import (
+	"crypto/sha256"
+	"errors"
+	"fmt"
+	"slices"
+
+	"google.golang.org/protobuf/encoding/protowire"
+
+	"github.com/celestiaorg/celestia-openrpc/types/appconsts"
+	"github.com/celestiaorg/celestia-openrpc/types/share"
+)
+
+// URLMsgPayForBlobs is the type URL of MsgPayForBlobs, as packed into the messages of a transaction.
+const URLMsgPayForBlobs = "/celestia.blob.v1.MsgPayForBlobs"
+
+// field numbers of celestia-app's MsgPayForBlobs message
+const (
+	protoPFBSigner           protowire.Number = 1
+	protoPFBNamespaces       protowire.Number = 2
+	protoPFBBlobSizes        protowire.Number = 3
+	protoPFBShareCommitments protowire.Number = 4
+	protoPFBShareVersions    protowire.Number = 5
+)
+
+// MsgPayForBlobs is celestia-app's message paying for the inclusion of blobs in a block.
+// The message is signed and broadcast along with the blobs it pays for.
+type MsgPayForBlobs struct {
+	// Signer is the bech32 address of the account paying for the blobs.
+	Signer string
+	// Namespaces, BlobSizes, ShareCommitments and ShareVersions describe every blob.
+	Namespaces       []share.Namespace
+	BlobSizes        []uint32
+	ShareCommitments []Commitment
+	ShareVersions    []uint32
+}
+
+// NewMsgPayForBlobs builds the message paying for the blobs from the account with the bech32
+// signer address, without asking the node, e.g. to sign and broadcast the transaction with
+// one's own infrastructure.
+func NewMsgPayForBlobs(signer string, blobs ...*Blob) (*MsgPayForBlobs, error) {
+	if _, err := decodeAddress(signer); err != nil {
+		return nil, fmt.Errorf("signer address %s: %w", signer, err)
+	}
+	if err := ValidateSigners(blobs, signer); err != nil {
+		return nil, err
+	}
+
+	msg := &MsgPayForBlobs{Signer: signer}
+	for _, b := range blobs {
+		msg.Namespaces = append(msg.Namespaces, share.Namespace(b.Namespace().Bytes()))
+		//nolint:gosec
+		msg.BlobSizes = append(msg.BlobSizes, uint32(len(b.Data)))
+		msg.ShareCommitments = append(msg.ShareCommitments, b.Commitment)
+		msg.ShareVersions = append(msg.ShareVersions, b.ShareVersion)
+	}
+	return msg, msg.ValidateBasic()
+}
+
+// ValidateBasic performs the stateless checks of celestia-app on the message.
+func (msg *MsgPayForBlobs) ValidateBasic() error {
+	if len(msg.Namespaces) == 0 {
+		return errors.New("blob: MsgPayForBlobs pays for no blobs")
+	}
+	if len(msg.BlobSizes) != len(msg.Namespaces) || len(msg.ShareCommitments) != len(msg.Namespaces) ||
+		len(msg.ShareVersions) != len(msg.Namespaces) {
+		return fmt.Errorf("blob: MsgPayForBlobs describes %d namespaces, %d sizes, %d commitments and %d share versions",
+			len(msg.Namespaces), len(msg.BlobSizes), len(msg.ShareCommitments), len(msg.ShareVersions))
+	}
+	for i, namespace := range msg.Namespaces {
+		if err := namespace.ValidateForBlob(); err != nil {
+			return fmt.Errorf("%w: blob %d: %v", ErrInvalidNamespace, i, err)
+		}
+		if msg.BlobSizes[i] == 0 {
+			return fmt.Errorf("%w: blob %d is empty", ErrInvalidSize, i)
+		}
+		if len(msg.ShareCommitments[i]) != sha256.Size {
+			return fmt.Errorf("blob: commitment of blob %d has %d bytes", i, len(msg.ShareCommitments[i]))
+		}
+		//nolint:gosec
+		if msg.ShareVersions[i] > 255 || !slices.Contains(appconsts.SupportedShareVersions, uint8(msg.ShareVersions[i])) {
+			return fmt.Errorf("%w: blob %d has share version %d", ErrUnsupportedShareVersion, i, msg.ShareVersions[i])
+		}
+	}
+	return nil
+}
+
+// Marshal encodes the message as celestia-app's MsgPayForBlobs protobuf message.
+func (msg *MsgPayForBlobs) Marshal() ([]byte, error) {
+	var bz []byte
+	if msg.Signer != "" {
+		bz = protowire.AppendTag(bz, protoPFBSigner, protowire.BytesType)
+		bz = protowire.AppendString(bz, msg.Signer)
+	}
+	for _, namespace := range msg.Namespaces {
+		bz = protowire.AppendTag(bz, protoPFBNamespaces, protowire.BytesType)
+		bz = protowire.AppendBytes(bz, namespace)
+	}
+	bz = appendPackedUint32s(bz, protoPFBBlobSizes, msg.BlobSizes)
+	for _, commitment := range msg.ShareCommitments {
+		bz = protowire.AppendTag(bz, protoPFBShareCommitments, protowire.BytesType)
+		bz = protowire.AppendBytes(bz, commitment)
+	}
+	bz = appendPackedUint32s(bz, protoPFBShareVersions, msg.ShareVersions)
+	return bz, nil
+}
+
+// Unmarshal decodes the message from celestia-app's MsgPayForBlobs protobuf message.
+func (msg *MsgPayForBlobs) Unmarshal(data []byte) error {
+	var decoded MsgPayForBlobs
+	for len(data) > 0 {
+		num, typ, n := protowire.ConsumeTag(data)
+		if n < 0 {
+			return errors.New("blob: malformed proto tag")
+		}
+		data = data[n:]
+
+		switch {
+		case typ == protowire.BytesType && num == protoPFBSigner:
+			var v string
+			v, n = protowire.ConsumeString(data)
+			decoded.Signer = v
+		case typ == protowire.BytesType && (num == protoPFBNamespaces || num == protoPFBShareCommitments):
+			var v []byte
+			v, n = protowire.ConsumeBytes(data)
+			if num == protoPFBNamespaces {
+				decoded.Namespaces = append(decoded.Namespaces, append(share.Namespace(nil), v...))
+			} else {
+				decoded.ShareCommitments = append(decoded.ShareCommitments, append(Commitment(nil), v...))
+			}
+		case num == protoPFBBlobSizes:
+			decoded.BlobSizes, n = consumeUint32s(decoded.BlobSizes, typ, data)
+		case num == protoPFBShareVersions:
+			decoded.ShareVersions, n = consumeUint32s(decoded.ShareVersions, typ, data)
+		default:
+			n = protowire.ConsumeFieldValue(num, typ, data)
+		}
+		if n < 0 {
+			return fmt.Errorf("blob: malformed proto field %d", num)
+		}
+		data = data[n:]
+	}
+	*msg = decoded
+	return nil
+}
+
+// appendPackedUint32s appends the values as a packed repeated field, the default
+// encoding of repeated scalars in proto3.
+func appendPackedUint32s(bz []byte, num protowire.Number, values []uint32) []byte {
+	if len(values) == 0 {
+		return bz
+	}
+	var packed []byte
+	for _, v := range values {
+		packed = protowire.AppendVarint(packed, uint64(v))
+	}
+	bz = protowire.AppendTag(bz, num, protowire.BytesType)
+	return protowire.AppendBytes(bz, packed)
+}
+
+// consumeUint32s consumes a repeated uint32 field, either packed or not, appending
+// its values. It returns the number of bytes consumed, or a negative one on error.
+func consumeUint32s(values []uint32, typ protowire.Type, data []byte) ([]uint32, int) {
+	switch typ {
+	case protowire.VarintType:
+		v, n := protowire.ConsumeVarint(data)
+		if n < 0 || v > 1<<32-1 {
+			return values, -1
+		}
+		return append(values, uint32(v)), n
+	case protowire.BytesType:
+		packed, n := protowire.ConsumeBytes(data)
+		if n < 0 {
+			return values, n
+		}
+		for len(packed) > 0 {
+			v, m := protowire.ConsumeVarint(packed)
+			if m < 0 || v > 1<<32-1 {
+				return values, -1
+			}
+			values = append(values, uint32(v))
+			packed = packed[m:]
+		}
+		return values, n
+	default:
+		return values, -1
+	}
+}
@@ -0,0 +1,43 @@
+package blob
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/encoding/protowire"
+)
+
+func TestMsgPayForBlobs(t *testing.T) {
+	unsigned, signed := testBlob(t, "hello"), signedBlob(t, "world")
+	msg, err := NewMsgPayForBlobs(testSignerAddress, unsigned, signed)
+	require.NoError(t, err)
+	require.Equal(t, testSignerAddress, msg.Signer)
+	require.Equal(t, []uint32{5, 5}, msg.BlobSizes)
+	require.Equal(t, []uint32{0, 1}, msg.ShareVersions)
+	require.Equal(t, []Commitment{unsigned.Commitment, signed.Commitment}, msg.ShareCommitments)
+
+	bz, err := msg.Marshal()
+	require.NoError(t, err)
+	var decoded MsgPayForBlobs
+	require.NoError(t, decoded.Unmarshal(bz))
+	require.Equal(t, *msg, decoded)
+	require.NoError(t, decoded.ValidateBasic())
+
+	// repeated scalars may be encoded unpacked as well
+	var unpacked []byte
+	unpacked = protowire.AppendTag(unpacked, 3, protowire.VarintType)
+	unpacked = protowire.AppendVarint(unpacked, 7)
+	unpacked = protowire.AppendTag(unpacked, 3, protowire.VarintType)
+	unpacked = protowire.AppendVarint(unpacked, 8)
+	require.NoError(t, decoded.Unmarshal(unpacked))
+	require.Equal(t, []uint32{7, 8}, decoded.BlobSizes)
+	require.Error(t, decoded.ValidateBasic())
+
+	// signed blobs must be paid for by their signer
+	_, err = NewMsgPayForBlobs(otherSignerAddress, unsigned, signed)
+	require.ErrorIs(t, err, ErrSignerMismatch)
+	_, err = NewMsgPayForBlobs("celestia1invalid", unsigned)
+	require.Error(t, err)
+	_, err = NewMsgPayForBlobs(testSignerAddress)
+	require.Error(t, err)
+}
@@ -0,0 +1,139 @@
+package blob
+
+import (
+	"bytes"
+	"fmt"
+
+	"github.com/celestiaorg/nmt"
+
+	"github.com/celestiaorg/celestia-openrpc/types/appconsts"
+	"github.com/celestiaorg/celestia-openrpc/types/share"
+
+	"github.com/celestiaorg/go-square/merkle"
+)
+
+// VerifyInclusion checks that the proof, as returned by GetProof, proves the inclusion of
+// the blob in the square committed to by the root, without asking the node. The root must
+// hash to the trusted data root, e.g. the DataHash of a verified header. Fails with
+// ErrInvalidProof if the blob isn't proven to be included.
+func (p Proof) VerifyInclusion(b *Blob, root *share.Root, dataRoot []byte) error {
+	if root == nil || !bytes.Equal(root.Hash(), dataRoot) {
+		return fmt.Errorf("%w: root doesn't match the data root", ErrInvalidProof)
+	}
+	shares, err := BlobsToShares(b)
+	if err != nil {
+		return err
+	}
+
+	// the proofs cover consecutive rows, starting at one of the rows holding the namespace
+	namespace := share.Namespace(b.Namespace().Bytes())
+	for _, row := range root.RowsWithNamespace(namespace) {
+		if p.verifyFrom(row, shares, namespace, root) {
+			return nil
+		}
+	}
+	return ErrInvalidProof
+}
+
+// verifyFrom reports whether the proofs prove the shares to be in the rows starting at startRow.
+func (p Proof) verifyFrom(startRow int, shares []share.Share, namespace share.Namespace, root *share.Root) bool {
+	if len(p) == 0 || startRow+len(p) > len(root.RowRoots) {
+		return false
+	}
+	cursor := 0
+	for i, proof := range p {
+		if proof == nil {
+			return false
+		}
+		n := proof.End() - proof.Start()
+		if n <= 0 || cursor+n > len(shares) {
+			return false
+		}
+		leaves := shares[cursor : cursor+n]
+		if !proof.VerifyInclusion(share.NewSHA256Hasher(), namespace.ToNMT(), leaves, root.RowRoots[startRow+i]) {
+			return false
+		}
+		cursor += n
+	}
+	return cursor == len(shares)
+}
+
+// Validate performs basic checks on the fields of the commitment proof.
+func (p *CommitmentProof) Validate() error {
+	if len(p.SubtreeRoots) < len(p.SubtreeRootProofs) {
+		return fmt.Errorf("the number of subtree roots %d should be bigger than the number of subtree root proofs %d",
+			len(p.SubtreeRoots), len(p.SubtreeRootProofs))
+	}
+	if len(p.SubtreeRootProofs) != len(p.RowProof.Proofs) {
+		return fmt.Errorf("the number of subtree root proofs %d should be equal to the number of row root proofs %d",
+			len(p.SubtreeRootProofs), len(p.RowProof.Proofs))
+	}
+	if int(p.RowProof.EndRow-p.RowProof.StartRow+1) != len(p.RowProof.RowRoots) {
+		return fmt.Errorf("the number of rows %d must equal the number of row roots %d",
+			int(p.RowProof.EndRow-p.RowProof.StartRow+1), len(p.RowProof.RowRoots))
+	}
+	if len(p.RowProof.Proofs) != len(p.RowProof.RowRoots) {
+		return fmt.Errorf("the number of proofs %d must equal the number of row roots %d",
+			len(p.RowProof.Proofs), len(p.RowProof.RowRoots))
+	}
+	return nil
+}
+
+// Verify checks that the subtree roots are included in the rows and the rows in the
+// square committed to by the trusted data root, e.g. the DataHash of a verified header.
+// Together with GenerateCommitment, it proves the inclusion of a commitment without
+// the blob's data. Fails with ErrInvalidProof if the proof doesn't verify.
+func (p *CommitmentProof) Verify(dataRoot []byte) error {
+	if err := p.Validate(); err != nil {
+		return fmt.Errorf("%w: %v", ErrInvalidProof, err)
+	}
+
+	nmtHasher := nmt.NewNmtHasher(share.NewSHA256Hasher(), appconsts.NamespaceSize, NMTIgnoreMaxNamespace)
+	// the width of the subtrees depends on the total number of shares proven, see ADR-013
+	numberOfShares := 0
+	for _, proof := range p.SubtreeRootProofs {
+		if proof == nil {
+			return fmt.Errorf("%w: missing subtree root proof", ErrInvalidProof)
+		}
+		numberOfShares += proof.End() - proof.Start()
+	}
+	subtreeRootsWidth := share.SubTreeWidth(numberOfShares, appconsts.DefaultSubtreeRootThreshold)
+
+	cursor := 0
+	for i, proof := range p.SubtreeRootProofs {
+		ranges, err := nmt.ToLeafRanges(proof.Start(), proof.End(), subtreeRootsWidth)
+		if err != nil {
+			return fmt.Errorf("%w: %v", ErrInvalidProof, err)
+		}
+		if cursor+len(ranges) > len(p.SubtreeRoots) {
+			return fmt.Errorf("%w: proof of row %d covers more subtree roots than provided", ErrInvalidProof, i)
+		}
+		valid, err := proof.VerifySubtreeRootInclusion(
+			nmtHasher,
+			p.SubtreeRoots[cursor:cursor+len(ranges)],
+			subtreeRootsWidth,
+			p.RowProof.RowRoots[i],
+		)
+		if err != nil {
+			return fmt.Errorf("%w: %v", ErrInvalidProof, err)
+		}
+		if !valid {
+			return fmt.Errorf("%w: subtree roots of row %d don't match its root", ErrInvalidProof, i)
+		}
+		cursor += len(ranges)
+	}
+	if cursor != len(p.SubtreeRoots) {
+		return fmt.Errorf("%w: %d of %d subtree roots are proven", ErrInvalidProof, cursor, len(p.SubtreeRoots))
+	}
+
+	if !p.RowProof.VerifyProof(dataRoot) {
+		return fmt.Errorf("%w: rows aren't in the square of the data root", ErrInvalidProof)
+	}
+	return nil
+}
+
+// GenerateCommitment returns the commitment the subtree roots of the proof add up to,
+// which is the share commitment of the proven blob.
+func (p *CommitmentProof) GenerateCommitment() Commitment {
+	return merkle.HashFromByteSlices(p.SubtreeRoots)
+}
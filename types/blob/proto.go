@@ -0,0 +1,122 @@
+package blob
+
+import (
+	"errors"
+	"fmt"
+
+	"google.golang.org/protobuf/encoding/protowire"
+
+	"github.com/celestiaorg/celestia-openrpc/types/share"
+)
+
+// field numbers of celestia-app's BlobProto message
+const (
+	protoNamespaceID      protowire.Number = 1
+	protoData             protowire.Number = 2
+	protoShareVersion     protowire.Number = 3
+	protoNamespaceVersion protowire.Number = 4
+	protoSigner           protowire.Number = 5
+)
+
+// MarshalProto encodes the blob as celestia-app's BlobProto message, e.g. to be embedded
+// into a BlobTx. The commitment and the index aren't part of the encoding.
+func (b *Blob) MarshalProto() ([]byte, error) {
+	var bz []byte
+	bz = protowire.AppendTag(bz, protoNamespaceID, protowire.BytesType)
+	bz = protowire.AppendBytes(bz, b.NamespaceId)
+	bz = protowire.AppendTag(bz, protoData, protowire.BytesType)
+	bz = protowire.AppendBytes(bz, b.Data)
+	if b.ShareVersion != 0 {
+		bz = protowire.AppendTag(bz, protoShareVersion, protowire.VarintType)
+		bz = protowire.AppendVarint(bz, uint64(b.ShareVersion))
+	}
+	if b.NamespaceVersion != 0 {
+		bz = protowire.AppendTag(bz, protoNamespaceVersion, protowire.VarintType)
+		bz = protowire.AppendVarint(bz, uint64(b.NamespaceVersion))
+	}
+	if len(b.signer) > 0 {
+		bz = protowire.AppendTag(bz, protoSigner, protowire.BytesType)
+		bz = protowire.AppendBytes(bz, b.signer)
+	}
+	return bz, nil
+}
+
+// UnmarshalProto decodes the blob from celestia-app's BlobProto message. The blob is
+// validated and its commitment computed, as if it was constructed with NewBlob.
+func (b *Blob) UnmarshalProto(data []byte) error {
+	var (
+		namespaceID, blobData, signer  []byte
+		shareVersion, namespaceVersion uint64
+	)
+	for len(data) > 0 {
+		num, typ, n := protowire.ConsumeTag(data)
+		if n < 0 {
+			return errors.New("blob: malformed proto tag")
+		}
+		data = data[n:]
+
+		switch {
+		case typ == protowire.BytesType && (num == protoNamespaceID || num == protoData || num == protoSigner):
+			var v []byte
+			v, n = protowire.ConsumeBytes(data)
+			switch num {
+			case protoNamespaceID:
+				namespaceID = v
+			case protoData:
+				blobData = v
+			default:
+				signer = v
+			}
+		case typ == protowire.VarintType && (num == protoShareVersion || num == protoNamespaceVersion):
+			var v uint64
+			v, n = protowire.ConsumeVarint(data)
+			if num == protoShareVersion {
+				shareVersion = v
+			} else {
+				namespaceVersion = v
+			}
+		default:
+			n = protowire.ConsumeFieldValue(num, typ, data)
+		}
+		if n < 0 {
+			return fmt.Errorf("blob: malformed proto field %d", num)
+		}
+		data = data[n:]
+	}
+
+	if shareVersion > 255 || namespaceVersion > 255 {
+		return fmt.Errorf("blob: invalid share version %d or namespace version %d", shareVersion, namespaceVersion)
+	}
+	//nolint:gosec
+	namespace := share.Namespace(append([]byte{byte(namespaceVersion)}, namespaceID...))
+	//nolint:gosec
+	blob, err := newBlob(uint8(shareVersion), namespace, append([]byte{}, blobData...), append([]byte(nil), signer...))
+	if err != nil {
+		return err
+	}
+	*b = *blob
+	return nil
+}
+
+// Marshal implements the customtype interface of gogoproto, so commitments can be used
+// as the type of bytes fields of gogoproto messages, e.g. the share commitments of a
+// MsgPayForBlobs.
+func (com Commitment) Marshal() ([]byte, error) {
+	return []byte(com), nil
+}
+
+// MarshalTo implements the customtype interface of gogoproto.
+func (com Commitment) MarshalTo(data []byte) (int, error) {
+	return copy(data, com), nil
+}
+
+// Unmarshal implements the customtype interface of gogoproto.
+func (com *Commitment) Unmarshal(data []byte) error {
+	*com = append(Commitment(nil), data...)
+	return nil
+}
+
+// Size implements the customtype interface of gogoproto.
+func (com Commitment) Size() int {
+	return len(com)
+}
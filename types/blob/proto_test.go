@@ -0,0 +1,51 @@
+package blob
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestBlobProto(t *testing.T) {
+	b := testBlob(t, "hello")
+	bz, err := b.MarshalProto()
+	require.NoError(t, err)
+	// share version 0 blobs are encoded exactly as go-square encodes them
+	expected, err := b.Blob.Marshal()
+	require.NoError(t, err)
+	require.Equal(t, expected, bz)
+
+	var decoded Blob
+	require.NoError(t, decoded.UnmarshalProto(bz))
+	require.Equal(t, b.Namespace(), decoded.Namespace())
+	require.Equal(t, b.Data, decoded.Data)
+	require.Equal(t, b.Commitment, decoded.Commitment)
+	require.Equal(t, -1, decoded.Index())
+
+	signed := signedBlob(t, "hello")
+	bz, err = signed.MarshalProto()
+	require.NoError(t, err)
+	require.NoError(t, decoded.UnmarshalProto(bz))
+	require.Equal(t, testSigner, decoded.Signer())
+	require.EqualValues(t, 1, decoded.ShareVersion)
+	require.Equal(t, signed.Commitment, decoded.Commitment)
+
+	require.Error(t, decoded.UnmarshalProto([]byte{0xff}))
+	require.Error(t, decoded.UnmarshalProto(nil))
+}
+
+func TestCommitmentProto(t *testing.T) {
+	com := testBlob(t, "hello").Commitment
+	bz, err := com.Marshal()
+	require.NoError(t, err)
+	require.Len(t, bz, com.Size())
+
+	buf := make([]byte, com.Size())
+	n, err := com.MarshalTo(buf)
+	require.NoError(t, err)
+	require.Equal(t, bz, buf[:n])
+
+	var decoded Commitment
+	require.NoError(t, decoded.Unmarshal(bz))
+	require.True(t, com.Equal(decoded))
+}
@@ -0,0 +1,231 @@
+package blob
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+
+	"github.com/celestiaorg/celestia-openrpc/types/share"
+)
+
+// defaultScanConcurrency is the default number of heights fetched at once by Scan.
+const defaultScanConcurrency = 8
+
+// ScanOption is the functional option that is applied to the scan
+// to configure its parameters.
+type ScanOption func(cfg *scanConfig)
+
+type scanConfig struct {
+	concurrency int
+	checkpoints CheckpointStore
+}
+
+// WithScanConcurrency is an option that allows to specify the maximum number of
+// heights fetched from the node at once.
+func WithScanConcurrency(concurrency int) ScanOption {
+	return func(cfg *scanConfig) {
+		cfg.concurrency = concurrency
+	}
+}
+
+// WithScanCheckpoints is an option that allows to specify the store keeping the progress
+// of the scan. The scan resumes after the checkpoint of the namespace, if any, instead of
+// starting at fromHeight. A height is checkpointed once the result of the following one
+// is received, so the height being processed when the scan is interrupted is scanned
+// again on resume. If a checkpoint can't be saved, the scan stops with a result carrying
+// the checkpointed height and the error.
+func WithScanCheckpoints(store CheckpointStore) ScanOption {
+	return func(cfg *scanConfig) {
+		cfg.checkpoints = store
+	}
+}
+
+// newScanConfig applies the options to the default scan configuration.
+func newScanConfig(opts []ScanOption) (*scanConfig, error) {
+	cfg := &scanConfig{concurrency: defaultScanConcurrency}
+	for _, opt := range opts {
+		opt(cfg)
+	}
+	if cfg.concurrency < 1 {
+		return nil, fmt.Errorf("blob: invalid scan concurrency %d", cfg.concurrency)
+	}
+	return cfg, nil
+}
+
+// ScanResult holds the blobs of the namespace at a height of a scan, or the error
+// retrieving them failed with. Blobs is empty for heights without any.
+// It holds the blobs of all the namespaces for results of GetAllAtHeights.
+type ScanResult struct {
+	Height uint64
+	Blobs  []*Blob
+	Err    error
+}
+
+// Scan retrieves the blobs of the namespace at every height from fromHeight to toHeight,
+// inclusive, e.g. to sync a rollup from its genesis. Heights are fetched concurrently,
+// but their results are delivered in order, one for every height. Once a height can't be
+// retrieved, its result carries the error and the scan stops. The channel is closed
+// once the scan completes or stops, or ctx is done.
+func Scan(
+	ctx context.Context,
+	api *API,
+	namespace share.Namespace,
+	fromHeight, toHeight uint64,
+	opts ...ScanOption,
+) (<-chan *ScanResult, error) {
+	if err := namespace.ValidateForBlob(); err != nil {
+		return nil, err
+	}
+	if fromHeight == 0 || toHeight < fromHeight {
+		return nil, fmt.Errorf("blob: invalid scan range [%d, %d]", fromHeight, toHeight)
+	}
+
+	cfg, err := newScanConfig(opts)
+	if err != nil {
+		return nil, err
+	}
+
+	if cfg.checkpoints != nil {
+		checkpoint, err := cfg.checkpoints.Checkpoint(ctx, namespace)
+		if err != nil {
+			return nil, fmt.Errorf("blob: loading scan checkpoint: %w", err)
+		}
+		if checkpoint >= toHeight {
+			// the scan was completed before
+			out := make(chan *ScanResult)
+			close(out)
+			return out, nil
+		}
+		fromHeight = max(fromHeight, checkpoint+1)
+	}
+
+	out := make(chan *ScanResult)
+	go scan(ctx, api, namespace, fromHeight, toHeight, cfg, out)
+	return out, nil
+}
+
+func scan(
+	ctx context.Context,
+	api *API,
+	namespace share.Namespace,
+	fromHeight, toHeight uint64,
+	cfg *scanConfig,
+	out chan<- *ScanResult,
+) {
+	defer close(out)
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	// the results of the heights being fetched are queued in order; together with the
+	// one awaited below, the queue bounds the number of heights fetched at once
+	pending := make(chan chan *ScanResult, cfg.concurrency-1)
+	go func() {
+		defer close(pending)
+		for height := fromHeight; height <= toHeight; height++ {
+			res := make(chan *ScanResult, 1)
+			select {
+			case pending <- res:
+			case <-ctx.Done():
+				return
+			}
+			go func(height uint64) {
+				res <- fetchHeight(ctx, api, []share.Namespace{namespace}, height)
+			}(height)
+			if height == toHeight {
+				// avoid overflowing when scanning up to the maximum height
+				return
+			}
+		}
+	}()
+
+	for res := range pending {
+		var r *ScanResult
+		select {
+		case r = <-res:
+		case <-ctx.Done():
+			return
+		}
+		select {
+		case out <- r:
+		case <-ctx.Done():
+			return
+		}
+		if r.Err != nil {
+			return
+		}
+
+		if cfg.checkpoints != nil && r.Height > fromHeight {
+			// the result of the previous height was processed, as the consumer is back for more
+			if err := cfg.checkpoints.SaveCheckpoint(ctx, namespace, r.Height-1); err != nil {
+				err = fmt.Errorf("blob: saving scan checkpoint: %w", err)
+				select {
+				case out <- &ScanResult{Height: r.Height - 1, Err: err}:
+				case <-ctx.Done():
+				}
+				return
+			}
+		}
+	}
+}
+
+// GetAllAtHeights retrieves the blobs of the namespaces at each of the heights, e.g. to
+// index a set of blocks. The heights are fetched by a pool of as many workers as the scan
+// concurrency, other options don't apply. A result is returned for every height, in the
+// order of the heights; heights whose blobs can't be retrieved carry the error in their
+// result. An error is only returned if ctx is done before all heights are retrieved.
+func GetAllAtHeights(
+	ctx context.Context,
+	api *API,
+	heights []uint64,
+	namespaces []share.Namespace,
+	opts ...ScanOption,
+) ([]*ScanResult, error) {
+	for _, namespace := range namespaces {
+		if err := namespace.ValidateForBlob(); err != nil {
+			return nil, err
+		}
+	}
+	cfg, err := newScanConfig(opts)
+	if err != nil {
+		return nil, err
+	}
+
+	results := make([]*ScanResult, len(heights))
+	indexes := make(chan int)
+	var wg sync.WaitGroup
+	for w := 0; w < min(cfg.concurrency, len(heights)); w++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := range indexes {
+				results[i] = fetchHeight(ctx, api, namespaces, heights[i])
+			}
+		}()
+	}
+	for i := 0; i < len(heights) && ctx.Err() == nil; i++ {
+		select {
+		case indexes <- i:
+		case <-ctx.Done():
+		}
+	}
+	close(indexes)
+	wg.Wait()
+
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	return results, nil
+}
+
+// fetchHeight retrieves the blobs of the namespaces at the height.
+func fetchHeight(ctx context.Context, api *API, namespaces []share.Namespace, height uint64) *ScanResult {
+	blobs, err := api.GetAll(ctx, height, namespaces)
+	if errors.Is(err, ErrBlobNotFound) {
+		blobs, err = nil, nil
+	}
+	if err != nil {
+		err = fmt.Errorf("blob: retrieving height %d: %w", height, err)
+	}
+	return &ScanResult{Height: height, Blobs: blobs, Err: err}
+}
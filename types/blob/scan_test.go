@@ -0,0 +1,178 @@
+package blob
+
+import (
+	"context"
+	"errors"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/celestiaorg/celestia-openrpc/types/share"
+)
+
+func TestScan(t *testing.T) {
+	b := testBlob(t, "hello")
+	namespace := share.Namespace(b.Namespace().Bytes())
+	var inFlight, maxInFlight atomic.Int32
+	api := &API{}
+	api.GetAll = func(_ context.Context, height uint64, _ []share.Namespace) ([]*Blob, error) {
+		n := inFlight.Add(1)
+		defer inFlight.Add(-1)
+		for {
+			m := maxInFlight.Load()
+			if n <= m || maxInFlight.CompareAndSwap(m, n) {
+				break
+			}
+		}
+		// later heights are answered first
+		time.Sleep(time.Duration(20-height) * time.Millisecond)
+		if height%3 == 0 {
+			return nil, ErrBlobNotFound
+		}
+		return []*Blob{b}, nil
+	}
+
+	results, err := Scan(context.Background(), api, namespace, 2, 10, WithScanConcurrency(3))
+	require.NoError(t, err)
+	height := uint64(2)
+	for res := range results {
+		require.NoError(t, res.Err)
+		require.Equal(t, height, res.Height)
+		if height%3 == 0 {
+			require.Empty(t, res.Blobs)
+		} else {
+			require.Equal(t, []*Blob{b}, res.Blobs)
+		}
+		height++
+	}
+	require.EqualValues(t, 11, height)
+	require.LessOrEqual(t, maxInFlight.Load(), int32(3))
+}
+
+func TestScanStopsOnError(t *testing.T) {
+	b := testBlob(t, "hello")
+	failure := errors.New("unavailable")
+	api := &API{}
+	api.GetAll = func(_ context.Context, height uint64, _ []share.Namespace) ([]*Blob, error) {
+		if height == 3 {
+			return nil, failure
+		}
+		return []*Blob{b}, nil
+	}
+
+	results, err := Scan(context.Background(), api, share.Namespace(b.Namespace().Bytes()), 1, 100)
+	require.NoError(t, err)
+	var heights []uint64
+	for res := range results {
+		heights = append(heights, res.Height)
+		if res.Height == 3 {
+			require.ErrorIs(t, res.Err, failure)
+		}
+	}
+	require.Equal(t, []uint64{1, 2, 3}, heights)
+
+	_, err = Scan(context.Background(), api, share.Namespace(b.Namespace().Bytes()), 5, 4)
+	require.Error(t, err)
+}
+
+func TestScanCheckpoints(t *testing.T) {
+	b := testBlob(t, "hello")
+	namespace := share.Namespace(b.Namespace().Bytes())
+	api := &API{}
+	api.GetAll = func(context.Context, uint64, []share.Namespace) ([]*Blob, error) {
+		return []*Blob{b}, nil
+	}
+	// interrupted is an API whose scans hang at height 5 until they are cancelled
+	interrupted := &API{}
+	interrupted.GetAll = func(ctx context.Context, height uint64, _ []share.Namespace) ([]*Blob, error) {
+		if height == 5 {
+			<-ctx.Done()
+			return nil, ctx.Err()
+		}
+		return []*Blob{b}, nil
+	}
+	fileStore, err := NewFileCheckpointStore(t.TempDir())
+	require.NoError(t, err)
+
+	for _, store := range []CheckpointStore{NewMemoryCheckpointStore(), fileStore} {
+		ctx, cancel := context.WithCancel(context.Background())
+		results, err := Scan(ctx, interrupted, namespace, 1, 10,
+			WithScanConcurrency(1), WithScanCheckpoints(store))
+		require.NoError(t, err)
+		// stop while processing height 4, after receiving its result
+		for res := range results {
+			if res.Height == 4 {
+				cancel()
+			}
+		}
+		checkpoint, err := store.Checkpoint(context.Background(), namespace)
+		require.NoError(t, err)
+		require.EqualValues(t, 3, checkpoint)
+
+		// the scan resumes with the height it was interrupted at
+		results, err = Scan(context.Background(), api, namespace, 1, 10,
+			WithScanConcurrency(1), WithScanCheckpoints(store))
+		require.NoError(t, err)
+		var heights []uint64
+		for res := range results {
+			require.NoError(t, res.Err)
+			heights = append(heights, res.Height)
+		}
+		require.Equal(t, []uint64{4, 5, 6, 7, 8, 9, 10}, heights)
+		checkpoint, err = store.Checkpoint(context.Background(), namespace)
+		require.NoError(t, err)
+		require.EqualValues(t, 9, checkpoint)
+	}
+}
+
+func TestGetAllAtHeights(t *testing.T) {
+	b := testBlob(t, "hello")
+	failure := errors.New("unavailable")
+	var inFlight, maxInFlight atomic.Int32
+	api := &API{}
+	api.GetAll = func(_ context.Context, height uint64, _ []share.Namespace) ([]*Blob, error) {
+		n := inFlight.Add(1)
+		defer inFlight.Add(-1)
+		for {
+			m := maxInFlight.Load()
+			if n <= m || maxInFlight.CompareAndSwap(m, n) {
+				break
+			}
+		}
+		time.Sleep(time.Duration(height%5) * time.Millisecond)
+		switch height {
+		case 7:
+			return nil, ErrBlobNotFound
+		case 9:
+			return nil, failure
+		}
+		return []*Blob{b}, nil
+	}
+
+	heights := []uint64{12, 3, 7, 20, 9, 5, 4}
+	namespaces := []share.Namespace{share.Namespace(b.Namespace().Bytes())}
+	results, err := GetAllAtHeights(context.Background(), api, heights, namespaces, WithScanConcurrency(2))
+	require.NoError(t, err)
+	require.Len(t, results, len(heights))
+	for i, res := range results {
+		require.Equal(t, heights[i], res.Height)
+		switch res.Height {
+		case 7:
+			require.NoError(t, res.Err)
+			require.Empty(t, res.Blobs)
+		case 9:
+			require.ErrorIs(t, res.Err, failure)
+		default:
+			require.NoError(t, res.Err)
+			require.Equal(t, []*Blob{b}, res.Blobs)
+		}
+	}
+	require.LessOrEqual(t, maxInFlight.Load(), int32(2))
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	_, err = GetAllAtHeights(ctx, api, heights, namespaces)
+	require.ErrorIs(t, err, context.Canceled)
+}
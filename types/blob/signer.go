@@ -0,0 +1,142 @@
+package blob
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/celestiaorg/celestia-openrpc/types/appconsts"
+)
+
+// ErrSignerMismatch is returned when the signer embedded into a share version 1 blob isn't
+// the account submitting it, which celestia-app would reject.
+var ErrSignerMismatch = errors.New("blob: signer doesn't match the submitting account")
+
+// ValidateSigners checks that the share version 1 blobs are signed by the account with the
+// bech32 signer address, as set in the options of the submission. As the node picks its
+// default account if none is set, the signer address must be set to submit such blobs.
+func ValidateSigners(blobs []*Blob, signerAddress string) error {
+	for i, b := range blobs {
+		//nolint:gosec
+		if uint8(b.ShareVersion) != appconsts.ShareVersionOne {
+			continue
+		}
+		if signerAddress == "" {
+			return fmt.Errorf("%w: blob %d is signed, but no signer address is set", ErrSignerMismatch, i)
+		}
+		signer, err := decodeAddress(signerAddress)
+		if err != nil {
+			return fmt.Errorf("signer address %s: %w", signerAddress, err)
+		}
+		if !bytes.Equal(signer, b.Signer()) {
+			return fmt.Errorf("%w: blob %d is signed by another account than %s", ErrSignerMismatch, i, signerAddress)
+		}
+	}
+	return nil
+}
+
+// AddressPrefix is the bech32 prefix of celestia account addresses.
+const AddressPrefix = "celestia"
+
+const bech32Charset = "qpzry9x8gf2tvdw0s3jn54khce6mua7l"
+
+// EncodeAddress returns the bech32 encoding of the account address, e.g. celestia1...
+func EncodeAddress(addr []byte) string {
+	data, _ := convertBits(addr, 8, 5, true)
+	values := append(bech32ExpandHRP(AddressPrefix), data...)
+	polymod := bech32Polymod(append(values, 0, 0, 0, 0, 0, 0)) ^ 1
+	for i := 0; i < 6; i++ {
+		data = append(data, byte(polymod>>(5*(5-i)))&31)
+	}
+
+	var sb strings.Builder
+	sb.WriteString(AddressPrefix)
+	sb.WriteByte('1')
+	for _, v := range data {
+		sb.WriteByte(bech32Charset[v])
+	}
+	return sb.String()
+}
+
+// decodeAddress returns the bytes of the bech32 encoded account address, e.g. celestia1...
+// Only what's needed to compare signers is implemented, to avoid depending on the cosmos-sdk.
+func decodeAddress(addr string) ([]byte, error) {
+	if strings.ToLower(addr) != addr && strings.ToUpper(addr) != addr {
+		return nil, errors.New("mixed case address")
+	}
+	addr = strings.ToLower(addr)
+	sep := strings.LastIndexByte(addr, '1')
+	if sep < 1 || sep+7 > len(addr) {
+		return nil, errors.New("invalid bech32 address")
+	}
+
+	hrp := addr[:sep]
+	data := make([]byte, 0, len(addr)-sep-1)
+	for _, c := range addr[sep+1:] {
+		v := strings.IndexRune(bech32Charset, c)
+		if v < 0 {
+			return nil, fmt.Errorf("invalid bech32 character %q", c)
+		}
+		data = append(data, byte(v))
+	}
+	if bech32Polymod(append(bech32ExpandHRP(hrp), data...)) != 1 {
+		return nil, errors.New("invalid bech32 checksum")
+	}
+	return convertBits(data[:len(data)-6], 5, 8, false)
+}
+
+func bech32Polymod(values []byte) uint32 {
+	generator := [5]uint32{0x3b6a57b2, 0x26508e6d, 0x1ea119fa, 0x3d4233dd, 0x2a1462b3}
+	chk := uint32(1)
+	for _, v := range values {
+		top := chk >> 25
+		chk = (chk&0x1ffffff)<<5 ^ uint32(v)
+		for i := 0; i < 5; i++ {
+			if (top>>i)&1 == 1 {
+				chk ^= generator[i]
+			}
+		}
+	}
+	return chk
+}
+
+func bech32ExpandHRP(hrp string) []byte {
+	expanded := make([]byte, 0, len(hrp)*2+1)
+	for i := 0; i < len(hrp); i++ {
+		expanded = append(expanded, hrp[i]>>5)
+	}
+	expanded = append(expanded, 0)
+	for i := 0; i < len(hrp); i++ {
+		expanded = append(expanded, hrp[i]&31)
+	}
+	return expanded
+}
+
+// convertBits regroups the bits of the data from groups of fromBits to groups of toBits.
+// With pad, the last group is padded with zeros, otherwise any non-zero padding is rejected.
+func convertBits(data []byte, fromBits, toBits uint, pad bool) ([]byte, error) {
+	var (
+		acc  uint32
+		bits uint
+		out  []byte
+	)
+	for _, v := range data {
+		acc = acc<<fromBits | uint32(v)
+		bits += fromBits
+		for bits >= toBits {
+			bits -= toBits
+			out = append(out, byte(acc>>bits)&(1<<toBits-1))
+		}
+	}
+	if pad {
+		if bits > 0 {
+			out = append(out, byte(acc<<(toBits-bits))&(1<<toBits-1))
+		}
+		return out, nil
+	}
+	if bits >= fromBits || (acc<<(toBits-bits))&(1<<toBits-1) != 0 {
+		return nil, errors.New("invalid bech32 padding")
+	}
+	return out, nil
+}
@@ -0,0 +1,86 @@
+package blob
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/celestiaorg/celestia-openrpc/types/appconsts"
+	"github.com/celestiaorg/celestia-openrpc/types/share"
+)
+
+// testSigner is the account encoded by testSignerAddress.
+var testSigner = []byte{1, 2, 3, 4, 5, 6, 7, 8, 9, 10, 11, 12, 13, 14, 15, 16, 17, 18, 19, 20}
+
+const (
+	testSignerAddress  = "celestia1qypqxpq9qcrsszg2pvxq6rs0zqg3yyc5wgawu3"
+	otherSignerAddress = "celestia142424242424242424242424242424242v52yp3"
+)
+
+func signedBlob(t *testing.T, data string) *Blob {
+	t.Helper()
+	namespace, err := share.NewBlobNamespaceV0([]byte{1, 2, 3, 4})
+	require.NoError(t, err)
+	b, err := NewBlobV1(namespace, []byte(data), testSigner)
+	require.NoError(t, err)
+	return b
+}
+
+func TestShareVersionOneBlob(t *testing.T) {
+	b := signedBlob(t, strings.Repeat("a", 1000))
+	require.Equal(t, testSigner, b.Signer())
+	require.NotEqual(t, testBlob(t, strings.Repeat("a", 1000)).Commitment, b.Commitment)
+
+	shares, err := BlobsToShares(b)
+	require.NoError(t, err)
+	length, err := b.Length()
+	require.NoError(t, err)
+	require.Len(t, shares, length)
+
+	// the signer follows the sequence length of the first share
+	start := appconsts.NamespaceSize + appconsts.ShareInfoBytes + appconsts.SequenceLenBytes
+	require.Equal(t, testSigner, []byte(shares[0][start:start+appconsts.SignerSize]))
+	appShare, err := share.NewShare(shares[0])
+	require.NoError(t, err)
+	signer, err := appShare.Signer()
+	require.NoError(t, err)
+	require.Equal(t, testSigner, signer)
+
+	parsed, err := ParseBlobs(append(shares, testShares(t, "unsigned")...))
+	require.NoError(t, err)
+	require.Len(t, parsed, 2)
+	require.Equal(t, b.Data, parsed[0].Data)
+	require.Equal(t, b.Commitment, parsed[0].Commitment)
+	require.Equal(t, testSigner, parsed[0].Signer())
+	require.Nil(t, parsed[1].Signer())
+
+	data, err := json.Marshal(b)
+	require.NoError(t, err)
+	var decoded Blob
+	require.NoError(t, json.Unmarshal(data, &decoded))
+	require.Equal(t, testSigner, decoded.Signer())
+
+	namespace := share.Namespace(b.Namespace().Bytes())
+	_, err = NewBlobV1(namespace, []byte("data"), testSigner[:10])
+	require.Error(t, err)
+}
+
+func TestValidateSigners(t *testing.T) {
+	blobs := []*Blob{testBlob(t, "unsigned"), signedBlob(t, "signed")}
+	require.NoError(t, ValidateSigners(blobs, testSignerAddress))
+	require.ErrorIs(t, ValidateSigners(blobs, otherSignerAddress), ErrSignerMismatch)
+	require.ErrorIs(t, ValidateSigners(blobs, ""), ErrSignerMismatch)
+	require.Error(t, ValidateSigners(blobs, testSignerAddress[:len(testSignerAddress)-1]+"q"))
+
+	// unsigned blobs can be submitted by any account
+	require.NoError(t, ValidateSigners(blobs[:1], ""))
+}
+
+func TestEncodeAddress(t *testing.T) {
+	require.Equal(t, testSignerAddress, EncodeAddress(testSigner))
+	addr, err := decodeAddress(testSignerAddress)
+	require.NoError(t, err)
+	require.Equal(t, testSigner, addr)
+}
@@ -0,0 +1,128 @@
+package blob
+
+import (
+	"context"
+	"errors"
+
+	"github.com/celestiaorg/celestia-openrpc/types/share"
+)
+
+// SubscribeFrom subscribes to the blobs of the namespace starting at fromHeight, which may
+// be in the past, e.g. to resume a rollup after a restart. The heights before the ones
+// delivered by the subscription are backfilled with GetAll, as are the heights the
+// subscription skips, so the channel delivers one response for every height in order.
+// The concurrency of the scans backfilling the heights is set by the options, other options
+// don't apply. If a height can't be backfilled, the channel is closed, so the consumer can
+// resume after the last height received. The channel is closed as well once ctx is done or
+// the subscription is closed.
+func SubscribeFrom(
+	ctx context.Context,
+	api *API,
+	namespace share.Namespace,
+	fromHeight uint64,
+	opts ...ScanOption,
+) (<-chan *SubscriptionResponse, error) {
+	if err := namespace.ValidateForBlob(); err != nil {
+		return nil, err
+	}
+	if fromHeight == 0 {
+		return nil, errors.New("blob: subscription must start at a height above zero")
+	}
+	cfg, err := newScanConfig(opts)
+	if err != nil {
+		return nil, err
+	}
+	scanOpts := []ScanOption{WithScanConcurrency(cfg.concurrency)}
+
+	ctx, cancel := context.WithCancel(ctx)
+	sub, err := api.Subscribe(ctx, namespace)
+	if err != nil {
+		cancel()
+		return nil, err
+	}
+
+	out := make(chan *SubscriptionResponse)
+	go func() {
+		defer close(out)
+		defer cancel()
+
+		next := fromHeight
+		for resp := range bufferResponses(ctx, sub) {
+			if resp.Height < next {
+				// delivered by a backfill already
+				continue
+			}
+			if resp.Height > next && !backfill(ctx, api, namespace, next, resp.Height-1, scanOpts, out) {
+				return
+			}
+			select {
+			case out <- resp:
+			case <-ctx.Done():
+				return
+			}
+			next = resp.Height + 1
+		}
+	}()
+	return out, nil
+}
+
+// backfill delivers the blobs of the namespace from fromHeight to toHeight, inclusive,
+// reporting whether all of them were delivered.
+func backfill(
+	ctx context.Context,
+	api *API,
+	namespace share.Namespace,
+	fromHeight, toHeight uint64,
+	opts []ScanOption,
+	out chan<- *SubscriptionResponse,
+) bool {
+	results, err := Scan(ctx, api, namespace, fromHeight, toHeight, opts...)
+	if err != nil {
+		return false
+	}
+	delivered := fromHeight
+	for res := range results {
+		if res.Err != nil {
+			return false
+		}
+		select {
+		case out <- &SubscriptionResponse{Blobs: res.Blobs, Height: res.Height}:
+		case <-ctx.Done():
+			return false
+		}
+		delivered = res.Height + 1
+	}
+	return delivered == toHeight+1
+}
+
+// bufferResponses queues the responses of the subscription while a backfill is delivered,
+// so the subscription isn't blocked by the consumer.
+func bufferResponses(ctx context.Context, sub <-chan *SubscriptionResponse) <-chan *SubscriptionResponse {
+	out := make(chan *SubscriptionResponse)
+	go func() {
+		defer close(out)
+		var queue []*SubscriptionResponse
+		for sub != nil || len(queue) > 0 {
+			// sending is only enabled while responses are queued
+			var send chan<- *SubscriptionResponse
+			var head *SubscriptionResponse
+			if len(queue) > 0 {
+				send, head = out, queue[0]
+			}
+
+			select {
+			case resp, ok := <-sub:
+				if !ok {
+					sub = nil
+					continue
+				}
+				queue = append(queue, resp)
+			case send <- head:
+				queue = queue[1:]
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+	return out
+}
@@ -0,0 +1,44 @@
+package blob
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/celestiaorg/celestia-openrpc/types/share"
+)
+
+func TestSubscribeFrom(t *testing.T) {
+	b := testBlob(t, "hello")
+	live := make(chan *SubscriptionResponse, 4)
+	api := &API{}
+	api.Subscribe = func(context.Context, share.Namespace) (<-chan *SubscriptionResponse, error) {
+		return live, nil
+	}
+	api.GetAll = func(_ context.Context, height uint64, _ []share.Namespace) ([]*Blob, error) {
+		if height%2 == 0 {
+			return nil, ErrBlobNotFound
+		}
+		return []*Blob{b}, nil
+	}
+
+	// the live stream starts at height 5, skips height 7 and repeats height 3
+	for _, height := range []uint64{5, 3, 6, 8} {
+		live <- &SubscriptionResponse{Blobs: []*Blob{b}, Height: height}
+	}
+	close(live)
+
+	sub, err := SubscribeFrom(context.Background(), api, share.Namespace(b.Namespace().Bytes()), 2)
+	require.NoError(t, err)
+	var heights []uint64
+	for resp := range sub {
+		heights = append(heights, resp.Height)
+		if resp.Height%2 == 0 && resp.Height < 5 {
+			require.Empty(t, resp.Blobs)
+		} else {
+			require.Equal(t, []*Blob{b}, resp.Blobs)
+		}
+	}
+	require.Equal(t, []uint64{2, 3, 4, 5, 6, 7, 8}, heights)
+}
@@ -11,6 +11,8 @@ import (
 	cmversion "github.com/cometbft/cometbft/proto/tendermint/version"
 
 	"github.com/celestiaorg/rsmt2d"
+
+	"github.com/celestiaorg/celestia-openrpc/types/appconsts"
 )
 
 // Header defines the structure of a Tendermint block header.
@@ -73,6 +75,10 @@ type CoreBlob struct {
 	// ShareVersion is the version of the share format that this blob should use
 	// when encoded into shares.
 	ShareVersion uint8
+
+	// Signer is the address of the account which paid for the blob. Only share
+	// version one blobs carry it, in their first share.
+	Signer []byte
 }
 
 // Address is hex bytes.
@@ -204,3 +210,47 @@ func (dah *DataAvailabilityHeader) Hash() []byte {
 	dah.hash = merkle.HashFromByteSlices(slices)
 	return dah.hash
 }
+
+// MinNamespace returns the minimum namespace of the shares of the row, as committed to by
+// the root of the row, or nil if the row isn't in the square.
+func (dah *DataAvailabilityHeader) MinNamespace(row int) []byte {
+	if !dah.hasRow(row) {
+		return nil
+	}
+	return dah.RowRoots[row][:appconsts.NamespaceSize]
+}
+
+// MaxNamespace returns the maximum namespace of the shares of the row, as committed to by
+// the root of the row, or nil if the row isn't in the square.
+func (dah *DataAvailabilityHeader) MaxNamespace(row int) []byte {
+	if !dah.hasRow(row) {
+		return nil
+	}
+	return dah.RowRoots[row][appconsts.NamespaceSize : 2*appconsts.NamespaceSize]
+}
+
+// RowsWithNamespace returns the indexes of the rows whose range of namespaces includes the
+// namespace, in ascending order. The shares of the namespace can only be in those rows, and
+// there are none in the square if no row is returned.
+func (dah *DataAvailabilityHeader) RowsWithNamespace(namespace []byte) []int {
+	var rows []int
+	for row := range dah.RowRoots {
+		if dah.hasRow(row) &&
+			bytes.Compare(dah.MinNamespace(row), namespace) <= 0 &&
+			bytes.Compare(namespace, dah.MaxNamespace(row)) <= 0 {
+			rows = append(rows, row)
+		}
+	}
+	return rows
+}
+
+// MayContainNamespace reports whether the square may hold shares of the namespace, i.e.
+// whether the namespace is within the range of namespaces of any row.
+func (dah *DataAvailabilityHeader) MayContainNamespace(namespace []byte) bool {
+	return len(dah.RowsWithNamespace(namespace)) > 0
+}
+
+// hasRow reports whether the row has a root holding its range of namespaces.
+func (dah *DataAvailabilityHeader) hasRow(row int) bool {
+	return dah != nil && row >= 0 && row < len(dah.RowRoots) && len(dah.RowRoots[row]) >= 2*appconsts.NamespaceSize
+}
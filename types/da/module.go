@@ -0,0 +1,81 @@
+package da
+
+import (
+	"context"
+)
+
+// Module is the interface of the da module. Use Wrap to obtain it from an API
+// and NewAPI to turn an implementation back into an API.
+type Module interface {
+	// MaxBlobSize returns the max blob size
+	MaxBlobSize(ctx context.Context) (uint64, error)
+	// Get returns Blob for each given ID, or an error.
+	//
+	// Error should be returned if ID is not formatted properly, there is no Blob for given ID or any other client-level
+	// error occurred (dropped connection, timeout, etc).
+	Get(ctx context.Context, ids []ID, ns Namespace) ([]Blob, error)
+	// GetIDs returns IDs of all Blobs located in DA at given height.
+	GetIDs(ctx context.Context, height uint64, ns Namespace) ([]ID, error)
+	// GetProofs returns inclusion Proofs for all Blobs located in DA at given height.
+	GetProofs(ctx context.Context, ids []ID, ns Namespace) ([]Proof, error)
+	// Commit creates a Commitment for each given Blob.
+	Commit(ctx context.Context, blobs []Blob, ns Namespace) ([]Commitment, error)
+	// Validate validates Commitments against the corresponding Proofs. This should be possible without retrieving the Blobs.
+	Validate(ctx context.Context, ids []ID, proofs []Proof, ns Namespace) ([]bool, error)
+	// Submit submits the Blobs to Data Availability layer.
+	//
+	// This method is synchronous. Upon successful submission to Data Availability layer, it returns the IDs identifying blobs
+	// in DA.
+	Submit(ctx context.Context, blobs []Blob, gasPrice float64, ns Namespace) ([]ID, error)
+}
+
+// Wrap returns the API as Module.
+func Wrap(api *API) Module {
+	return module{API: api}
+}
+
+// NewAPI returns an API whose methods call the ones of the module.
+func NewAPI(m Module) API {
+	return API{
+		MaxBlobSize: m.MaxBlobSize,
+		Get:         m.Get,
+		GetIDs:      m.GetIDs,
+		GetProofs:   m.GetProofs,
+		Commit:      m.Commit,
+		Validate:    m.Validate,
+		Submit:      m.Submit,
+	}
+}
+
+// module adapts API to Module.
+type module struct {
+	*API
+}
+
+func (m module) MaxBlobSize(ctx context.Context) (uint64, error) {
+	return m.API.MaxBlobSize(ctx)
+}
+
+func (m module) Get(ctx context.Context, ids []ID, ns Namespace) ([]Blob, error) {
+	return m.API.Get(ctx, ids, ns)
+}
+
+func (m module) GetIDs(ctx context.Context, height uint64, ns Namespace) ([]ID, error) {
+	return m.API.GetIDs(ctx, height, ns)
+}
+
+func (m module) GetProofs(ctx context.Context, ids []ID, ns Namespace) ([]Proof, error) {
+	return m.API.GetProofs(ctx, ids, ns)
+}
+
+func (m module) Commit(ctx context.Context, blobs []Blob, ns Namespace) ([]Commitment, error) {
+	return m.API.Commit(ctx, blobs, ns)
+}
+
+func (m module) Validate(ctx context.Context, ids []ID, proofs []Proof, ns Namespace) ([]bool, error) {
+	return m.API.Validate(ctx, ids, proofs, ns)
+}
+
+func (m module) Submit(ctx context.Context, blobs []Blob, gasPrice float64, ns Namespace) ([]ID, error) {
+	return m.API.Submit(ctx, blobs, gasPrice, ns)
+}
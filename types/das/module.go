@@ -0,0 +1,38 @@
+package das
+
+import (
+	"context"
+)
+
+// Module is the interface of the das module. Use Wrap to obtain it from an API
+// and NewAPI to turn an implementation back into an API.
+type Module interface {
+	SamplingStats(ctx context.Context) (SamplingStats, error)
+	WaitCatchUp(ctx context.Context) error
+}
+
+// Wrap returns the API as Module.
+func Wrap(api *API) Module {
+	return module{API: api}
+}
+
+// NewAPI returns an API whose methods call the ones of the module.
+func NewAPI(m Module) API {
+	return API{
+		SamplingStats: m.SamplingStats,
+		WaitCatchUp:   m.WaitCatchUp,
+	}
+}
+
+// module adapts API to Module.
+type module struct {
+	*API
+}
+
+func (m module) SamplingStats(ctx context.Context) (SamplingStats, error) {
+	return m.API.SamplingStats(ctx)
+}
+
+func (m module) WaitCatchUp(ctx context.Context) error {
+	return m.API.WaitCatchUp(ctx)
+}
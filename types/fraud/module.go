@@ -0,0 +1,42 @@
+package fraud
+
+import (
+	"context"
+
+	"github.com/celestiaorg/go-fraud"
+)
+
+// Module is the interface of the fraud module. Use Wrap to obtain it from an API
+// and NewAPI to turn an implementation back into an API.
+type Module interface {
+	// Subscribe allows to subscribe on a Proof pub sub topic by its type.
+	Subscribe(context.Context, fraud.ProofType) (<-chan *Proof, error)
+	// Get fetches fraud proofs from the disk by its type.
+	Get(context.Context, fraud.ProofType) ([]Proof, error)
+}
+
+// Wrap returns the API as Module.
+func Wrap(api *API) Module {
+	return module{API: api}
+}
+
+// NewAPI returns an API whose methods call the ones of the module.
+func NewAPI(m Module) API {
+	return API{
+		Subscribe: m.Subscribe,
+		Get:       m.Get,
+	}
+}
+
+// module adapts API to Module.
+type module struct {
+	*API
+}
+
+func (m module) Subscribe(ctx context.Context, proofType fraud.ProofType) (<-chan *Proof, error) {
+	return m.API.Subscribe(ctx, proofType)
+}
+
+func (m module) Get(ctx context.Context, proofType fraud.ProofType) ([]Proof, error) {
+	return m.API.Get(ctx, proofType)
+}
@@ -0,0 +1,96 @@
+package header
+
+import (
+	"context"
+
+	libhead "github.com/celestiaorg/go-header"
+	"github.com/celestiaorg/go-header/sync"
+)
+
+// Module is the interface of the header module. Use Wrap to obtain it from an API
+// and NewAPI to turn an implementation back into an API.
+type Module interface {
+	// LocalHead returns the ExtendedHeader of the chain head.
+	LocalHead(context.Context) (*ExtendedHeader, error)
+	// GetByHash returns the header of the given hash from the node's header store.
+	GetByHash(ctx context.Context, hash libhead.Hash) (*ExtendedHeader, error)
+	// GetRangeByHeight returns the given range (from:to) of ExtendedHeaders
+	// from the node's header store and verifies that the returned headers are
+	// adjacent to each other.
+	GetRangeByHeight(context.Context, *ExtendedHeader, uint64) ([]*ExtendedHeader, error)
+	// GetByHeight returns the ExtendedHeader at the given height if it is
+	// currently available.
+	GetByHeight(context.Context, uint64) (*ExtendedHeader, error)
+	// WaitForHeight blocks until the header at the given height has been processed
+	// by the store or context deadline is exceeded.
+	WaitForHeight(context.Context, uint64) (*ExtendedHeader, error)
+	// SyncState returns the current state of the header Syncer.
+	SyncState(ctx context.Context) (sync.State, error)
+	// SyncWait blocks until the header Syncer is synced to network head.
+	SyncWait(ctx context.Context) error
+	// NetworkHead provides the Syncer's view of the current network head.
+	NetworkHead(ctx context.Context) (*ExtendedHeader, error)
+	// Subscribe to recent ExtendedHeaders from the network.
+	Subscribe(ctx context.Context) (<-chan *ExtendedHeader, error)
+}
+
+// Wrap returns the API as Module.
+func Wrap(api *API) Module {
+	return module{API: api}
+}
+
+// NewAPI returns an API whose methods call the ones of the module.
+func NewAPI(m Module) API {
+	return API{
+		LocalHead:        m.LocalHead,
+		GetByHash:        m.GetByHash,
+		GetRangeByHeight: m.GetRangeByHeight,
+		GetByHeight:      m.GetByHeight,
+		WaitForHeight:    m.WaitForHeight,
+		SyncState:        m.SyncState,
+		SyncWait:         m.SyncWait,
+		NetworkHead:      m.NetworkHead,
+		Subscribe:        m.Subscribe,
+	}
+}
+
+// module adapts API to Module.
+type module struct {
+	*API
+}
+
+func (m module) LocalHead(ctx context.Context) (*ExtendedHeader, error) {
+	return m.API.LocalHead(ctx)
+}
+
+func (m module) GetByHash(ctx context.Context, hash libhead.Hash) (*ExtendedHeader, error) {
+	return m.API.GetByHash(ctx, hash)
+}
+
+func (m module) GetRangeByHeight(ctx context.Context, extendedHeader *ExtendedHeader, height uint64) ([]*ExtendedHeader, error) {
+	return m.API.GetRangeByHeight(ctx, extendedHeader, height)
+}
+
+func (m module) GetByHeight(ctx context.Context, height uint64) (*ExtendedHeader, error) {
+	return m.API.GetByHeight(ctx, height)
+}
+
+func (m module) WaitForHeight(ctx context.Context, height uint64) (*ExtendedHeader, error) {
+	return m.API.WaitForHeight(ctx, height)
+}
+
+func (m module) SyncState(ctx context.Context) (sync.State, error) {
+	return m.API.SyncState(ctx)
+}
+
+func (m module) SyncWait(ctx context.Context) error {
+	return m.API.SyncWait(ctx)
+}
+
+func (m module) NetworkHead(ctx context.Context) (*ExtendedHeader, error) {
+	return m.API.NetworkHead(ctx)
+}
+
+func (m module) Subscribe(ctx context.Context) (<-chan *ExtendedHeader, error) {
+	return m.API.Subscribe(ctx)
+}
@@ -0,0 +1,63 @@
+package node
+
+import (
+	"context"
+
+	"github.com/filecoin-project/go-jsonrpc/auth"
+)
+
+// Module is the interface of the node module. Use Wrap to obtain it from an API
+// and NewAPI to turn an implementation back into an API.
+type Module interface {
+	// Info returns administrative information about the node.
+	Info(context.Context) (Info, error)
+	// Ready returns true once the node's RPC is ready to accept requests.
+	Ready(context.Context) (bool, error)
+	// LogLevelSet sets the given component log level to the given level.
+	LogLevelSet(ctx context.Context, name, level string) error
+	// AuthVerify returns the permissions assigned to the given token.
+	AuthVerify(ctx context.Context, token string) ([]auth.Permission, error)
+	// AuthNew signs and returns a new token with the given permissions.
+	AuthNew(ctx context.Context, perms []auth.Permission) ([]byte, error)
+}
+
+// Wrap returns the API as Module.
+func Wrap(api *API) Module {
+	return module{API: api}
+}
+
+// NewAPI returns an API whose methods call the ones of the module.
+func NewAPI(m Module) API {
+	return API{
+		Info:        m.Info,
+		Ready:       m.Ready,
+		LogLevelSet: m.LogLevelSet,
+		AuthVerify:  m.AuthVerify,
+		AuthNew:     m.AuthNew,
+	}
+}
+
+// module adapts API to Module.
+type module struct {
+	*API
+}
+
+func (m module) Info(ctx context.Context) (Info, error) {
+	return m.API.Info(ctx)
+}
+
+func (m module) Ready(ctx context.Context) (bool, error) {
+	return m.API.Ready(ctx)
+}
+
+func (m module) LogLevelSet(ctx context.Context, name, level string) error {
+	return m.API.LogLevelSet(ctx, name, level)
+}
+
+func (m module) AuthVerify(ctx context.Context, token string) ([]auth.Permission, error) {
+	return m.API.AuthVerify(ctx, token)
+}
+
+func (m module) AuthNew(ctx context.Context, perms []auth.Permission) ([]byte, error) {
+	return m.API.AuthNew(ctx, perms)
+}
@@ -0,0 +1,170 @@
+package p2p
+
+import (
+	"context"
+
+	"github.com/libp2p/go-libp2p/core/metrics"
+	"github.com/libp2p/go-libp2p/core/network"
+	"github.com/libp2p/go-libp2p/core/peer"
+	"github.com/libp2p/go-libp2p/core/protocol"
+	rcmgr "github.com/libp2p/go-libp2p/p2p/host/resource-manager"
+)
+
+// Module is the interface of the p2p module. Use Wrap to obtain it from an API
+// and NewAPI to turn an implementation back into an API.
+type Module interface {
+	// Info returns address information about the host.
+	Info(context.Context) (peer.AddrInfo, error)
+	// Peers returns connected peers.
+	Peers(context.Context) ([]peer.ID, error)
+	// PeerInfo returns a small slice of information Peerstore has on the
+	// given peer.
+	PeerInfo(ctx context.Context, id peer.ID) (peer.AddrInfo, error)
+	// Connect ensures there is a connection between this host and the peer with
+	// given peer.
+	Connect(ctx context.Context, pi peer.AddrInfo) error
+	// ClosePeer closes the connection to a given peer.
+	ClosePeer(ctx context.Context, id peer.ID) error
+	// Connectedness returns a state signaling connection capabilities.
+	Connectedness(ctx context.Context, id peer.ID) (network.Connectedness, error)
+	// NATStatus returns the current NAT status.
+	NATStatus(context.Context) (network.Reachability, error)
+	// BlockPeer adds a peer to the set of blocked peers.
+	BlockPeer(ctx context.Context, p peer.ID) error
+	// UnblockPeer removes a peer from the set of blocked peers.
+	UnblockPeer(ctx context.Context, p peer.ID) error
+	// ListBlockedPeers returns a list of blocked peers.
+	ListBlockedPeers(context.Context) ([]peer.ID, error)
+	// Protect adds a peer to the list of peers who have a bidirectional
+	// peering agreement that they are protected from being trimmed, dropped
+	// or negatively scored.
+	Protect(ctx context.Context, id peer.ID, tag string) error
+	// Unprotect removes a peer from the list of peers who have a bidirectional
+	// peering agreement that they are protected from being trimmed, dropped
+	// or negatively scored, returning a bool representing whether the given
+	// peer is protected or not.
+	Unprotect(ctx context.Context, id peer.ID, tag string) (bool, error)
+	// IsProtected returns whether the given peer is protected.
+	IsProtected(ctx context.Context, id peer.ID, tag string) (bool, error)
+	// BandwidthStats returns a Stats struct with bandwidth metrics for all
+	// data sent/received by the local peer, regardless of protocol or remote
+	// peer IDs.
+	BandwidthStats(context.Context) (metrics.Stats, error)
+	// BandwidthForPeer returns a Stats struct with bandwidth metrics associated with the given peer.ID.
+	// The metrics returned include all traffic sent / received for the peer, regardless of protocol.
+	BandwidthForPeer(ctx context.Context, id peer.ID) (metrics.Stats, error)
+	// BandwidthForProtocol returns a Stats struct with bandwidth metrics associated with the given
+	// protocol.ID.
+	BandwidthForProtocol(ctx context.Context, proto protocol.ID) (metrics.Stats, error)
+	// ResourceState returns the state of the resource manager.
+	ResourceState(context.Context) (rcmgr.ResourceManagerStat, error)
+	// PubSubPeers returns the peer IDs of the peers joined on
+	// the given topic.
+	PubSubPeers(ctx context.Context, topic string) ([]peer.ID, error)
+}
+
+// Wrap returns the API as Module.
+func Wrap(api *API) Module {
+	return module{API: api}
+}
+
+// NewAPI returns an API whose methods call the ones of the module.
+func NewAPI(m Module) API {
+	return API{
+		Info:                 m.Info,
+		Peers:                m.Peers,
+		PeerInfo:             m.PeerInfo,
+		Connect:              m.Connect,
+		ClosePeer:            m.ClosePeer,
+		Connectedness:        m.Connectedness,
+		NATStatus:            m.NATStatus,
+		BlockPeer:            m.BlockPeer,
+		UnblockPeer:          m.UnblockPeer,
+		ListBlockedPeers:     m.ListBlockedPeers,
+		Protect:              m.Protect,
+		Unprotect:            m.Unprotect,
+		IsProtected:          m.IsProtected,
+		BandwidthStats:       m.BandwidthStats,
+		BandwidthForPeer:     m.BandwidthForPeer,
+		BandwidthForProtocol: m.BandwidthForProtocol,
+		ResourceState:        m.ResourceState,
+		PubSubPeers:          m.PubSubPeers,
+	}
+}
+
+// module adapts API to Module.
+type module struct {
+	*API
+}
+
+func (m module) Info(ctx context.Context) (peer.AddrInfo, error) {
+	return m.API.Info(ctx)
+}
+
+func (m module) Peers(ctx context.Context) ([]peer.ID, error) {
+	return m.API.Peers(ctx)
+}
+
+func (m module) PeerInfo(ctx context.Context, id peer.ID) (peer.AddrInfo, error) {
+	return m.API.PeerInfo(ctx, id)
+}
+
+func (m module) Connect(ctx context.Context, pi peer.AddrInfo) error {
+	return m.API.Connect(ctx, pi)
+}
+
+func (m module) ClosePeer(ctx context.Context, id peer.ID) error {
+	return m.API.ClosePeer(ctx, id)
+}
+
+func (m module) Connectedness(ctx context.Context, id peer.ID) (network.Connectedness, error) {
+	return m.API.Connectedness(ctx, id)
+}
+
+func (m module) NATStatus(ctx context.Context) (network.Reachability, error) {
+	return m.API.NATStatus(ctx)
+}
+
+func (m module) BlockPeer(ctx context.Context, p peer.ID) error {
+	return m.API.BlockPeer(ctx, p)
+}
+
+func (m module) UnblockPeer(ctx context.Context, p peer.ID) error {
+	return m.API.UnblockPeer(ctx, p)
+}
+
+func (m module) ListBlockedPeers(ctx context.Context) ([]peer.ID, error) {
+	return m.API.ListBlockedPeers(ctx)
+}
+
+func (m module) Protect(ctx context.Context, id peer.ID, tag string) error {
+	return m.API.Protect(ctx, id, tag)
+}
+
+func (m module) Unprotect(ctx context.Context, id peer.ID, tag string) (bool, error) {
+	return m.API.Unprotect(ctx, id, tag)
+}
+
+func (m module) IsProtected(ctx context.Context, id peer.ID, tag string) (bool, error) {
+	return m.API.IsProtected(ctx, id, tag)
+}
+
+func (m module) BandwidthStats(ctx context.Context) (metrics.Stats, error) {
+	return m.API.BandwidthStats(ctx)
+}
+
+func (m module) BandwidthForPeer(ctx context.Context, id peer.ID) (metrics.Stats, error) {
+	return m.API.BandwidthForPeer(ctx, id)
+}
+
+func (m module) BandwidthForProtocol(ctx context.Context, proto protocol.ID) (metrics.Stats, error) {
+	return m.API.BandwidthForProtocol(ctx, proto)
+}
+
+func (m module) ResourceState(ctx context.Context) (rcmgr.ResourceManagerStat, error) {
+	return m.API.ResourceState(ctx)
+}
+
+func (m module) PubSubPeers(ctx context.Context, topic string) ([]peer.ID, error) {
+	return m.API.PubSubPeers(ctx, topic)
+}
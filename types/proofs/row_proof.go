@@ -1,15 +1,53 @@
 package proofs
 
-import "github.com/celestiaorg/go-square/merkle"
+import (
+	"fmt"
+
+	cmbytes "github.com/cometbft/cometbft/libs/bytes"
+
+	"github.com/celestiaorg/go-square/merkle"
+)
 
 // RowProof is a Merkle proof that a set of rows exist in a Merkle tree with a
 // given data root.
 type RowProof struct {
 	// RowRoots are the roots of the rows being proven.
-	RowRoots []byte `json:"row_roots"`
+	RowRoots []cmbytes.HexBytes `json:"row_roots"`
 	// Proofs is a list of Merkle proofs where each proof proves that a row
 	// exists in a Merkle tree with a given data root.
 	Proofs   []*merkle.Proof `json:"proofs"`
 	StartRow uint32          `json:"start_row"`
 	EndRow   uint32          `json:"end_row"`
 }
+
+// Validate performs checks on the fields of this RowProof. Returns an error if
+// the proof isn't correctly constructed or it doesn't prove the rows to be in
+// the Merkle tree with the given data root.
+func (rp RowProof) Validate(root []byte) error {
+	if rp.EndRow < rp.StartRow || int(rp.EndRow-rp.StartRow+1) != len(rp.RowRoots) {
+		return fmt.Errorf("the number of rows %d must equal the number of row roots %d",
+			int(rp.EndRow)-int(rp.StartRow)+1, len(rp.RowRoots))
+	}
+	if len(rp.Proofs) != len(rp.RowRoots) {
+		return fmt.Errorf("the number of proofs %d must equal the number of row roots %d",
+			len(rp.Proofs), len(rp.RowRoots))
+	}
+	if !rp.VerifyProof(root) {
+		return fmt.Errorf("row proof failed to verify")
+	}
+	return nil
+}
+
+// VerifyProof reports whether the proofs prove the row roots to be in the
+// Merkle tree with the given data root.
+func (rp RowProof) VerifyProof(root []byte) bool {
+	if len(rp.Proofs) != len(rp.RowRoots) {
+		return false
+	}
+	for i, proof := range rp.Proofs {
+		if proof == nil || proof.Verify(root, rp.RowRoots[i]) != nil {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,177 @@
+package share
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+
+	"github.com/celestiaorg/nmt"
+	"github.com/celestiaorg/rsmt2d"
+
+	"github.com/celestiaorg/celestia-openrpc/types/appconsts"
+)
+
+const (
+	// nmtCodec is the multicodec of the nodes of namespaced Merkle trees.
+	nmtCodec = 0x7700
+	// nmtHashCode is the multihash code of the hashes of namespaced Merkle trees.
+	nmtHashCode = 0x7701
+	// nmtHashSize is the size of the hashes of namespaced Merkle trees: the minimum and
+	// maximum namespace of the subtree followed by its SHA-256 digest.
+	nmtHashSize = 2*appconsts.NamespaceSize + 32
+	// maxCARSectionSize bounds the sections read, the largest being the header of a
+	// square of the maximum size.
+	maxCARSectionSize = 1 << 20
+)
+
+// WriteEDS writes the extended data square to w in the CARv1 format celestia-node stores
+// squares in: a header with the row and column roots, followed by the shares of the four
+// quadrants of the square, each prefixed by its namespace. The inner nodes of the trees,
+// which celestia-node appends to serve them to peers, are left out, as ReadEDS and
+// celestia-node only read the shares back.
+func WriteEDS(w io.Writer, eds *rsmt2d.ExtendedDataSquare) error {
+	rowRoots, colRoots, err := computeRoots(eds)
+	if err != nil {
+		return err
+	}
+	if _, err := w.Write(carSection(carHeader(append(rowRoots, colRoots...)))); err != nil {
+		return err
+	}
+
+	hasher := nmt.NewNmtHasher(NewSHA256Hasher(), appconsts.NamespaceSize, true)
+	half := eds.Width() / 2
+	for quadrant := uint(0); quadrant < 4; quadrant++ {
+		rowOffset, colOffset := quadrant/2*half, quadrant%2*half
+		for row := uint(0); row < half; row++ {
+			for col := uint(0); col < half; col++ {
+				leaf := namespacedLeaf(eds.GetCell(row+rowOffset, col+colOffset), quadrant == 0)
+				hash, err := hasher.HashLeaf(leaf)
+				if err != nil {
+					return fmt.Errorf("hashing share (%d, %d): %w", row+rowOffset, col+colOffset, err)
+				}
+				if _, err := w.Write(carSection(nmtCID(hash), leaf)); err != nil {
+					return err
+				}
+			}
+		}
+	}
+	return nil
+}
+
+// ReadEDS reads an extended data square written by WriteEDS or stored by celestia-node
+// from r. Only the shares of the original square are read, the square is extended and
+// checked against the root, failing if the file isn't the one of the square.
+func ReadEDS(r io.Reader, root *Root) (*rsmt2d.ExtendedDataSquare, error) {
+	if root == nil || len(root.RowRoots) == 0 || len(root.RowRoots)%2 != 0 {
+		return nil, errors.New("root is not the one of an extended data square")
+	}
+	br := bufio.NewReader(r)
+	header, err := readCARSection(br)
+	if err != nil {
+		return nil, fmt.Errorf("reading header: %w", err)
+	}
+	roots := append(append([][]byte{}, root.RowRoots...), root.ColumnRoots...)
+	if !bytes.Equal(header, carHeader(roots)) {
+		return nil, errors.New("roots of the file don't match the root")
+	}
+
+	half := len(root.RowRoots) / 2
+	cidSize := len(nmtCID(make([]byte, nmtHashSize)))
+	shares := make([][]byte, 0, half*half)
+	for len(shares) < half*half {
+		section, err := readCARSection(br)
+		if err != nil {
+			return nil, fmt.Errorf("reading share %d: %w", len(shares), err)
+		}
+		if len(section) != cidSize+appconsts.NamespaceSize+appconsts.ShareSize {
+			return nil, fmt.Errorf("share %d has %d bytes, expected a namespaced share", len(shares), len(section))
+		}
+		shares = append(shares, section[cidSize+appconsts.NamespaceSize:])
+	}
+
+	eds, err := rsmt2d.ComputeExtendedDataSquare(shares, appconsts.DefaultCodec(), NewConstructor(uint64(half)))
+	if err != nil {
+		return nil, fmt.Errorf("extending square: %w", err)
+	}
+	if err := checkRoot(eds, root); err != nil {
+		return nil, fmt.Errorf("square of the file: %w", err)
+	}
+	return eds, nil
+}
+
+// nmtCID returns the CIDv1 of the node of a namespaced Merkle tree with the hash.
+func nmtCID(hash []byte) []byte {
+	cid := binary.AppendUvarint(nil, 1)
+	cid = binary.AppendUvarint(cid, nmtCodec)
+	cid = binary.AppendUvarint(cid, nmtHashCode)
+	cid = binary.AppendUvarint(cid, uint64(len(hash)))
+	return append(cid, hash...)
+}
+
+// carHeader returns the DAG-CBOR encoding of the CARv1 header with the roots:
+// {"roots": [CIDs...], "version": 1}, with the keys in canonical order.
+func carHeader(roots [][]byte) []byte {
+	header := cborHead(5, 2)
+	header = append(header, cborHead(3, 5)...)
+	header = append(header, "roots"...)
+	header = append(header, cborHead(4, uint64(len(roots)))...)
+	for _, root := range roots {
+		// CIDs are byte strings tagged with 42 and prefixed by the identity multibase
+		cid := append([]byte{0}, nmtCID(root)...)
+		header = append(header, cborHead(6, 42)...)
+		header = append(header, cborHead(2, uint64(len(cid)))...)
+		header = append(header, cid...)
+	}
+	header = append(header, cborHead(3, 7)...)
+	header = append(header, "version"...)
+	return append(header, cborHead(0, 1)...)
+}
+
+// carSection returns the section made of the parts, prefixed by its length.
+func carSection(parts ...[]byte) []byte {
+	var size int
+	for _, part := range parts {
+		size += len(part)
+	}
+	section := binary.AppendUvarint(make([]byte, 0, binary.MaxVarintLen64+size), uint64(size))
+	for _, part := range parts {
+		section = append(section, part...)
+	}
+	return section
+}
+
+// readCARSection reads the next length-prefixed section, returning it without the prefix.
+func readCARSection(r *bufio.Reader) ([]byte, error) {
+	size, err := binary.ReadUvarint(r)
+	if err != nil {
+		return nil, err
+	}
+	if size > maxCARSectionSize {
+		return nil, fmt.Errorf("section of %d bytes is too large", size)
+	}
+	section := make([]byte, size)
+	if _, err := io.ReadFull(r, section); err != nil {
+		return nil, err
+	}
+	return section, nil
+}
+
+// cborHead returns the head of a CBOR data item of the major type with the argument.
+func cborHead(major byte, arg uint64) []byte {
+	major <<= 5
+	switch {
+	case arg < 24:
+		return []byte{major | byte(arg)}
+	case arg <= 0xff:
+		return []byte{major | 24, byte(arg)}
+	case arg <= 0xffff:
+		return binary.BigEndian.AppendUint16([]byte{major | 25}, uint16(arg))
+	case arg <= 0xffffffff:
+		return binary.BigEndian.AppendUint32([]byte{major | 26}, uint32(arg))
+	default:
+		return binary.BigEndian.AppendUint64([]byte{major | 27}, arg)
+	}
+}
@@ -0,0 +1,61 @@
+package share
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/celestiaorg/rsmt2d"
+	"github.com/stretchr/testify/require"
+
+	"github.com/celestiaorg/celestia-openrpc/types/appconsts"
+	"github.com/celestiaorg/celestia-openrpc/types/core"
+)
+
+// testBlobShares returns the sparse shares of a blob of the namespace {1, 2, 3, 4} holding the data.
+func testBlobShares(t *testing.T, data string) []AppShare {
+	t.Helper()
+	namespace, err := NewBlobNamespaceV0([]byte{1, 2, 3, 4})
+	require.NoError(t, err)
+	shares, err := SplitBlobs(core.CoreBlob{
+		NamespaceVersion: namespace.Version(),
+		NamespaceID:      namespace.ID(),
+		Data:             []byte(data),
+		ShareVersion:     appconsts.ShareVersionZero,
+	})
+	require.NoError(t, err)
+	return shares
+}
+
+// testSquare returns the extended data square of a block holding a blob of four shares,
+// along with its root.
+func testSquare(t *testing.T) (*rsmt2d.ExtendedDataSquare, *Root) {
+	t.Helper()
+	shares := testBlobShares(t, strings.Repeat("x", 1500))
+	require.Len(t, shares, 4)
+	eds, err := rsmt2d.ComputeExtendedDataSquare(ToBytes(shares), appconsts.DefaultCodec(), NewConstructor(2))
+	require.NoError(t, err)
+	root, err := core.NewDataAvailabilityHeader(eds)
+	require.NoError(t, err)
+	return eds, &root
+}
+
+func TestEDSCAR(t *testing.T) {
+	eds, root := testSquare(t)
+	var buf bytes.Buffer
+	require.NoError(t, WriteEDS(&buf, eds))
+
+	read, err := ReadEDS(bytes.NewReader(buf.Bytes()), root)
+	require.NoError(t, err)
+	require.Equal(t, eds.Flattened(), read.Flattened())
+
+	// files of other squares are rejected
+	swapped := &Root{RowRoots: root.ColumnRoots, ColumnRoots: root.RowRoots}
+	_, err = ReadEDS(bytes.NewReader(buf.Bytes()), swapped)
+	require.ErrorContains(t, err, "roots of the file")
+
+	tampered := bytes.Clone(buf.Bytes())
+	tampered[bytes.Index(tampered, eds.GetCell(0, 1))+Size-1] ^= 0xff
+	_, err = ReadEDS(bytes.NewReader(tampered), root)
+	require.ErrorContains(t, err, "square of the file")
+}
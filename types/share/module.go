@@ -0,0 +1,59 @@
+package share
+
+import (
+	"context"
+
+	"github.com/celestiaorg/celestia-openrpc/types/header"
+	"github.com/celestiaorg/rsmt2d"
+)
+
+// Module is the interface of the share module. Use Wrap to obtain it from an API
+// and NewAPI to turn an implementation back into an API.
+type Module interface {
+	SharesAvailable(context.Context, *header.ExtendedHeader) error
+	GetShare(ctx context.Context, eh *header.ExtendedHeader, row, col int) (*Share, error)
+	GetEDS(ctx context.Context, eh *header.ExtendedHeader) (*rsmt2d.ExtendedDataSquare, error)
+	GetSharesByNamespace(ctx context.Context, eh *header.ExtendedHeader, namespace Namespace) (*NamespacedShares, error)
+	GetRange(ctx context.Context, height uint64, start, end int) (*GetRangeResult, error)
+}
+
+// Wrap returns the API as Module.
+func Wrap(api *API) Module {
+	return module{API: api}
+}
+
+// NewAPI returns an API whose methods call the ones of the module.
+func NewAPI(m Module) API {
+	return API{
+		SharesAvailable:      m.SharesAvailable,
+		GetShare:             m.GetShare,
+		GetEDS:               m.GetEDS,
+		GetSharesByNamespace: m.GetSharesByNamespace,
+		GetRange:             m.GetRange,
+	}
+}
+
+// module adapts API to Module.
+type module struct {
+	*API
+}
+
+func (m module) SharesAvailable(ctx context.Context, eh *header.ExtendedHeader) error {
+	return m.API.SharesAvailable(ctx, eh)
+}
+
+func (m module) GetShare(ctx context.Context, eh *header.ExtendedHeader, row, col int) (*Share, error) {
+	return m.API.GetShare(ctx, eh, row, col)
+}
+
+func (m module) GetEDS(ctx context.Context, eh *header.ExtendedHeader) (*rsmt2d.ExtendedDataSquare, error) {
+	return m.API.GetEDS(ctx, eh)
+}
+
+func (m module) GetSharesByNamespace(ctx context.Context, eh *header.ExtendedHeader, namespace Namespace) (*NamespacedShares, error) {
+	return m.API.GetSharesByNamespace(ctx, eh, namespace)
+}
+
+func (m module) GetRange(ctx context.Context, height uint64, start, end int) (*GetRangeResult, error) {
+	return m.API.GetRange(ctx, height, start, end)
+}
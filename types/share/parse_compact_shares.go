@@ -0,0 +1,131 @@
+package share
+
+import (
+	"encoding/binary"
+	"errors"
+	"fmt"
+
+	"github.com/celestiaorg/celestia-openrpc/types/appconsts"
+)
+
+// ParseCompactShares returns the units, e.g. transactions, of the sequence of compact shares
+// written by a CompactShareSplitter, without their length delimiters. The first share must
+// start the sequence. Data past the sequence length is padding and ignored, and if the shares
+// end before the sequence does, the unit cut short is left out.
+func ParseCompactShares(shares []AppShare) ([][]byte, error) {
+	if len(shares) == 0 {
+		return nil, nil
+	}
+	if err := validateCompactShares(shares); err != nil {
+		return nil, err
+	}
+	rawData, err := extractRawData(shares)
+	if err != nil {
+		return nil, err
+	}
+	return parseRawData(rawData)
+}
+
+// validateCompactShares checks that the shares are compact shares of a single namespace
+// with a supported share version, the first of them starting the sequence.
+func validateCompactShares(shares []AppShare) error {
+	seqStart, err := shares[0].IsSequenceStart()
+	if err != nil {
+		return err
+	}
+	if !seqStart {
+		return errors.New("first share is not the start of a sequence")
+	}
+	ns, err := shares[0].Namespace()
+	if err != nil {
+		return err
+	}
+	for i := range shares {
+		if err := shares[i].DoesSupportVersions(appconsts.SupportedShareVersions); err != nil {
+			return err
+		}
+		isCompact, err := shares[i].IsCompactShare()
+		if err != nil {
+			return err
+		}
+		if !isCompact {
+			return fmt.Errorf("share %d is not a compact share", i)
+		}
+		other, err := shares[i].Namespace()
+		if err != nil {
+			return err
+		}
+		if !other.Equals(ns) {
+			return fmt.Errorf("share %d is of namespace %x, not %x", i, other.Bytes(), ns.Bytes())
+		}
+		if i == 0 {
+			continue
+		}
+		start, err := shares[i].IsSequenceStart()
+		if err != nil {
+			return err
+		}
+		if start {
+			return fmt.Errorf("share %d starts another sequence", i)
+		}
+	}
+	return nil
+}
+
+// extractRawData returns the data of the sequence, starting with the first unit as located
+// by the reserved bytes of the first share and ending at the sequence length.
+func extractRawData(shares []AppShare) ([]byte, error) {
+	seqLen, err := shares[0].SequenceLen()
+	if err != nil {
+		return nil, err
+	}
+	first, err := shares[0].RawData()
+	if err != nil {
+		return nil, err
+	}
+	unitStart, err := shares[0].rawDataStartIndexUsingReserved()
+	if err != nil {
+		return nil, err
+	}
+	// the offset of the first unit within the data of the sequence
+	offset := unitStart - (len(shares[0].data) - len(first))
+	if offset < 0 || offset > len(first) {
+		return nil, fmt.Errorf("reserved bytes of the first share point at byte %d, outside of its data", unitStart)
+	}
+
+	rawData := append([]byte{}, first...)
+	for i := 1; i < len(shares); i++ {
+		raw, err := shares[i].RawData()
+		if err != nil {
+			return nil, err
+		}
+		rawData = append(rawData, raw...)
+	}
+	if uint64(len(rawData)) > uint64(seqLen) {
+		rawData = rawData[:seqLen]
+	}
+	if offset > len(rawData) {
+		return nil, fmt.Errorf("first unit starts past the sequence length %d", seqLen)
+	}
+	return rawData[offset:], nil
+}
+
+// parseRawData returns the units contained in the raw data by parsing the length delimiter
+// prefixed to each unit.
+func parseRawData(rawData []byte) ([][]byte, error) {
+	units := make([][]byte, 0)
+	for len(rawData) > 0 {
+		unitLen, n := binary.Uvarint(rawData)
+		if n < 0 {
+			return nil, fmt.Errorf("malformed length delimiter of unit %d", len(units))
+		}
+		// the rest of the data is padding, or holds part of the next unit only
+		if n == 0 || unitLen == 0 || unitLen > uint64(len(rawData)-n) {
+			break
+		}
+		rawData = rawData[n:]
+		units = append(units, rawData[:unitLen:unitLen])
+		rawData = rawData[unitLen:]
+	}
+	return units, nil
+}
@@ -0,0 +1,35 @@
+package share
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/celestiaorg/celestia-openrpc/types/appconsts"
+	appns "github.com/celestiaorg/celestia-openrpc/types/namespace"
+)
+
+func TestParseCompactShares(t *testing.T) {
+	txs := [][]byte{[]byte("tx"), bytes.Repeat([]byte{1}, 1000), []byte("last")}
+	splitter := NewCompactShareSplitter(appns.TxNamespace, appconsts.ShareVersionZero)
+	for _, tx := range txs {
+		require.NoError(t, splitter.WriteTx(tx))
+	}
+	shares, err := splitter.Export()
+	require.NoError(t, err)
+
+	parsed, err := ParseCompactShares(shares)
+	require.NoError(t, err)
+	require.Equal(t, txs, parsed)
+
+	// transactions cut short by the end of the shares are left out
+	parsed, err = ParseCompactShares(shares[:2])
+	require.NoError(t, err)
+	require.Equal(t, txs[:1], parsed)
+
+	_, err = ParseCompactShares(shares[1:])
+	require.ErrorContains(t, err, "not the start of a sequence")
+	_, err = ParseCompactShares(testBlobShares(t, "data"))
+	require.ErrorContains(t, err, "not a compact share")
+}
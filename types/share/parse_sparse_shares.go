@@ -0,0 +1,135 @@
+package share
+
+import (
+	"bytes"
+	"fmt"
+
+	"github.com/celestiaorg/celestia-openrpc/types/appconsts"
+)
+
+// Sequence is the data of a sequence of sparse shares, e.g. a blob.
+type Sequence struct {
+	// Namespace is the namespace of the shares of the sequence.
+	Namespace Namespace
+	// ShareVersion is the share version of the shares of the sequence.
+	ShareVersion uint8
+	// Signer is the signer written to the first share of the sequence, if any.
+	Signer []byte
+	// Data is the data of the sequence, without the padding past its sequence length.
+	Data []byte
+	// Shares is the range of the indexes of the shares occupied by the sequence.
+	Shares Range
+}
+
+// ParseSparseShares groups the sparse shares, e.g. the shares of a namespace or of the blobs of
+// a square, into the sequences they form. Every sequence begins with a share starting it, all
+// following shares up to the next start or padding share continuing it under the same namespace
+// and share version. Padding shares are skipped. Each sequence is checked to span as many shares
+// as its sequence length requires and to be followed by zeroed padding only.
+func ParseSparseShares(shares []AppShare) ([]Sequence, error) {
+	var (
+		sequences []Sequence
+		// seqLen is the sequence length of the last sequence, while it is open
+		seqLen uint32
+		open   bool
+	)
+	for i := range shares {
+		if err := shares[i].DoesSupportVersions(appconsts.SupportedShareVersions); err != nil {
+			return nil, fmt.Errorf("share %d: %w", i, err)
+		}
+		isCompact, err := shares[i].IsCompactShare()
+		if err != nil {
+			return nil, fmt.Errorf("share %d: %w", i, err)
+		}
+		if isCompact {
+			return nil, fmt.Errorf("share %d is not a sparse share", i)
+		}
+		padding, err := shares[i].IsPadding()
+		if err != nil {
+			return nil, fmt.Errorf("share %d: %w", i, err)
+		}
+		isStart, err := shares[i].IsSequenceStart()
+		if err != nil {
+			return nil, fmt.Errorf("share %d: %w", i, err)
+		}
+		if open && (padding || isStart) {
+			if err := closeSequence(&sequences[len(sequences)-1], seqLen); err != nil {
+				return nil, err
+			}
+			open = false
+		}
+		if padding {
+			continue
+		}
+
+		version, err := shares[i].Version()
+		if err != nil {
+			return nil, fmt.Errorf("share %d: %w", i, err)
+		}
+		rawData, err := shares[i].RawData()
+		if err != nil {
+			return nil, fmt.Errorf("share %d: %w", i, err)
+		}
+		namespace := GetNamespace(shares[i].ToBytes())
+		if isStart {
+			if seqLen, err = shares[i].SequenceLen(); err != nil {
+				return nil, fmt.Errorf("share %d: %w", i, err)
+			}
+			signer, err := shares[i].Signer()
+			if err != nil {
+				return nil, fmt.Errorf("share %d: %w", i, err)
+			}
+			sequences = append(sequences, Sequence{
+				Namespace:    append(Namespace{}, namespace...),
+				ShareVersion: version,
+				Signer:       append([]byte{}, signer...),
+				Data:         append([]byte{}, rawData...),
+				Shares:       NewRange(i, i+1),
+			})
+			open = true
+			continue
+		}
+
+		if !open {
+			return nil, fmt.Errorf("share %d continues no sequence", i)
+		}
+		seq := &sequences[len(sequences)-1]
+		if !bytes.Equal(namespace, seq.Namespace) {
+			return nil, fmt.Errorf("share %d is of namespace %x, not %x of its sequence", i, namespace, seq.Namespace)
+		}
+		if version != seq.ShareVersion {
+			return nil, fmt.Errorf("share %d is of share version %d, not %d of its sequence", i, version, seq.ShareVersion)
+		}
+		seq.Data = append(seq.Data, rawData...)
+		seq.Shares.End = i + 1
+	}
+	if open {
+		if err := closeSequence(&sequences[len(sequences)-1], seqLen); err != nil {
+			return nil, err
+		}
+	}
+	return sequences, nil
+}
+
+// closeSequence checks that the sequence spans the shares its sequence length requires, with
+// zeroed padding only past it, and trims the padding off its data.
+func closeSequence(seq *Sequence, seqLen uint32) error {
+	//nolint:gosec
+	sharesNeeded := SparseSharesNeeded(seqLen + uint32(len(seq.Signer)))
+	if got := seq.Shares.End - seq.Shares.Start; got != sharesNeeded {
+		return fmt.Errorf("sequence starting at share %d spans %d shares, %d expected",
+			seq.Shares.Start, got, sharesNeeded)
+	}
+	if uint64(len(seq.Data)) < uint64(seqLen) {
+		return fmt.Errorf("sequence starting at share %d holds %d bytes, %d expected",
+			seq.Shares.Start, len(seq.Data), seqLen)
+	}
+	for _, b := range seq.Data[seqLen:] {
+		if b != 0 {
+			return fmt.Errorf("sequence starting at share %d has non-zero bytes past its sequence length",
+				seq.Shares.Start)
+		}
+	}
+	seq.Data = seq.Data[:seqLen:seqLen]
+	return nil
+}
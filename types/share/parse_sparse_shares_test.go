@@ -0,0 +1,42 @@
+package share
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/celestiaorg/celestia-openrpc/types/appconsts"
+	appns "github.com/celestiaorg/celestia-openrpc/types/namespace"
+)
+
+func TestParseSparseShares(t *testing.T) {
+	small, large := testBlobShares(t, "small"), testBlobShares(t, strings.Repeat("l", 2000))
+	namespace, err := small[0].Namespace()
+	require.NoError(t, err)
+	padding, err := NamespacePaddingShare(namespace)
+	require.NoError(t, err)
+	shares := append(append([]AppShare{}, small...), padding)
+	shares = append(append(shares, large...), padding)
+
+	sequences, err := ParseSparseShares(shares)
+	require.NoError(t, err)
+	require.Len(t, sequences, 2)
+	require.Equal(t, NewRange(0, 1), sequences[0].Shares)
+	require.Equal(t, NewRange(2, 2+len(large)), sequences[1].Shares)
+	require.Equal(t, []byte("small"), sequences[0].Data)
+	require.Equal(t, []byte(strings.Repeat("l", 2000)), sequences[1].Data)
+	require.Equal(t, Namespace(namespace.Bytes()), sequences[0].Namespace)
+
+	_, err = ParseSparseShares(shares[3:])
+	require.ErrorContains(t, err, "continues no sequence")
+	_, err = ParseSparseShares(shares[2 : len(shares)-2])
+	require.ErrorContains(t, err, "spans")
+
+	txs := NewCompactShareSplitter(appns.TxNamespace, appconsts.ShareVersionZero)
+	require.NoError(t, txs.WriteTx([]byte("tx")))
+	compact, err := txs.Export()
+	require.NoError(t, err)
+	_, err = ParseSparseShares(compact)
+	require.ErrorContains(t, err, "not a sparse share")
+}
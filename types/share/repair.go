@@ -0,0 +1,54 @@
+package share
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+
+	"github.com/celestiaorg/rsmt2d"
+
+	"github.com/celestiaorg/celestia-openrpc/types/appconsts"
+)
+
+// RepairEDS reconstructs the extended data square committed to by the root from some of
+// its shares, given in row-major order with nil for the missing ones, e.g. as sampled from
+// the network. It fails if the shares are too few to recover the square, or if they are
+// inconsistent with the root, in which case the error wraps rsmt2d.ErrByzantineData.
+func RepairEDS(shares []Share, root *Root) (*rsmt2d.ExtendedDataSquare, error) {
+	if root == nil || len(root.RowRoots) == 0 || len(root.RowRoots)%2 != 0 {
+		return nil, errors.New("root is not the one of an extended data square")
+	}
+	width := len(root.RowRoots)
+	if len(shares) != width*width {
+		return nil, fmt.Errorf("got %d shares, a square of width %d has %d", len(shares), width, width*width)
+	}
+	for i, shr := range shares {
+		if shr != nil && len(shr) != appconsts.ShareSize {
+			return nil, fmt.Errorf("share %d has %d bytes, expected %d", i, len(shr), appconsts.ShareSize)
+		}
+	}
+
+	eds, err := rsmt2d.ImportExtendedDataSquare(shares, appconsts.DefaultCodec(), NewConstructor(uint64(width/2)))
+	if err != nil {
+		return nil, err
+	}
+	if err := eds.Repair(root.RowRoots, root.ColumnRoots); err != nil {
+		return nil, fmt.Errorf("repairing square: %w", err)
+	}
+	if err := checkRoot(eds, root); err != nil {
+		return nil, fmt.Errorf("repaired square: %w", err)
+	}
+	return eds, nil
+}
+
+// checkRoot checks that the extended data square is the one committed to by the root.
+func checkRoot(eds *rsmt2d.ExtendedDataSquare, root *Root) error {
+	computed, err := NewRoot(eds)
+	if err != nil {
+		return err
+	}
+	if !bytes.Equal(computed.Hash(), root.Hash()) {
+		return errors.New("doesn't match the root")
+	}
+	return nil
+}
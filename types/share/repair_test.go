@@ -0,0 +1,34 @@
+package share
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/celestiaorg/rsmt2d"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRepairEDS(t *testing.T) {
+	eds, root := testSquare(t)
+	shares := eds.Flattened()
+	for i := range shares[:4] {
+		shares[i] = nil
+	}
+	repaired, err := RepairEDS(shares, root)
+	require.NoError(t, err)
+	require.Equal(t, eds.Flattened(), repaired.Flattened())
+
+	// too few shares to recover the square
+	sparse := make([]Share, 16)
+	sparse[5] = eds.GetCell(1, 1)
+	_, err = RepairEDS(sparse, root)
+	require.ErrorIs(t, err, rsmt2d.ErrUnrepairableDataSquare)
+
+	// shares inconsistent with the root
+	shares = eds.Flattened()
+	shares[0] = nil
+	shares[1] = bytes.Clone(shares[1])
+	shares[1][Size-1] ^= 0xff
+	_, err = RepairEDS(shares, root)
+	require.Error(t, err)
+}
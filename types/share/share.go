@@ -259,8 +259,36 @@ func (s *AppShare) ToBytes() []byte {
 	return s.data
 }
 
+// Signer returns the signer carried by the first share of a share version one blob,
+// or nil for other shares.
+func (s *AppShare) Signer() ([]byte, error) {
+	hasSigner, err := s.hasSigner()
+	if err != nil || !hasSigner {
+		return nil, err
+	}
+	start := appconsts.NamespaceSize + appconsts.ShareInfoBytes + appconsts.SequenceLenBytes
+	return s.data[start : start+appconsts.SignerSize], nil
+}
+
+// hasSigner returns true if the share is the first share of a share version one blob.
+func (s *AppShare) hasSigner() (bool, error) {
+	isStart, err := s.IsSequenceStart()
+	if err != nil || !isStart {
+		return false, err
+	}
+	isCompact, err := s.IsCompactShare()
+	if err != nil || isCompact {
+		return false, err
+	}
+	version, err := s.Version()
+	if err != nil {
+		return false, err
+	}
+	return version == appconsts.ShareVersionOne, nil
+}
+
 // RawData returns the raw share data. The raw share data does not contain the
-// namespace ID, info byte, sequence length, or reserved bytes.
+// namespace ID, info byte, sequence length, signer, or reserved bytes.
 func (s *AppShare) RawData() (rawData []byte, err error) {
 	if len(s.data) < s.rawDataStartIndex() {
 		return rawData, fmt.Errorf("share %s is too short to contain raw data", s)
@@ -286,6 +314,11 @@ func (s *AppShare) rawDataStartIndex() int {
 	if isCompact {
 		index += appconsts.CompactShareReservedBytes
 	}
+	if hasSigner, err := s.hasSigner(); err != nil {
+		panic(err)
+	} else if hasSigner {
+		index += appconsts.SignerSize
+	}
 	return index
 }
 
@@ -89,9 +89,26 @@ func (b *Builder) IsEmptyShare() bool {
 	if b.isFirstShare {
 		expectedLen += appconsts.SequenceLenBytes
 	}
+	if b.hasSigner() {
+		expectedLen += appconsts.SignerSize
+	}
 	return len(b.rawShareData) == expectedLen
 }
 
+// WriteSigner writes the signer of a share version one blob after the sequence length of
+// its first share. It must be called right after WriteSequenceLen and is a no-op for other shares.
+func (b *Builder) WriteSigner(signer []byte) {
+	if !b.hasSigner() {
+		return
+	}
+	b.rawShareData = append(b.rawShareData, signer...)
+}
+
+// hasSigner returns true if the share carries the signer of its blob.
+func (b *Builder) hasSigner() bool {
+	return !b.isCompactShare && b.isFirstShare && b.shareVersion == appconsts.ShareVersionOne
+}
+
 func (b *Builder) ZeroPadIfNecessary() (bytesOfPadding int) {
 	b.rawShareData, bytesOfPadding = zeroPadIfNecessary(b.rawShareData, appconsts.ShareSize)
 	return bytesOfPadding
@@ -0,0 +1,39 @@
+package share
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/celestiaorg/celestia-openrpc/types/appconsts"
+	appns "github.com/celestiaorg/celestia-openrpc/types/namespace"
+)
+
+func TestCompactShareSplitter(t *testing.T) {
+	small, large := []byte("tx"), bytes.Repeat([]byte{1}, 1000)
+	splitter := NewCompactShareSplitter(appns.TxNamespace, appconsts.ShareVersionZero)
+	require.NoError(t, splitter.WriteTx(small))
+	require.NoError(t, splitter.WriteTx(large))
+	require.Equal(t, 3, splitter.Count())
+
+	shares, err := splitter.Export()
+	require.NoError(t, err)
+	require.Len(t, shares, 3)
+	seqLen, err := shares[0].SequenceLen()
+	require.NoError(t, err)
+	require.EqualValues(t, len(MarshalDelimitedTx(small))+len(MarshalDelimitedTx(large)), seqLen)
+
+	// the reserved bytes of the first share point at its first transaction
+	first := shares[0].ToBytes()
+	reserved := first[appconsts.NamespaceSize+appconsts.ShareInfoBytes+appconsts.SequenceLenBytes:][:appconsts.CompactShareReservedBytes]
+	index, err := ParseReservedBytes(reserved)
+	require.NoError(t, err)
+	require.EqualValues(t, appconsts.NamespaceSize+appconsts.ShareInfoBytes+appconsts.SequenceLenBytes+
+		appconsts.CompactShareReservedBytes, index)
+
+	ranges := splitter.ShareRanges(1)
+	require.Equal(t, NewRange(1, 2), ranges[sha256.Sum256(small)])
+	require.Equal(t, NewRange(1, 4), ranges[sha256.Sum256(large)])
+}
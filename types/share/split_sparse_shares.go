@@ -26,6 +26,9 @@ func (sss *SparseShareSplitter) Write(blob coretypes.CoreBlob) error {
 	if !slices.Contains(appconsts.SupportedShareVersions, blob.ShareVersion) {
 		return fmt.Errorf("unsupported share version: %d", blob.ShareVersion)
 	}
+	if err := validateSigner(blob); err != nil {
+		return err
+	}
 
 	rawData := blob.Data
 	blobNamespace, err := appns.New(blob.NamespaceVersion, blob.NamespaceID)
@@ -42,6 +45,7 @@ func (sss *SparseShareSplitter) Write(blob coretypes.CoreBlob) error {
 	if err := b.WriteSequenceLen(uint32(len(rawData))); err != nil {
 		return err
 	}
+	b.WriteSigner(blob.Signer)
 
 	for rawData != nil {
 
@@ -67,6 +71,18 @@ func (sss *SparseShareSplitter) Write(blob coretypes.CoreBlob) error {
 	return nil
 }
 
+// validateSigner checks that share version one blobs carry a signer, and only those.
+func validateSigner(blob coretypes.CoreBlob) error {
+	if blob.ShareVersion == appconsts.ShareVersionOne && len(blob.Signer) != appconsts.SignerSize {
+		return fmt.Errorf("share version 1 blobs require a signer of %d bytes, got %d",
+			appconsts.SignerSize, len(blob.Signer))
+	}
+	if blob.ShareVersion != appconsts.ShareVersionOne && len(blob.Signer) != 0 {
+		return fmt.Errorf("share version %d blobs can't carry a signer", blob.ShareVersion)
+	}
+	return nil
+}
+
 // WriteNamespacePaddingShares adds padding shares with the namespace of the
 // last written  This is useful to follow the non-interactive default
 // rules. This function assumes that at least one share has already been
@@ -0,0 +1,108 @@
+package share
+
+import (
+	"fmt"
+
+	"github.com/celestiaorg/nmt"
+	"github.com/celestiaorg/rsmt2d"
+
+	"github.com/celestiaorg/celestia-openrpc/types/appconsts"
+)
+
+// NewConstructor returns the constructor of the trees committing to the rows and columns
+// of extended data squares whose original square has the given width, as celestia-app
+// builds them. Shares outside of the original square are pushed under the parity
+// namespace.
+func NewConstructor(squareSize uint64) rsmt2d.TreeConstructorFn {
+	return func(_ rsmt2d.Axis, axisIndex uint) rsmt2d.Tree {
+		return newErasuredTree(squareSize, axisIndex)
+	}
+}
+
+// erasuredTree is a namespaced Merkle tree of a row or column of an extended data square.
+type erasuredTree struct {
+	squareSize uint64
+	axisIndex  uint64
+	shareIndex uint64
+	tree       *nmt.NamespacedMerkleTree
+}
+
+func newErasuredTree(squareSize uint64, axisIndex uint) *erasuredTree {
+	return &erasuredTree{
+		squareSize: squareSize,
+		axisIndex:  uint64(axisIndex),
+		tree: nmt.New(NewSHA256Hasher(),
+			nmt.NamespaceIDSize(appconsts.NamespaceSize), nmt.IgnoreMaxNamespace(true)),
+	}
+}
+
+// Push implements rsmt2d.Tree, prefixing the share with its namespace.
+func (t *erasuredTree) Push(data []byte) error {
+	if t.axisIndex >= 2*t.squareSize || t.shareIndex >= 2*t.squareSize {
+		return fmt.Errorf("pushed past the square of width %d: axis %d, share %d",
+			2*t.squareSize, t.axisIndex, t.shareIndex)
+	}
+	if len(data) < appconsts.NamespaceSize {
+		return fmt.Errorf("share of %d bytes is too short to contain a namespace", len(data))
+	}
+	if err := t.tree.Push(namespacedLeaf(data, t.axisIndex < t.squareSize && t.shareIndex < t.squareSize)); err != nil {
+		return err
+	}
+	t.shareIndex++
+	return nil
+}
+
+// Root implements rsmt2d.Tree.
+func (t *erasuredTree) Root() ([]byte, error) {
+	return t.tree.Root()
+}
+
+// namespacedLeaf returns the share prefixed by its namespace, or by the parity namespace
+// if the share isn't part of the original square.
+func namespacedLeaf(shr Share, original bool) []byte {
+	namespace := ParitySharesNamespace
+	if original {
+		namespace = GetNamespace(shr)
+	}
+	return append(append(make([]byte, 0, len(namespace)+len(shr)), namespace...), shr...)
+}
+
+// NewRoot computes the root committing to the extended data square, e.g. to check a square
+// retrieved from a node against the header of its block. Unlike NewDataAvailabilityHeader,
+// the trees are always built as celestia-app builds them, regardless of the tree constructor
+// the square was created with, so squares decoded from JSON are committed to correctly.
+func NewRoot(eds *rsmt2d.ExtendedDataSquare) (*Root, error) {
+	rowRoots, colRoots, err := computeRoots(eds)
+	if err != nil {
+		return nil, err
+	}
+	root := &Root{RowRoots: rowRoots, ColumnRoots: colRoots}
+	// memoizes the data hash
+	root.Hash()
+	return root, nil
+}
+
+// computeRoots returns the roots of the rows and columns of the extended data square.
+func computeRoots(eds *rsmt2d.ExtendedDataSquare) (rowRoots, colRoots [][]byte, err error) {
+	width := eds.Width()
+	rowRoots, colRoots = make([][]byte, width), make([][]byte, width)
+	for i := uint(0); i < width; i++ {
+		if rowRoots[i], err = axisRoot(eds.Row(i), width/2, i); err != nil {
+			return nil, nil, fmt.Errorf("computing root of row %d: %w", i, err)
+		}
+		if colRoots[i], err = axisRoot(eds.Col(i), width/2, i); err != nil {
+			return nil, nil, fmt.Errorf("computing root of column %d: %w", i, err)
+		}
+	}
+	return rowRoots, colRoots, nil
+}
+
+func axisRoot(shares [][]byte, squareSize, axisIndex uint) ([]byte, error) {
+	tree := newErasuredTree(uint64(squareSize), axisIndex)
+	for _, shr := range shares {
+		if err := tree.Push(shr); err != nil {
+			return nil, err
+		}
+	}
+	return tree.Root()
+}
@@ -0,0 +1,24 @@
+package share
+
+import (
+	"testing"
+
+	"github.com/celestiaorg/rsmt2d"
+	"github.com/stretchr/testify/require"
+
+	"github.com/celestiaorg/celestia-openrpc/types/appconsts"
+)
+
+func TestNewRoot(t *testing.T) {
+	eds, root := testSquare(t)
+	computed, err := NewRoot(eds)
+	require.NoError(t, err)
+	require.True(t, computed.Equals(root))
+
+	// squares decoded from JSON are created with the default tree constructor
+	decoded, err := rsmt2d.ImportExtendedDataSquare(eds.Flattened(), appconsts.DefaultCodec(), rsmt2d.NewDefaultTree)
+	require.NoError(t, err)
+	computed, err = NewRoot(decoded)
+	require.NoError(t, err)
+	require.True(t, computed.Equals(root))
+}
@@ -0,0 +1,112 @@
+package share
+
+import (
+	"bytes"
+	"fmt"
+)
+
+// Verify checks that the namespaced shares are all the shares of the namespace within
+// the square committed to by the root, using the proofs of the rows they are in. Every row
+// whose namespace range includes the namespace must be present, with an absence proof if
+// the namespace has no shares in it, so rows omitted by the node are detected.
+func (ns NamespacedShares) Verify(root *Root, namespace Namespace) error {
+	if root == nil {
+		return fmt.Errorf("no root to verify against")
+	}
+	rows := root.RowsWithNamespace(namespace)
+	if len(rows) != len(ns) {
+		return fmt.Errorf("amount of rows differs between root and namespace shares: expected %d, got %d",
+			len(rows), len(ns))
+	}
+	for i, row := range ns {
+		if !row.verify(root.RowRoots[rows[i]], namespace) {
+			return fmt.Errorf("row verification failed: row %d doesn't match root: %s", rows[i], root.String())
+		}
+	}
+	return nil
+}
+
+// Flatten returns the shares of all the rows.
+func (ns NamespacedShares) Flatten() []Share {
+	var shares []Share
+	for _, row := range ns {
+		shares = append(shares, row.Shares...)
+	}
+	return shares
+}
+
+// verify checks the shares of the row against its root.
+func (row NamespacedRow) verify(rowRoot []byte, namespace Namespace) bool {
+	if row.Proof == nil {
+		return false
+	}
+	leaves := make([][]byte, len(row.Shares))
+	for i, shr := range row.Shares {
+		// leaves of the tree are prefixed with the namespace of the share
+		leaves[i] = append(append([]byte{}, GetNamespace(shr)...), shr...)
+	}
+	return row.Proof.VerifyNamespace(NewSHA256Hasher(), namespace.ToNMT(), leaves, rowRoot)
+}
+
+// Verify checks that the shares of the range are shares of the namespace in the square
+// committed to by the data root, e.g. the DataHash of a verified header. The NMT proofs
+// prove the shares against the roots of the rows they are in, which the row proof proves
+// against the data root.
+func (r *GetRangeResult) Verify(dataRoot []byte, namespace Namespace) error {
+	if r.Proof == nil {
+		return fmt.Errorf("range has no proof")
+	}
+	if len(r.Shares) != len(r.Proof.Data) {
+		return fmt.Errorf("amount of shares differs between range and proof: expected %d, got %d",
+			len(r.Proof.Data), len(r.Shares))
+	}
+	for i := range r.Shares {
+		if !bytes.Equal(r.Shares[i], r.Proof.Data[i]) {
+			return fmt.Errorf("share %d of range differs from the proven one", i)
+		}
+	}
+	return r.Proof.verify(dataRoot, namespace)
+}
+
+// verify checks the proven shares against the row roots of the row proof, and the row
+// roots against the data root.
+func (p *ShareProof) verify(dataRoot []byte, namespace Namespace) error {
+	if p.NamespaceVersion > 0xff {
+		return fmt.Errorf("invalid namespace version %d", p.NamespaceVersion)
+	}
+	proven := append(Namespace{byte(p.NamespaceVersion)}, p.NamespaceID...)
+	if !proven.Equals(namespace) {
+		return fmt.Errorf("proof is for namespace %s, not %s", proven, namespace)
+	}
+	if err := p.RowProof.Validate(dataRoot); err != nil {
+		return err
+	}
+	if len(p.ShareProofs) != len(p.RowProof.RowRoots) {
+		return fmt.Errorf("the number of share proofs %d must equal the number of row roots %d",
+			len(p.ShareProofs), len(p.RowProof.RowRoots))
+	}
+
+	cursor := 0
+	for i, proof := range p.ShareProofs {
+		row := int(p.RowProof.StartRow) + i
+		if proof == nil {
+			return fmt.Errorf("row %d has no proof", row)
+		}
+		if p.RowProof.Proofs[i].Index != int64(row) {
+			return fmt.Errorf("row proof %d is for row %d, not %d", i, p.RowProof.Proofs[i].Index, row)
+		}
+		sharesUsed := proof.End() - proof.Start()
+		if sharesUsed <= 0 || cursor+sharesUsed > len(p.Data) {
+			return fmt.Errorf("proof of row %d covers %d shares, %d are left", row, sharesUsed, len(p.Data)-cursor)
+		}
+		if !proof.VerifyInclusion(NewSHA256Hasher(), namespace.ToNMT(), p.Data[cursor:cursor+sharesUsed],
+			p.RowProof.RowRoots[i]) {
+			return fmt.Errorf("row verification failed: row %d doesn't match its root %s", row, p.RowProof.RowRoots[i])
+		}
+		cursor += sharesUsed
+	}
+	if cursor != len(p.Data) {
+		return fmt.Errorf("proofs cover %d of %d shares", cursor, len(p.Data))
+	}
+	return nil
+}
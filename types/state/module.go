@@ -0,0 +1,133 @@
+package state
+
+import (
+	"context"
+
+	"github.com/celestiaorg/celestia-openrpc/types/blob"
+)
+
+// Module is the interface of the state module. Use Wrap to obtain it from an API
+// and NewAPI to turn an implementation back into an API.
+type Module interface {
+	// AccountAddress retrieves the address of the node's account/signer
+	AccountAddress(ctx context.Context) (Address, error)
+	// Balance retrieves the Celestia coin balance for the node's account/signer
+	// and verifies it against the corresponding block's AppHash.
+	Balance(ctx context.Context) (*Balance, error)
+	// BalanceForAddress retrieves the Celestia coin balance for the given address and verifies
+	// the returned balance against the corresponding block's AppHash.
+	//
+	// NOTE: the balance returned is the balance reported by the block right before
+	// the node's current head (head-1). This is due to the fact that for block N, the block's
+	// `AppHash` is the result of applying the previous block's transaction list.
+	BalanceForAddress(ctx context.Context, addr Address) (*Balance, error)
+	// Transfer sends the given amount of coins from default wallet of the node to the given account
+	// address.
+	Transfer(ctx context.Context, to AccAddress, amount Int, config *TxConfig) (*TxResponse, error)
+	// SubmitPayForBlob builds, signs and submits a PayForBlob transaction.
+	SubmitPayForBlob(ctx context.Context, blobs []*blob.Blob, config *TxConfig) (*TxResponse, error)
+	// CancelUnbondingDelegation cancels a user's pending undelegation from a validator.
+	CancelUnbondingDelegation(ctx context.Context, valAddr ValAddress, amount, height Int, config *TxConfig) (*TxResponse, error)
+	// BeginRedelegate sends a user's delegated tokens to a new validator for redelegation.
+	BeginRedelegate(ctx context.Context, srcValAddr, dstValAddr ValAddress, amount Int, config *TxConfig) (*TxResponse, error)
+	// Undelegate undelegates a user's delegated tokens, unbonding them from the current validator.
+	Undelegate(ctx context.Context, delAddr ValAddress, amount Int, config *TxConfig) (*TxResponse, error)
+	// Delegate sends a user's liquid tokens to a validator for delegation.
+	Delegate(ctx context.Context, delAddr ValAddress, amount Int, config *TxConfig) (*TxResponse, error)
+	// QueryDelegation retrieves the delegation information between a delegator and a validator.
+	QueryDelegation(ctx context.Context, valAddr ValAddress) (*QueryDelegationResponse, error)
+	// QueryUnbonding retrieves the unbonding status between a delegator and a validator.
+	QueryUnbonding(ctx context.Context, valAddr ValAddress) (*QueryUnbondingDelegationResponse, error)
+	// QueryRedelegations retrieves the status of the redelegations between a delegator and a validator.
+	QueryRedelegations(ctx context.Context, srcValAddr, dstValAddr ValAddress) (*QueryRedelegationsResponse, error)
+	// GrantFee grants the given amount of fee to the given grantee.
+	GrantFee(ctx context.Context, grantee AccAddress, amount Int, config *TxConfig) (*TxResponse, error)
+	// RevokeGrantFee revokes the granted fee from the given grantee.
+	RevokeGrantFee(ctx context.Context, grantee AccAddress, config *TxConfig) (*TxResponse, error)
+}
+
+// Wrap returns the API as Module.
+func Wrap(api *API) Module {
+	return module{API: api}
+}
+
+// NewAPI returns an API whose methods call the ones of the module.
+func NewAPI(m Module) API {
+	return API{
+		AccountAddress:            m.AccountAddress,
+		Balance:                   m.Balance,
+		BalanceForAddress:         m.BalanceForAddress,
+		Transfer:                  m.Transfer,
+		SubmitPayForBlob:          m.SubmitPayForBlob,
+		CancelUnbondingDelegation: m.CancelUnbondingDelegation,
+		BeginRedelegate:           m.BeginRedelegate,
+		Undelegate:                m.Undelegate,
+		Delegate:                  m.Delegate,
+		QueryDelegation:           m.QueryDelegation,
+		QueryUnbonding:            m.QueryUnbonding,
+		QueryRedelegations:        m.QueryRedelegations,
+		GrantFee:                  m.GrantFee,
+		RevokeGrantFee:            m.RevokeGrantFee,
+	}
+}
+
+// module adapts API to Module.
+type module struct {
+	*API
+}
+
+func (m module) AccountAddress(ctx context.Context) (Address, error) {
+	return m.API.AccountAddress(ctx)
+}
+
+func (m module) Balance(ctx context.Context) (*Balance, error) {
+	return m.API.Balance(ctx)
+}
+
+func (m module) BalanceForAddress(ctx context.Context, addr Address) (*Balance, error) {
+	return m.API.BalanceForAddress(ctx, addr)
+}
+
+func (m module) Transfer(ctx context.Context, to AccAddress, amount Int, config *TxConfig) (*TxResponse, error) {
+	return m.API.Transfer(ctx, to, amount, config)
+}
+
+func (m module) SubmitPayForBlob(ctx context.Context, blobs []*blob.Blob, config *TxConfig) (*TxResponse, error) {
+	return m.API.SubmitPayForBlob(ctx, blobs, config)
+}
+
+func (m module) CancelUnbondingDelegation(ctx context.Context, valAddr ValAddress, amount, height Int, config *TxConfig) (*TxResponse, error) {
+	return m.API.CancelUnbondingDelegation(ctx, valAddr, amount, height, config)
+}
+
+func (m module) BeginRedelegate(ctx context.Context, srcValAddr, dstValAddr ValAddress, amount Int, config *TxConfig) (*TxResponse, error) {
+	return m.API.BeginRedelegate(ctx, srcValAddr, dstValAddr, amount, config)
+}
+
+func (m module) Undelegate(ctx context.Context, delAddr ValAddress, amount Int, config *TxConfig) (*TxResponse, error) {
+	return m.API.Undelegate(ctx, delAddr, amount, config)
+}
+
+func (m module) Delegate(ctx context.Context, delAddr ValAddress, amount Int, config *TxConfig) (*TxResponse, error) {
+	return m.API.Delegate(ctx, delAddr, amount, config)
+}
+
+func (m module) QueryDelegation(ctx context.Context, valAddr ValAddress) (*QueryDelegationResponse, error) {
+	return m.API.QueryDelegation(ctx, valAddr)
+}
+
+func (m module) QueryUnbonding(ctx context.Context, valAddr ValAddress) (*QueryUnbondingDelegationResponse, error) {
+	return m.API.QueryUnbonding(ctx, valAddr)
+}
+
+func (m module) QueryRedelegations(ctx context.Context, srcValAddr, dstValAddr ValAddress) (*QueryRedelegationsResponse, error) {
+	return m.API.QueryRedelegations(ctx, srcValAddr, dstValAddr)
+}
+
+func (m module) GrantFee(ctx context.Context, grantee AccAddress, amount Int, config *TxConfig) (*TxResponse, error) {
+	return m.API.GrantFee(ctx, grantee, amount, config)
+}
+
+func (m module) RevokeGrantFee(ctx context.Context, grantee AccAddress, config *TxConfig) (*TxResponse, error) {
+	return m.API.RevokeGrantFee(ctx, grantee, config)
+}
@@ -0,0 +1,72 @@
+package client
+
+import (
+	"context"
+	"net"
+	"net/http"
+	"strings"
+	"time"
+)
+
+const (
+	unixScheme = "unix://"
+	// unixHost is the placeholder address of HTTP requests sent over a unix socket.
+	unixHost = "http://unix"
+)
+
+// unixSocketPath returns the path of the socket if addr is of the form "unix:///path/to/socket".
+func unixSocketPath(addr string) (string, bool) {
+	if !strings.HasPrefix(addr, unixScheme) {
+		return "", false
+	}
+	return strings.TrimPrefix(addr, unixScheme), true
+}
+
+// unixIdleConnTimeout closes the idle connections of HTTP clients of unix sockets which
+// aren't closed by an endpoint, as done by http.DefaultTransport.
+const unixIdleConnTimeout = 90 * time.Second
+
+// newUnixHTTPClient returns an HTTP client sending all requests over the unix socket at path.
+func newUnixHTTPClient(path string) *http.Client {
+	var dialer net.Dialer
+	return &http.Client{
+		Transport: &http.Transport{
+			DialContext: func(ctx context.Context, _, _ string) (net.Conn, error) {
+				return dialer.DialContext(ctx, "unix", path)
+			},
+			IdleConnTimeout: unixIdleConnTimeout,
+		},
+	}
+}
+
+// unixClientKey is the context key of the HTTP client of the unix socket an endpoint is
+// served on, so the connections of its modules and batches share the client.
+type unixClientKey struct{}
+
+// withUnixClient makes transports dialing with the returned context send the requests to
+// unix sockets with the client. The context is returned as is if the client is nil.
+func withUnixClient(ctx context.Context, client *http.Client) context.Context {
+	if client == nil {
+		return ctx
+	}
+	return context.WithValue(ctx, unixClientKey{}, client)
+}
+
+// unixClient returns the HTTP client of the unix socket set on the context, if any.
+func unixClient(ctx context.Context) *http.Client {
+	client, _ := ctx.Value(unixClientKey{}).(*http.Client)
+	return client
+}
+
+// httpEndpoint returns the URL of the HTTP server of the endpoint at addr and the client to
+// send requests to it with, which is client unless addr is a unix socket. Requests to unix
+// sockets are sent with unix, the client of the socket, or a new one if it's nil.
+func httpEndpoint(addr string, client, unix *http.Client) (string, *http.Client) {
+	if path, ok := unixSocketPath(addr); ok {
+		if unix == nil {
+			unix = newUnixHTTPClient(path)
+		}
+		return unixHost, unix
+	}
+	return httpAddr(addr), client
+}
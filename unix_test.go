@@ -0,0 +1,105 @@
+package client
+
+import (
+	"context"
+	"io"
+	"net"
+	"net/http"
+	"path/filepath"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/celestiaorg/celestia-openrpc/types/node"
+)
+
+func TestUnixSocket(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "node.sock")
+	lis, err := net.Listen("unix", path)
+	require.NoError(t, err)
+	srv := &http.Server{Handler: http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) { //nolint:gosec
+		req, err := io.ReadAll(r.Body)
+		require.NoError(t, err)
+		_, _ = w.Write(rpcResponse(t, req, true))
+	})}
+	go srv.Serve(lis) //nolint:errcheck
+	defer srv.Close()
+
+	var api node.API
+	closer, err := DefaultTransport.Dial(context.Background(), unixScheme+path, "node", &api, nil)
+	require.NoError(t, err)
+	defer closer()
+
+	ready, err := api.Ready(context.Background())
+	require.NoError(t, err)
+	require.True(t, ready)
+}
+
+func TestUnixSocketPath(t *testing.T) {
+	path, ok := unixSocketPath("unix:///run/celestia/node.sock")
+	require.True(t, ok)
+	require.Equal(t, "/run/celestia/node.sock", path)
+
+	_, ok = unixSocketPath("http://localhost:26658")
+	require.False(t, ok)
+
+	url, client := httpEndpoint("unix:///run/celestia/node.sock", nil, nil)
+	require.Equal(t, unixHost, url)
+	require.NotNil(t, client)
+	// the client of the endpoint is used if set
+	unix := newUnixHTTPClient("/run/celestia/node.sock")
+	_, client = httpEndpoint("unix:///run/celestia/node.sock", nil, unix)
+	require.Same(t, unix, client)
+}
+
+func TestUnixSocketEndpoint(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "node.sock")
+	lis, err := net.Listen("unix", path)
+	require.NoError(t, err)
+	var open atomic.Int32
+	srv := &http.Server{ //nolint:gosec
+		Handler: http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			req, err := io.ReadAll(r.Body)
+			require.NoError(t, err)
+			_, _ = w.Write(rpcResponse(t, req, true))
+		}),
+		ConnState: func(_ net.Conn, state http.ConnState) {
+			switch state {
+			case http.StateNew:
+				open.Add(1)
+			case http.StateClosed, http.StateHijacked:
+				open.Add(-1)
+			}
+		},
+	}
+	go srv.Serve(lis) //nolint:errcheck
+	defer srv.Close()
+
+	ctx := context.Background()
+	ep, err := dialEndpoint(ctx, unixScheme+path, map[string]interface{}{"node": &node.API{}}, nil, DefaultTransport, nil)
+	require.NoError(t, err)
+	require.NotNil(t, ep.unix)
+	module, ok := ep.module("node")
+	require.True(t, ok)
+	ready, err := module.(*node.API).Ready(ctx)
+	require.NoError(t, err)
+	require.True(t, ready)
+	body, err := ep.post(ctx, []byte(`{"jsonrpc":"2.0","id":1,"method":"node.Ready","params":[]}`))
+	require.NoError(t, err)
+	_, err = io.ReadAll(body)
+	require.NoError(t, err)
+	require.NoError(t, body.Close())
+	require.Positive(t, open.Load())
+
+	// the idle connections to the socket are closed along with the endpoint
+	ep.close()
+	require.Eventually(t, func() bool { return open.Load() == 0 }, 5*time.Second, 10*time.Millisecond)
+}
+
+func TestWebSocketTransportRejectsUnixSocket(t *testing.T) {
+	var api node.API
+	_, err := WebSocketTransport{}.Dial(context.Background(), "unix:///run/celestia/node.sock", "node", &api, nil)
+	require.Error(t, err)
+}
@@ -0,0 +1,169 @@
+package client
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"sync"
+)
+
+// ErrNotRecorded is returned by transports of ReplayTransport for requests missing from the fixture.
+var ErrNotRecorded = errors.New("request was not recorded")
+
+// exchange is a request to a node and the response to it, as stored in fixtures. The IDs
+// of single requests and their responses are stripped, as they depend on the order of calls.
+type exchange struct {
+	Request  json.RawMessage `json:"request"`
+	Response json.RawMessage `json:"response"`
+}
+
+// RecordTransport returns a TransportFunc sending requests over HTTP with the client,
+// http.DefaultClient if nil, and recording the exchanges with the node to the fixture
+// at path, which is overwritten. The fixture is rewritten after every exchange, so it
+// is complete even if the test fails. Headers, such as the auth token, aren't recorded.
+func RecordTransport(path string, client *http.Client) TransportFunc {
+	if client == nil {
+		client = http.DefaultClient
+	}
+	var (
+		mu        sync.Mutex
+		exchanges []exchange
+		// unix is the client of the unix socket requests are sent to if the endpoint doesn't
+		// provide its own, so connections are reused across requests
+		unixOnce sync.Once
+		unix     *http.Client
+	)
+	return func(ctx context.Context, addr string, header http.Header, req []byte) (io.ReadCloser, error) {
+		socket := unixClient(ctx)
+		if path, ok := unixSocketPath(addr); ok && socket == nil {
+			unixOnce.Do(func() { unix = newUnixHTTPClient(path) })
+			socket = unix
+		}
+		url, httpClient := httpEndpoint(addr, client, socket)
+		body, err := postHTTP(ctx, httpClient, url, header, req)
+		if err != nil {
+			return nil, err
+		}
+		defer body.Close()
+		resp, err := io.ReadAll(body)
+		if err != nil {
+			return nil, err
+		}
+
+		e := exchange{}
+		if e.Request, _, err = withoutID(req); err != nil {
+			return nil, fmt.Errorf("recording request: %w", err)
+		}
+		if e.Response, _, err = withoutID(resp); err != nil {
+			return nil, fmt.Errorf("recording response: %w", err)
+		}
+		mu.Lock()
+		defer mu.Unlock()
+		exchanges = append(exchanges, e)
+		data, err := json.MarshalIndent(exchanges, "", "  ")
+		if err != nil {
+			return nil, err
+		}
+		if err := os.WriteFile(path, data, 0o644); err != nil {
+			return nil, fmt.Errorf("writing fixture: %w", err)
+		}
+		return io.NopCloser(bytes.NewReader(resp)), nil
+	}
+}
+
+// ReplayTransport returns a TransportFunc answering requests with the responses recorded
+// to the fixture at path by RecordTransport, without connecting to a node. Requests are
+// matched by their method and params. Responses to identical requests are replayed in the
+// order they were recorded, repeating the last one once all were replayed.
+func ReplayTransport(path string) (TransportFunc, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("reading fixture: %w", err)
+	}
+	var exchanges []exchange
+	if err := json.Unmarshal(data, &exchanges); err != nil {
+		return nil, fmt.Errorf("decoding fixture %s: %w", path, err)
+	}
+	responses := make(map[string][]json.RawMessage, len(exchanges))
+	for _, e := range exchanges {
+		key, err := compact(e.Request)
+		if err != nil {
+			return nil, fmt.Errorf("decoding fixture %s: %w", path, err)
+		}
+		responses[key] = append(responses[key], e.Response)
+	}
+
+	var (
+		mu       sync.Mutex
+		replayed = make(map[string]int)
+	)
+	return func(_ context.Context, _ string, _ http.Header, req []byte) (io.ReadCloser, error) {
+		stripped, id, err := withoutID(req)
+		if err != nil {
+			return nil, err
+		}
+		key, err := compact(stripped)
+		if err != nil {
+			return nil, err
+		}
+
+		mu.Lock()
+		resps, n := responses[key], replayed[key]
+		if n < len(resps)-1 {
+			replayed[key]++
+		}
+		mu.Unlock()
+		if len(resps) == 0 {
+			return nil, fmt.Errorf("%w: %s", ErrNotRecorded, key)
+		}
+
+		resp, err := withID(resps[n], id)
+		if err != nil {
+			return nil, err
+		}
+		return io.NopCloser(bytes.NewReader(resp)), nil
+	}, nil
+}
+
+// withoutID strips the ID from a single request or response, returning it separately.
+// Batches are returned as they are, as the IDs of their calls are their positions.
+func withoutID(msg []byte) (json.RawMessage, json.RawMessage, error) {
+	if trimmed := bytes.TrimSpace(msg); len(trimmed) == 0 || trimmed[0] != '{' {
+		return msg, nil, nil
+	}
+	var fields map[string]json.RawMessage
+	if err := json.Unmarshal(msg, &fields); err != nil {
+		return nil, nil, err
+	}
+	id := fields["id"]
+	delete(fields, "id")
+	stripped, err := json.Marshal(fields)
+	return stripped, id, err
+}
+
+// withID sets the ID of a single response stripped by withoutID.
+func withID(msg json.RawMessage, id json.RawMessage) ([]byte, error) {
+	if id == nil {
+		return msg, nil
+	}
+	var fields map[string]json.RawMessage
+	if err := json.Unmarshal(msg, &fields); err != nil {
+		return nil, err
+	}
+	fields["id"] = id
+	return json.Marshal(fields)
+}
+
+// compact returns the compacted encoding of msg, identifying equal requests.
+func compact(msg json.RawMessage) (string, error) {
+	var buf bytes.Buffer
+	if err := json.Compact(&buf, msg); err != nil {
+		return "", err
+	}
+	return buf.String(), nil
+}
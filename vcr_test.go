@@ -0,0 +1,81 @@
+package client
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestRecordReplay(t *testing.T) {
+	ctx := context.Background()
+	fixture := filepath.Join(t.TempDir(), "node.json")
+
+	var readyCalls int
+	srv := testNode(t, func(_ *http.Request, method string, _ json.RawMessage) (interface{}, error) {
+		switch method {
+		case "node.Ready":
+			// the node is ready from the second call on
+			readyCalls++
+			return readyCalls > 1, nil
+		case "header.GetByHeight":
+			return nil, errors.New("header: not found")
+		}
+		return nil, errors.New("method not found")
+	})
+
+	c, err := NewClient(ctx, srv.URL, "token", WithTransport(RecordTransport(fixture, nil)))
+	require.NoError(t, err)
+	for _, want := range []bool{false, true} {
+		ready, err := c.Node.Ready(ctx)
+		require.NoError(t, err)
+		require.Equal(t, want, ready)
+	}
+	_, err = c.Header.GetByHeight(ctx, 1)
+	require.ErrorIs(t, err, ErrHeaderNotFound)
+	c.Close()
+	srv.Close()
+
+	transport, err := ReplayTransport(fixture)
+	require.NoError(t, err)
+	c, err = NewClient(ctx, "http://localhost:26658", "", WithTransport(transport))
+	require.NoError(t, err)
+	defer c.Close()
+
+	// responses are replayed in order, repeating the last one
+	for _, want := range []bool{false, true, true} {
+		ready, err := c.Node.Ready(ctx)
+		require.NoError(t, err)
+		require.Equal(t, want, ready)
+	}
+	_, err = c.Header.GetByHeight(ctx, 1)
+	require.ErrorIs(t, err, ErrHeaderNotFound)
+	_, err = c.Header.GetByHeight(ctx, 2)
+	require.ErrorContains(t, err, ErrNotRecorded.Error())
+}
+
+func TestReplayTransportMissingFixture(t *testing.T) {
+	_, err := ReplayTransport(filepath.Join(t.TempDir(), "missing.json"))
+	require.Error(t, err)
+}
+
+func TestWithoutID(t *testing.T) {
+	stripped, id, err := withoutID([]byte(`{"jsonrpc":"2.0","id":7,"method":"node.Ready","params":[]}`))
+	require.NoError(t, err)
+	require.JSONEq(t, `{"jsonrpc":"2.0","method":"node.Ready","params":[]}`, string(stripped))
+	require.Equal(t, "7", string(id))
+
+	resp, err := withID(json.RawMessage(`{"jsonrpc":"2.0","result":true}`), id)
+	require.NoError(t, err)
+	require.JSONEq(t, `{"jsonrpc":"2.0","id":7,"result":true}`, string(resp))
+
+	batch := []byte(`[{"jsonrpc":"2.0","id":0,"method":"node.Ready","params":[]}]`)
+	stripped, id, err = withoutID(batch)
+	require.NoError(t, err)
+	require.Equal(t, batch, []byte(stripped))
+	require.Nil(t, id)
+}
@@ -0,0 +1,221 @@
+package client
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/celestiaorg/rsmt2d"
+
+	"github.com/celestiaorg/celestia-openrpc/types/appconsts"
+	"github.com/celestiaorg/celestia-openrpc/types/blob"
+	"github.com/celestiaorg/celestia-openrpc/types/header"
+	"github.com/celestiaorg/celestia-openrpc/types/share"
+)
+
+// ErrVerificationFailed is returned by methods of clients constructed with WithProofVerification
+// if the data returned by the node can't be proven against the data root of the header.
+var ErrVerificationFailed = errors.New("response failed proof verification")
+
+// verifyProofs wraps the methods of the client retrieving shares and blobs to verify
+// their proofs against the data availability header of the block they are retrieved from.
+// The inclusion of blobs is verified locally instead of relying on the answer of the node.
+func verifyProofs(c *Client) {
+	getSharesByNamespace := c.Share.GetSharesByNamespace
+	c.Share.GetSharesByNamespace = func(
+		ctx context.Context,
+		eh *header.ExtendedHeader,
+		namespace share.Namespace,
+	) (*share.NamespacedShares, error) {
+		shares, err := getSharesByNamespace(ctx, eh, namespace)
+		if err != nil {
+			return nil, err
+		}
+		if eh == nil || eh.DAH == nil || shares == nil {
+			return nil, fmt.Errorf("%w: no header or shares to verify", ErrVerificationFailed)
+		}
+		if err := shares.Verify(eh.DAH, namespace); err != nil {
+			return nil, fmt.Errorf("%w: namespace %s at height %d: %v", ErrVerificationFailed, namespace, eh.Height(), err)
+		}
+		return shares, nil
+	}
+
+	getEDS := c.Share.GetEDS
+	c.Share.GetEDS = func(ctx context.Context, eh *header.ExtendedHeader) (*rsmt2d.ExtendedDataSquare, error) {
+		eds, err := getEDS(ctx, eh)
+		if err != nil {
+			return nil, err
+		}
+		if eh == nil || eh.DAH == nil || eds == nil {
+			return nil, fmt.Errorf("%w: no header or square to verify", ErrVerificationFailed)
+		}
+		root, err := share.NewRoot(eds)
+		if err != nil {
+			return nil, fmt.Errorf("%w: square at height %d: %v", ErrVerificationFailed, eh.Height(), err)
+		}
+		if !root.Equals(eh.DAH) {
+			return nil, fmt.Errorf("%w: square at height %d doesn't match its header", ErrVerificationFailed, eh.Height())
+		}
+		return eds, nil
+	}
+
+	getRange := c.Share.GetRange
+	c.Share.GetRange = func(ctx context.Context, height uint64, start, end int) (*share.GetRangeResult, error) {
+		res, err := getRange(ctx, height, start, end)
+		if err != nil {
+			return nil, err
+		}
+		eh, err := c.Header.GetByHeight(ctx, height)
+		if err != nil {
+			return nil, fmt.Errorf("getting header to verify range: %w", err)
+		}
+		if res == nil || len(res.Shares) != end-start {
+			return nil, fmt.Errorf("%w: range [%d, %d) at height %d is incomplete", ErrVerificationFailed, start, end, height)
+		}
+		// the shares of a range are proven against the namespace they claim to be of
+		var namespace share.Namespace
+		if len(res.Shares) > 0 && len(res.Shares[0]) >= appconsts.NamespaceSize {
+			namespace = share.GetNamespace(res.Shares[0])
+		}
+		if err := res.Verify(eh.DataHash, namespace); err != nil {
+			return nil, fmt.Errorf("%w: range [%d, %d) at height %d: %v", ErrVerificationFailed, start, end, height, err)
+		}
+		return res, nil
+	}
+
+	getAll := c.Blob.GetAll
+	c.Blob.GetAll = func(ctx context.Context, height uint64, namespaces []share.Namespace) ([]*blob.Blob, error) {
+		blobs, err := getAll(ctx, height, namespaces)
+		if err != nil && !errors.Is(err, blob.ErrBlobNotFound) {
+			return nil, err
+		}
+		// the absence of blobs is verified as well
+		eh, herr := c.Header.GetByHeight(ctx, height)
+		if herr != nil {
+			return nil, fmt.Errorf("getting header to verify blobs: %w", herr)
+		}
+		for _, namespace := range namespaces {
+			if verr := verifyBlobs(ctx, c, eh, namespace, blobs); verr != nil {
+				return nil, verr
+			}
+		}
+		return blobs, err
+	}
+
+	c.Blob.Included = func(
+		ctx context.Context,
+		height uint64,
+		namespace share.Namespace,
+		proof *blob.Proof,
+		commitment blob.Commitment,
+	) (bool, error) {
+		if proof == nil {
+			return false, nil
+		}
+		b, err := c.Blob.Get(ctx, height, namespace, commitment)
+		if errors.Is(err, blob.ErrBlobNotFound) {
+			return false, nil
+		}
+		if err != nil {
+			return false, fmt.Errorf("getting blob to verify inclusion: %w", err)
+		}
+		eh, err := c.Header.GetByHeight(ctx, height)
+		if err != nil {
+			return false, fmt.Errorf("getting header to verify inclusion: %w", err)
+		}
+
+		// the commitment is recomputed, as the one returned by the node isn't trusted
+		var local *blob.Blob
+		//nolint:gosec
+		if uint8(b.ShareVersion) == appconsts.ShareVersionOne {
+			local, err = blob.NewBlobV1(namespace, b.Data, b.Signer())
+		} else {
+			local, err = blob.NewBlob(uint8(b.ShareVersion), namespace, b.Data)
+		}
+		if err != nil {
+			return false, fmt.Errorf("%w: blob at height %d: %v", ErrVerificationFailed, height, err)
+		}
+		if !local.Commitment.Equal(commitment) {
+			return false, nil
+		}
+		return proof.VerifyInclusion(local, eh.DAH, eh.DataHash) == nil, nil
+	}
+
+	getCommitmentProof := c.Blob.GetCommitmentProof
+	c.Blob.GetCommitmentProof = func(
+		ctx context.Context,
+		height uint64,
+		namespace share.Namespace,
+		shareCommitment []byte,
+	) (*blob.CommitmentProof, error) {
+		proof, err := getCommitmentProof(ctx, height, namespace, shareCommitment)
+		if err != nil {
+			return nil, err
+		}
+		eh, err := c.Header.GetByHeight(ctx, height)
+		if err != nil {
+			return nil, fmt.Errorf("getting header to verify commitment proof: %w", err)
+		}
+		if err := verifyCommitmentProof(proof, namespace, shareCommitment, eh.DataHash); err != nil {
+			return nil, fmt.Errorf("%w: commitment proof at height %d: %v", ErrVerificationFailed, height, err)
+		}
+		return proof, nil
+	}
+}
+
+// verifyBlobs checks that the blobs of the namespace are all the blobs stored under it in
+// the block, comparing their shares with the verified shares of the namespace.
+func verifyBlobs(
+	ctx context.Context,
+	c *Client,
+	eh *header.ExtendedHeader,
+	namespace share.Namespace,
+	blobs []*blob.Blob,
+) error {
+	shares, err := c.Share.GetSharesByNamespace(ctx, eh, namespace)
+	if err != nil {
+		return fmt.Errorf("getting shares to verify blobs: %w", err)
+	}
+
+	var proven []share.Share
+	for _, shr := range shares.Flatten() {
+		appShare, err := share.NewShare(shr)
+		if err != nil {
+			return fmt.Errorf("%w: namespace %s at height %d: %v", ErrVerificationFailed, namespace, eh.Height(), err)
+		}
+		if padding, err := appShare.IsPadding(); err != nil || padding {
+			continue
+		}
+		proven = append(proven, shr)
+	}
+
+	var returned []share.Share
+	for i, b := range blobs {
+		if !bytes.Equal(b.Namespace().Bytes(), namespace) {
+			continue
+		}
+		if commitment, err := blob.CreateCommitment(b); err != nil || !commitment.Equal(b.Commitment) {
+			return fmt.Errorf("%w: namespace %s at height %d: commitment of blob %d doesn't match its data",
+				ErrVerificationFailed, namespace, eh.Height(), i)
+		}
+		// converted one by one, as BlobsToShares reorders blobs of the same namespace
+		bShares, err := blob.BlobsToShares(b)
+		if err != nil {
+			return err
+		}
+		returned = append(returned, bShares...)
+	}
+
+	if len(proven) != len(returned) {
+		return fmt.Errorf("%w: namespace %s at height %d holds %d blob shares, %d were returned",
+			ErrVerificationFailed, namespace, eh.Height(), len(proven), len(returned))
+	}
+	for i := range proven {
+		if !bytes.Equal(proven[i], returned[i]) {
+			return fmt.Errorf("%w: namespace %s at height %d: share %d of the blobs differs from the proven one",
+				ErrVerificationFailed, namespace, eh.Height(), i)
+		}
+	}
+	return nil
+}
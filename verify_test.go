@@ -0,0 +1,306 @@
+package client
+
+import (
+	"context"
+	"crypto/sha256"
+	"testing"
+
+	"github.com/celestiaorg/go-square/merkle"
+	"github.com/celestiaorg/nmt"
+	cmbytes "github.com/cometbft/cometbft/libs/bytes"
+	"github.com/stretchr/testify/require"
+
+	"github.com/celestiaorg/celestia-openrpc/mocks"
+	"github.com/celestiaorg/celestia-openrpc/types/appconsts"
+	"github.com/celestiaorg/celestia-openrpc/types/blob"
+	"github.com/celestiaorg/celestia-openrpc/types/header"
+	"github.com/celestiaorg/celestia-openrpc/types/proofs"
+	"github.com/celestiaorg/celestia-openrpc/types/share"
+)
+
+// provenBlock is a block holding the blob in its first row and a blob of
+// another namespace in its second one.
+type provenBlock struct {
+	blob     *blob.Blob
+	header   *header.ExtendedHeader
+	shares   []share.Share
+	nsProof  nmt.Proof
+	rowProof nmt.Proof
+}
+
+// rowTree returns the tree of a row holding the shares.
+func rowTree(t *testing.T, shares []share.Share) *nmt.NamespacedMerkleTree {
+	t.Helper()
+	tree := nmt.New(sha256.New(), nmt.NamespaceIDSize(appconsts.NamespaceSize), nmt.IgnoreMaxNamespace(true))
+	for _, shr := range shares {
+		require.NoError(t, tree.Push(append(append([]byte{}, share.GetNamespace(shr)...), shr...)))
+	}
+	return tree
+}
+
+func newProvenBlock(t *testing.T) *provenBlock {
+	t.Helper()
+	b := testBlob(t, "hello")
+	shares, err := blob.BlobsToShares(b)
+	require.NoError(t, err)
+
+	other, err := share.NewBlobNamespaceV0([]byte{9, 9, 9, 9})
+	require.NoError(t, err)
+	otherBlob, err := blob.NewBlobV0(other, []byte("other"))
+	require.NoError(t, err)
+	otherShares, err := blob.BlobsToShares(otherBlob)
+	require.NoError(t, err)
+
+	tree, otherTree := rowTree(t, shares), rowTree(t, otherShares)
+	root, err := tree.Root()
+	require.NoError(t, err)
+	otherRoot, err := otherTree.Root()
+	require.NoError(t, err)
+
+	namespace := share.Namespace(b.Namespace().Bytes())
+	nsProof, err := tree.ProveNamespace(namespace.ToNMT())
+	require.NoError(t, err)
+	rowProof, err := tree.ProveRange(0, len(shares))
+	require.NoError(t, err)
+
+	eh := mocks.NewHeader(1)
+	eh.DAH = &header.DataAvailabilityHeader{RowRoots: [][]byte{root, otherRoot}, ColumnRoots: [][]byte{root, otherRoot}}
+	eh.DataHash = eh.DAH.Hash()
+	return &provenBlock{blob: b, header: eh, shares: shares, nsProof: nsProof, rowProof: rowProof}
+}
+
+// client returns a client whose methods return the blobs and shares, verifying their proofs.
+func (pb *provenBlock) client(blobs []*blob.Blob, shares []share.Share) *Client {
+	c := &Client{}
+	c.Header.GetByHeight = func(context.Context, uint64) (*header.ExtendedHeader, error) {
+		return pb.header, nil
+	}
+	c.Blob.Get = func(_ context.Context, _ uint64, _ share.Namespace, commitment blob.Commitment) (*blob.Blob, error) {
+		for _, b := range blobs {
+			if b.Commitment.Equal(commitment) {
+				return b, nil
+			}
+		}
+		return nil, blob.ErrBlobNotFound
+	}
+	c.Blob.GetAll = func(context.Context, uint64, []share.Namespace) ([]*blob.Blob, error) {
+		if len(blobs) == 0 {
+			return nil, blob.ErrBlobNotFound
+		}
+		return blobs, nil
+	}
+	c.Share.GetSharesByNamespace = func(
+		context.Context,
+		*header.ExtendedHeader,
+		share.Namespace,
+	) (*share.NamespacedShares, error) {
+		return &share.NamespacedShares{{Shares: shares, Proof: &pb.nsProof}}, nil
+	}
+	c.Share.GetRange = func(_ context.Context, _ uint64, start, end int) (*share.GetRangeResult, error) {
+		namespace := share.Namespace(pb.blob.Namespace().Bytes())
+		return &share.GetRangeResult{
+			Shares: shares[start:end],
+			Proof: &share.ShareProof{
+				Data:             shares[start:end],
+				ShareProofs:      []*nmt.Proof{&pb.rowProof},
+				NamespaceID:      namespace.ID(),
+				NamespaceVersion: uint32(namespace.Version()),
+				RowProof:         pb.rowRootProof(),
+			},
+		}, nil
+	}
+	c.Blob.GetCommitmentProof = func(context.Context, uint64, share.Namespace, []byte) (*blob.CommitmentProof, error) {
+		return pb.commitmentProof(shares), nil
+	}
+	verifyProofs(c)
+	return c
+}
+
+// commitmentProof returns the proof of the commitment of the blob made of the shares, which
+// take up a single subtree of the first row.
+func (pb *provenBlock) commitmentProof(shares []share.Share) *blob.CommitmentProof {
+	hasher := nmt.NewNmtHasher(sha256.New(), appconsts.NamespaceSize, true)
+	subtreeRoot, err := hasher.HashLeaf(append(append([]byte{}, share.GetNamespace(shares[0])...), shares[0]...))
+	if err != nil {
+		panic(err)
+	}
+	namespace := share.Namespace(pb.blob.Namespace().Bytes())
+	return &blob.CommitmentProof{
+		SubtreeRoots:      [][]byte{subtreeRoot},
+		SubtreeRootProofs: []*nmt.Proof{&pb.rowProof},
+		NamespaceID:       namespace.ID(),
+		NamespaceVersion:  namespace.Version(),
+		RowProof:          pb.rowRootProof(),
+	}
+}
+
+// rowRootProof returns the proof of the root of the first row against the data root.
+func (pb *provenBlock) rowRootProof() proofs.RowProof {
+	dah := pb.header.DAH
+	_, rowProofs := merkle.ProofsFromByteSlices(append(append([][]byte{}, dah.RowRoots...), dah.ColumnRoots...))
+	return proofs.RowProof{
+		RowRoots: []cmbytes.HexBytes{dah.RowRoots[0]},
+		Proofs:   rowProofs[:1],
+	}
+}
+
+func TestVerifyProofs(t *testing.T) {
+	pb := newProvenBlock(t)
+	c := pb.client([]*blob.Blob{pb.blob}, pb.shares)
+	namespace := share.Namespace(pb.blob.Namespace().Bytes())
+	ctx := context.Background()
+
+	blobs, err := c.Blob.GetAll(ctx, 1, []share.Namespace{namespace})
+	require.NoError(t, err)
+	require.Equal(t, []*blob.Blob{pb.blob}, blobs)
+
+	shares, err := c.Share.GetSharesByNamespace(ctx, pb.header, namespace)
+	require.NoError(t, err)
+	require.Equal(t, pb.shares, shares.Flatten())
+
+	res, err := c.Share.GetRange(ctx, 1, 0, len(pb.shares))
+	require.NoError(t, err)
+	require.Equal(t, pb.shares, res.Shares)
+}
+
+func TestVerifyProofsRejectsTamperedData(t *testing.T) {
+	pb := newProvenBlock(t)
+	namespace := share.Namespace(pb.blob.Namespace().Bytes())
+	ctx := context.Background()
+
+	tampered := append([]byte{}, pb.shares[0]...)
+	tampered[len(tampered)-1] ^= 0xff
+	c := pb.client([]*blob.Blob{pb.blob}, []share.Share{tampered})
+
+	_, err := c.Share.GetSharesByNamespace(ctx, pb.header, namespace)
+	require.ErrorIs(t, err, ErrVerificationFailed)
+	_, err = c.Share.GetRange(ctx, 1, 0, 1)
+	require.ErrorIs(t, err, ErrVerificationFailed)
+	_, err = c.Blob.GetAll(ctx, 1, []share.Namespace{namespace})
+	require.ErrorIs(t, err, ErrVerificationFailed)
+
+	// blobs which aren't in the block, or are withheld, are caught as well
+	c = pb.client([]*blob.Blob{testBlob(t, "forged")}, pb.shares)
+	_, err = c.Blob.GetAll(ctx, 1, []share.Namespace{namespace})
+	require.ErrorIs(t, err, ErrVerificationFailed)
+
+	c = pb.client(nil, pb.shares)
+	_, err = c.Blob.GetAll(ctx, 1, []share.Namespace{namespace})
+	require.ErrorIs(t, err, ErrVerificationFailed)
+
+	// as are commitments which don't match the data
+	mislabeled := testBlob(t, "hello")
+	mislabeled.Commitment = testBlob(t, "forged").Commitment
+	c = pb.client([]*blob.Blob{mislabeled}, pb.shares)
+	_, err = c.Blob.GetAll(ctx, 1, []share.Namespace{namespace})
+	require.ErrorIs(t, err, ErrVerificationFailed)
+}
+
+func TestNamespacedSharesVerify(t *testing.T) {
+	pb := newProvenBlock(t)
+	namespace := share.Namespace(pb.blob.Namespace().Bytes())
+	shares := share.NamespacedShares{{Shares: pb.shares, Proof: &pb.nsProof}}
+	require.NoError(t, shares.Verify(pb.header.DAH, namespace))
+	require.Error(t, shares.Verify(nil, namespace))
+
+	// rows of the namespace can't be omitted
+	require.ErrorContains(t, share.NamespacedShares{}.Verify(pb.header.DAH, namespace), "amount of rows")
+
+	// namespaces between the rows are absent from the square
+	absent, err := share.NewBlobNamespaceV0([]byte{5, 5, 5, 5})
+	require.NoError(t, err)
+	require.NoError(t, share.NamespacedShares{}.Verify(pb.header.DAH, absent))
+	require.Error(t, shares.Verify(pb.header.DAH, absent))
+}
+
+func TestRootNamespaces(t *testing.T) {
+	pb := newProvenBlock(t)
+	root := pb.header.DAH
+	namespace := share.Namespace(pb.blob.Namespace().Bytes())
+	other, err := share.NewBlobNamespaceV0([]byte{9, 9, 9, 9})
+	require.NoError(t, err)
+	absent, err := share.NewBlobNamespaceV0([]byte{5, 5, 5, 5})
+	require.NoError(t, err)
+
+	require.Equal(t, []byte(namespace), root.MinNamespace(0))
+	require.Equal(t, []byte(namespace), root.MaxNamespace(0))
+	require.Equal(t, []byte(other), root.MinNamespace(1))
+	require.Nil(t, root.MinNamespace(2))
+
+	require.Equal(t, []int{0}, root.RowsWithNamespace(namespace))
+	require.Equal(t, []int{1}, root.RowsWithNamespace(other))
+	require.Empty(t, root.RowsWithNamespace(absent))
+	require.True(t, root.MayContainNamespace(other))
+	require.False(t, root.MayContainNamespace(absent))
+}
+
+func TestGetRangeResultVerify(t *testing.T) {
+	pb := newProvenBlock(t)
+	namespace := share.Namespace(pb.blob.Namespace().Bytes())
+	res := &share.GetRangeResult{
+		Shares: pb.shares,
+		Proof: &share.ShareProof{
+			Data:             pb.shares,
+			ShareProofs:      []*nmt.Proof{&pb.rowProof},
+			NamespaceID:      namespace.ID(),
+			NamespaceVersion: uint32(namespace.Version()),
+			RowProof:         pb.rowRootProof(),
+		},
+	}
+	require.NoError(t, res.Verify(pb.header.DataHash, namespace))
+	require.ErrorContains(t, res.Verify([]byte("untrusted"), namespace), "row proof")
+
+	other, err := share.NewBlobNamespaceV0([]byte{9, 9, 9, 9})
+	require.NoError(t, err)
+	require.ErrorContains(t, res.Verify(pb.header.DataHash, other), "proof is for namespace")
+
+	// the row roots must be the ones of the rows the shares are in
+	res.Proof.RowProof.StartRow, res.Proof.RowProof.EndRow = 1, 1
+	require.ErrorContains(t, res.Verify(pb.header.DataHash, namespace), "is for row 0")
+}
+
+func TestVerifyInclusion(t *testing.T) {
+	pb := newProvenBlock(t)
+	namespace := share.Namespace(pb.blob.Namespace().Bytes())
+	proof := &blob.Proof{&pb.rowProof}
+	ctx := context.Background()
+
+	require.NoError(t, proof.VerifyInclusion(pb.blob, pb.header.DAH, pb.header.DataHash))
+	require.ErrorIs(t, proof.VerifyInclusion(pb.blob, pb.header.DAH, []byte("untrusted")), blob.ErrInvalidProof)
+	require.ErrorIs(t, proof.VerifyInclusion(testBlob(t, "other"), pb.header.DAH, pb.header.DataHash), blob.ErrInvalidProof)
+
+	c := pb.client([]*blob.Blob{pb.blob}, pb.shares)
+	included, err := c.Blob.Included(ctx, 1, namespace, proof, pb.blob.Commitment)
+	require.NoError(t, err)
+	require.True(t, included)
+
+	// a node returning a blob which doesn't match the proof can't make it pass
+	forged := testBlob(t, "forged")
+	c = pb.client([]*blob.Blob{forged}, pb.shares)
+	included, err = c.Blob.Included(ctx, 1, namespace, proof, forged.Commitment)
+	require.NoError(t, err)
+	require.False(t, included)
+}
+
+func TestVerifyCommitmentProof(t *testing.T) {
+	pb := newProvenBlock(t)
+	namespace := share.Namespace(pb.blob.Namespace().Bytes())
+	ctx := context.Background()
+
+	proof := pb.commitmentProof(pb.shares)
+	require.Equal(t, pb.blob.Commitment, proof.GenerateCommitment())
+	require.NoError(t, proof.Verify(pb.header.DataHash))
+	require.ErrorIs(t, proof.Verify([]byte("untrusted")), blob.ErrInvalidProof)
+
+	c := pb.client([]*blob.Blob{pb.blob}, pb.shares)
+	_, err := c.Blob.GetCommitmentProof(ctx, 1, namespace, pb.blob.Commitment)
+	require.NoError(t, err)
+	_, err = c.Blob.GetCommitmentProof(ctx, 1, namespace, testBlob(t, "other").Commitment)
+	require.ErrorIs(t, err, ErrVerificationFailed)
+
+	tampered := append([]byte{}, pb.shares[0]...)
+	tampered[len(tampered)-1] ^= 0xff
+	c = pb.client([]*blob.Blob{pb.blob}, []share.Share{tampered})
+	_, err = c.Blob.GetCommitmentProof(ctx, 1, namespace, pb.blob.Commitment)
+	require.ErrorIs(t, err, ErrVerificationFailed)
+}
@@ -0,0 +1,54 @@
+package client
+
+import (
+	"context"
+	"fmt"
+
+	"golang.org/x/mod/semver"
+)
+
+const (
+	// MinAPIVersion and MaxAPIVersion bound the versions of the node API the client is
+	// compatible with. MaxAPIVersion is exclusive.
+	MinAPIVersion = "v0.13.0"
+	MaxAPIVersion = "v1.0.0"
+)
+
+// IncompatibleVersionError is returned if the API version of the node is outside the
+// range supported by the client.
+type IncompatibleVersionError struct {
+	Version string
+}
+
+func (e *IncompatibleVersionError) Error() string {
+	return fmt.Sprintf("node API version %s is not supported, supported are [%s, %s)",
+		e.Version, MinAPIVersion, MaxAPIVersion)
+}
+
+// checkAPIVersion returns an IncompatibleVersionError if the version isn't supported.
+func checkAPIVersion(version string) error {
+	if !semver.IsValid(version) ||
+		semver.Compare(version, MinAPIVersion) < 0 ||
+		semver.Compare(version, MaxAPIVersion) >= 0 {
+		return &IncompatibleVersionError{Version: version}
+	}
+	return nil
+}
+
+// negotiateVersion compares the API version of the node with the supported range.
+// Incompatibilities are logged, unless strict is set, in which case they are returned.
+// As the version is only available to admins, the check is skipped for other tokens.
+func negotiateVersion(ctx context.Context, c *Client, strict bool) error {
+	info, err := c.Node.Info(ctx)
+	if err != nil {
+		c.log.DebugContext(ctx, "skipping API version check", "err", err)
+		return nil
+	}
+
+	err = checkAPIVersion(info.APIVersion)
+	if err != nil && !strict {
+		c.log.WarnContext(ctx, "node API version may be incompatible", "err", err)
+		return nil
+	}
+	return err
+}
@@ -0,0 +1,44 @@
+package client
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/celestiaorg/celestia-openrpc/types/node"
+)
+
+func TestCheckAPIVersion(t *testing.T) {
+	for _, version := range []string{MinAPIVersion, "v0.14.2", "v0.99.0"} {
+		require.NoError(t, checkAPIVersion(version), version)
+	}
+	for _, version := range []string{"v0.12.4", MaxAPIVersion, "v1.2.0", "0.13.0", ""} {
+		var incompatible *IncompatibleVersionError
+		require.ErrorAs(t, checkAPIVersion(version), &incompatible, version)
+		require.Equal(t, version, incompatible.Version)
+	}
+}
+
+// versionClient returns a client whose node reports the API version, or fails with err.
+func versionClient(version string, err error) *Client {
+	c := &Client{log: discardLogger}
+	c.Node.Info = func(context.Context) (node.Info, error) {
+		return node.Info{APIVersion: version}, err
+	}
+	return c
+}
+
+func TestNegotiateVersion(t *testing.T) {
+	ctx := context.Background()
+	require.NoError(t, negotiateVersion(ctx, versionClient("v0.13.1", nil), true))
+
+	// incompatible versions are only returned in strict mode
+	require.NoError(t, negotiateVersion(ctx, versionClient("v0.11.0", nil), false))
+	var incompatible *IncompatibleVersionError
+	require.ErrorAs(t, negotiateVersion(ctx, versionClient("v0.11.0", nil), true), &incompatible)
+
+	// the check is skipped if the version isn't available to the token
+	require.NoError(t, negotiateVersion(ctx, versionClient("", errors.New("missing permission")), true))
+}
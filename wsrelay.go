@@ -0,0 +1,285 @@
+package client
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"errors"
+	"net"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/filecoin-project/go-jsonrpc"
+	"github.com/gorilla/websocket"
+)
+
+// wsOptions limit the size of the messages sent and received over WebSocket connections
+// and configure the keep-alive pings timed by the clock. Zero disables the respective
+// limit or the pings.
+type wsOptions struct {
+	maxRequestSize  int64
+	maxResponseSize int64
+	// pingInterval is the interval between pings sent to the node, while connTimeout is
+	// the duration without anything arriving from it after which a connection is torn down.
+	pingInterval time.Duration
+	connTimeout  time.Duration
+	clock        Clock
+}
+
+// keepAlive reports whether the relay sends the keep-alive pings.
+func (o wsOptions) keepAlive() bool {
+	return o.pingInterval > 0 && o.connTimeout > 0 && o.clock != nil
+}
+
+// dial connects the module of the given RPC namespace to the WebSocket server at addr.
+// go-jsonrpc doesn't expose its connections to set their read limits on, nor lets its pings
+// be timed by another clock, so if limits or keep-alive pings are set, its connections are
+// relayed to the node through a loopback listener enforcing them.
+func (o wsOptions) dial(
+	ctx context.Context,
+	addr, namespace string,
+	out interface{},
+	header http.Header,
+	opts ...jsonrpc.Option,
+) (jsonrpc.ClientCloser, error) {
+	if o.maxRequestSize <= 0 && o.maxResponseSize <= 0 && !o.keepAlive() {
+		return jsonrpc.NewMergeClient(ctx, addr, namespace, []interface{}{out}, header, opts...)
+	}
+	relay, err := newWSRelay(addr, o)
+	if err != nil {
+		return nil, err
+	}
+	closer, err := jsonrpc.NewMergeClient(ctx, relay.addr(), namespace, []interface{}{out}, header, opts...)
+	if err != nil {
+		relay.close()
+		return nil, err
+	}
+	return func() {
+		closer()
+		relay.close()
+	}, nil
+}
+
+// wsRelay relays the WebSocket connections made to its loopback listener to the node,
+// limiting the size of the messages read from either side. Messages exceeding a limit
+// close both connections with CloseMessageTooBig, and go-jsonrpc reconnects. If keep-alive
+// pings are set, the relay pings the node instead of go-jsonrpc, whose pings it answers,
+// and closes both connections once the node went silent for too long.
+type wsRelay struct {
+	target string
+	opts   wsOptions
+	// path is the random path connections are accepted on, so other processes can't
+	// use the relay to reach the node.
+	path     string
+	listener net.Listener
+	server   *http.Server
+
+	mu     sync.Mutex
+	conns  map[*websocket.Conn]struct{}
+	closed bool
+}
+
+func newWSRelay(target string, opts wsOptions) (*wsRelay, error) {
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		return nil, err
+	}
+	secret := make([]byte, 16)
+	if _, err := rand.Read(secret); err != nil {
+		listener.Close()
+		return nil, err
+	}
+	r := &wsRelay{
+		target:   target,
+		opts:     opts,
+		path:     "/" + hex.EncodeToString(secret),
+		listener: listener,
+		conns:    make(map[*websocket.Conn]struct{}),
+	}
+	r.server = &http.Server{Handler: r, ReadHeaderTimeout: 10 * time.Second}
+	go r.server.Serve(listener) //nolint:errcheck
+	return r, nil
+}
+
+// addr returns the WebSocket address go-jsonrpc connects to.
+func (r *wsRelay) addr() string {
+	return "ws://" + r.listener.Addr().String() + r.path
+}
+
+// close stops the relay, closing the relayed connections.
+func (r *wsRelay) close() {
+	r.server.Close()
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.closed = true
+	for conn := range r.conns {
+		conn.Close()
+	}
+}
+
+func (r *wsRelay) ServeHTTP(w http.ResponseWriter, req *http.Request) {
+	if req.URL.Path != r.path {
+		http.NotFound(w, req)
+		return
+	}
+	// the headers of the handshake are set by the dialer
+	header := req.Header.Clone()
+	for _, key := range []string{
+		"Upgrade", "Connection", "Sec-Websocket-Key", "Sec-Websocket-Version",
+		"Sec-Websocket-Extensions", "Sec-Websocket-Protocol",
+	} {
+		header.Del(key)
+	}
+	upstream, resp, err := websocket.DefaultDialer.DialContext(req.Context(), r.target, header)
+	if err != nil {
+		status := http.StatusBadGateway
+		if resp != nil {
+			status = resp.StatusCode
+		}
+		http.Error(w, err.Error(), status)
+		return
+	}
+	downstream, err := (&websocket.Upgrader{}).Upgrade(w, req, nil)
+	if err != nil {
+		upstream.Close()
+		return
+	}
+	if !r.track(upstream, downstream) {
+		return
+	}
+	defer r.untrack(upstream, downstream)
+
+	upstream.SetReadLimit(r.opts.maxResponseSize)
+	downstream.SetReadLimit(r.opts.maxRequestSize)
+	var seen func()
+	if r.opts.keepAlive() {
+		// the pings of go-jsonrpc are answered by the default ping handler, while the relay
+		// pings the node itself
+		last := &lastSeen{clock: r.opts.clock, at: r.opts.clock.Now()}
+		seen = last.seen
+		upstream.SetPongHandler(func(string) error {
+			last.seen()
+			return nil
+		})
+		stop := make(chan struct{})
+		defer close(stop)
+		go r.keepAlive(upstream, last, stop)
+	} else {
+		// pings are forwarded to the node, so the keep-alive of go-jsonrpc detects dead connections
+		downstream.SetPingHandler(func(data string) error {
+			return upstream.WriteControl(websocket.PingMessage, []byte(data), time.Now().Add(time.Second))
+		})
+		upstream.SetPongHandler(func(data string) error {
+			return downstream.WriteControl(websocket.PongMessage, []byte(data), time.Now().Add(time.Second))
+		})
+	}
+
+	done := make(chan struct{}, 2)
+	go relayMessages(upstream, downstream, ErrRequestTooLarge, nil, done)
+	go relayMessages(downstream, upstream, ErrResponseTooLarge, seen, done)
+	<-done
+	upstream.Close()
+	downstream.Close()
+	<-done
+}
+
+// track registers the connections to be closed along with the relay. It closes them and
+// returns false if the relay is closed already.
+func (r *wsRelay) track(conns ...*websocket.Conn) bool {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	for _, conn := range conns {
+		if r.closed {
+			conn.Close()
+			continue
+		}
+		r.conns[conn] = struct{}{}
+	}
+	return !r.closed
+}
+
+func (r *wsRelay) untrack(conns ...*websocket.Conn) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	for _, conn := range conns {
+		delete(r.conns, conn)
+	}
+}
+
+// keepAlive pings the node every ping interval of the clock until stop is closed. Once
+// nothing arrived from the node for the connection timeout, the connection to it is closed,
+// which closes the one of go-jsonrpc as well, so it reconnects.
+func (r *wsRelay) keepAlive(upstream *websocket.Conn, last *lastSeen, stop <-chan struct{}) {
+	for {
+		timer := r.opts.clock.NewTimer(r.opts.pingInterval)
+		select {
+		case <-timer.C():
+		case <-stop:
+			timer.Stop()
+			return
+		}
+		if last.since() >= r.opts.connTimeout {
+			upstream.Close()
+			return
+		}
+		if err := upstream.WriteControl(websocket.PingMessage, nil, time.Now().Add(time.Second)); err != nil {
+			return
+		}
+	}
+}
+
+// lastSeen records when something last arrived from the node, according to the clock.
+type lastSeen struct {
+	clock Clock
+
+	mu sync.Mutex
+	at time.Time
+}
+
+func (l *lastSeen) seen() {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.at = l.clock.Now()
+}
+
+// since returns the time elapsed since something last arrived from the node.
+func (l *lastSeen) since() time.Duration {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	return l.clock.Now().Sub(l.at)
+}
+
+// relayMessages writes the messages read from src to dst until either fails, calling seen,
+// if set, for every message read. Once reading fails, the close status, e.g. the one of a
+// message exceeding the limit, is sent to dst.
+func relayMessages(dst, src *websocket.Conn, tooLarge error, seen func(), done chan<- struct{}) {
+	defer func() { done <- struct{}{} }()
+	for {
+		typ, msg, err := src.ReadMessage()
+		if err != nil {
+			_ = dst.WriteControl(websocket.CloseMessage, closeMessage(err, tooLarge), time.Now().Add(time.Second))
+			return
+		}
+		if seen != nil {
+			seen()
+		}
+		if err := dst.WriteMessage(typ, msg); err != nil {
+			return
+		}
+	}
+}
+
+// closeMessage returns the close message relayed once reading failed with err.
+func closeMessage(err, tooLarge error) []byte {
+	var closeErr *websocket.CloseError
+	switch {
+	// abnormal closures are reported locally only and can't be sent
+	case errors.As(err, &closeErr) && closeErr.Code != websocket.CloseAbnormalClosure:
+		return websocket.FormatCloseMessage(closeErr.Code, closeErr.Text)
+	case errors.Is(err, websocket.ErrReadLimit):
+		return websocket.FormatCloseMessage(websocket.CloseMessageTooBig, tooLarge.Error())
+	default:
+		return websocket.FormatCloseMessage(websocket.CloseGoingAway, "")
+	}
+}